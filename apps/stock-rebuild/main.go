@@ -0,0 +1,43 @@
+// Command stock-rebuild recomputes the stock balance table from the
+// StockEntry ledger. Run it when balances drift from the ledger:
+//
+//	go run ./apps/stock-rebuild                     # all warehouses
+//	go run ./apps/stock-rebuild -warehouse <uuid>   # a single warehouse
+package main
+
+import (
+	"flag"
+	"log"
+
+	dbpkg "github.com/antoniusDoni/monorepo/core/db"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/google/uuid"
+)
+
+func main() {
+	warehouse := flag.String("warehouse", "", "Warehouse ID (UUID format) to rebuild, empty for all warehouses")
+	flag.Parse()
+
+	warehouseID := uuid.Nil
+	if *warehouse != "" {
+		id, err := uuid.Parse(*warehouse)
+		if err != nil {
+			log.Fatalf("Invalid warehouse ID '%s': %v", *warehouse, err)
+		}
+		warehouseID = id
+	}
+
+	db, err := dbpkg.GetInstance()
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	balanceService := service.NewStockBalanceService(db, repository.NewStockBalanceRepository(db), repository.NewStockPeriodRepository(db))
+	count, err := balanceService.Rebuild(warehouseID)
+	if err != nil {
+		log.Fatal("Failed to rebuild stock balances:", err)
+	}
+
+	log.Printf("Rebuilt %d stock balance rows", count)
+}
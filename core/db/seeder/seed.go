@@ -25,7 +25,40 @@ func Seed(db *gorm.DB) {
 		&warehouseModels.CategoryProduct{},
 		&warehouseModels.Product{},
 		&warehouseModels.UnitProduct{},
+		&warehouseModels.ProductUnit{},
+		&warehouseModels.ProductBarcode{},
 		&warehouseModels.StockEntry{},
+		&warehouseModels.StockBalance{},
+		&warehouseModels.StockTransfer{},
+		&warehouseModels.StockTransferLine{},
+		&warehouseModels.StockAlert{},
+		&warehouseModels.StockCount{},
+		&warehouseModels.StockCountLine{},
+		&warehouseModels.Supplier{},
+		&warehouseModels.SupplierContact{},
+		&warehouseModels.ProductSupplier{},
+		&warehouseModels.ReorderSetting{},
+		&warehouseModels.PurchaseOrder{},
+		&warehouseModels.PurchaseOrderLine{},
+		&warehouseModels.GoodsReceipt{},
+		&warehouseModels.GoodsReceiptLine{},
+		&warehouseModels.StockReservation{},
+		&warehouseModels.AdjustmentReason{},
+		&warehouseModels.StockAdjustment{},
+		&warehouseModels.StockAdjustmentLine{},
+		&warehouseModels.StockAdjustmentAttachment{},
+		&warehouseModels.SupplierReturn{},
+		&warehouseModels.SupplierReturnLine{},
+		&warehouseModels.CustomerReturn{},
+		&warehouseModels.CustomerReturnLine{},
+		&warehouseModels.StockPeriod{},
+		&warehouseModels.StockBalanceSnapshot{},
+		&warehouseModels.StockValuationSnapshot{},
+		&warehouseModels.LandedCost{},
+		&warehouseModels.LandedCostCharge{},
+		&warehouseModels.LandedCostLine{},
+		&warehouseModels.Recall{},
+		&warehouseModels.RecallTask{},
 	)
 	if err != nil {
 		log.Fatalf("AutoMigrate failed: %v", err)
@@ -48,6 +81,12 @@ func Seed(db *gorm.DB) {
 		log.Fatalf("Failed to seed role admin: %v", err)
 	}
 
+	// Seed the QC role for batch release decisions, assigned to QC staff by an admin
+	qcRole := models.Role{Name: "qc"}
+	if err := db.FirstOrCreate(&qcRole, models.Role{Name: qcRole.Name}).Error; err != nil {
+		log.Fatalf("Failed to seed role qc: %v", err)
+	}
+
 	// Assign Permissions to Role (RolePermission)
 	if err := db.Model(&adminRole).Association("Permissions").Replace(permissions); err != nil {
 		log.Printf("Failed to assign permissions to admin role: %v", err)
@@ -74,36 +113,45 @@ func Seed(db *gorm.DB) {
 		log.Printf("Failed to assign role to admin user: %v", err)
 	}
 
+	const (
+		mass   = warehouseModels.UnitDimensionMass
+		volume = warehouseModels.UnitDimensionVolume
+		length = warehouseModels.UnitDimensionLength
+		count  = warehouseModels.UnitDimensionCount
+	)
+	// Factors are to the SI base of each dimension: kg, m³ and m. Packaging
+	// units count items but their size differs per product, so they have none.
 	units := []warehouseModels.UnitProduct{
 		// Metric
-		{ID: uuid.New(), Code: "pcs", Name: "Piece"},
-		{ID: uuid.New(), Code: "kg", Name: "Kilogram"},
-		{ID: uuid.New(), Code: "g", Name: "Gram"},
-		{ID: uuid.New(), Code: "mg", Name: "Milligram"},
-		{ID: uuid.New(), Code: "l", Name: "Liter"},
-		{ID: uuid.New(), Code: "ml", Name: "Milliliter"},
-		{ID: uuid.New(), Code: "m", Name: "Meter"},
-		{ID: uuid.New(), Code: "cm", Name: "Centimeter"},
-		{ID: uuid.New(), Code: "mm", Name: "Millimeter"},
-
-		// Imperial
-		{ID: uuid.New(), Code: "lb", Name: "Pound"},
-		{ID: uuid.New(), Code: "oz", Name: "Ounce"},
-		{ID: uuid.New(), Code: "gal", Name: "Gallon"},
-		{ID: uuid.New(), Code: "qt", Name: "Quart"},
-		{ID: uuid.New(), Code: "pt", Name: "Pint"},
-		{ID: uuid.New(), Code: "ft", Name: "Foot"},
-		{ID: uuid.New(), Code: "in", Name: "Inch"},
+		{ID: uuid.New(), Code: "pcs", Name: "Piece", Dimension: count, Factor: 1},
+		{ID: uuid.New(), Code: "kg", Name: "Kilogram", Dimension: mass, Factor: 1},
+		{ID: uuid.New(), Code: "g", Name: "Gram", Dimension: mass, Factor: 0.001},
+		{ID: uuid.New(), Code: "mg", Name: "Milligram", Dimension: mass, Factor: 0.000001},
+		{ID: uuid.New(), Code: "l", Name: "Liter", Dimension: volume, Factor: 0.001},
+		{ID: uuid.New(), Code: "ml", Name: "Milliliter", Dimension: volume, Factor: 0.000001},
+		{ID: uuid.New(), Code: "m", Name: "Meter", Dimension: length, Factor: 1},
+		{ID: uuid.New(), Code: "cm", Name: "Centimeter", Dimension: length, Factor: 0.01},
+		{ID: uuid.New(), Code: "mm", Name: "Millimeter", Dimension: length, Factor: 0.001},
+
+		// Imperial (US liquid measures)
+		{ID: uuid.New(), Code: "lb", Name: "Pound", Dimension: mass, Factor: 0.45359237},
+		{ID: uuid.New(), Code: "oz", Name: "Ounce", Dimension: mass, Factor: 0.028349523125},
+		{ID: uuid.New(), Code: "gal", Name: "Gallon", Dimension: volume, Factor: 0.003785411784},
+		{ID: uuid.New(), Code: "qt", Name: "Quart", Dimension: volume, Factor: 0.000946352946},
+		{ID: uuid.New(), Code: "pt", Name: "Pint", Dimension: volume, Factor: 0.000473176473},
+		{ID: uuid.New(), Code: "ft", Name: "Foot", Dimension: length, Factor: 0.3048},
+		{ID: uuid.New(), Code: "in", Name: "Inch", Dimension: length, Factor: 0.0254},
 
 		// Packaged/Other
-		{ID: uuid.New(), Code: "box", Name: "Box"},
-		{ID: uuid.New(), Code: "bag", Name: "Bag"},
-		{ID: uuid.New(), Code: "btl", Name: "Bottle"},
-		{ID: uuid.New(), Code: "can", Name: "Can"},
-		{ID: uuid.New(), Code: "roll", Name: "Roll"},
-		{ID: uuid.New(), Code: "pack", Name: "Pack"},
-		{ID: uuid.New(), Code: "carton", Name: "Carton"},
-		{ID: uuid.New(), Code: "set", Name: "Set"},
+		{ID: uuid.New(), Code: "box", Name: "Box", Dimension: count},
+		{ID: uuid.New(), Code: "bag", Name: "Bag", Dimension: count},
+		{ID: uuid.New(), Code: "btl", Name: "Bottle", Dimension: count},
+		{ID: uuid.New(), Code: "can", Name: "Can", Dimension: count},
+		{ID: uuid.New(), Code: "roll", Name: "Roll", Dimension: count},
+		{ID: uuid.New(), Code: "pack", Name: "Pack", Dimension: count},
+		{ID: uuid.New(), Code: "carton", Name: "Carton", Dimension: count},
+		{ID: uuid.New(), Code: "set", Name: "Set", Dimension: count},
+		{ID: uuid.New(), Code: "strip", Name: "Strip", Dimension: count},
 	}
 
 	for _, unit := range units {
@@ -117,6 +165,33 @@ func Seed(db *gorm.DB) {
 			} else {
 				log.Printf("✅ Seeded unit: %s", unit.Code)
 			}
+		} else if err == nil && existing.Dimension == "" {
+			// Units seeded before dimensions existed get their standard factor
+			if err := db.Model(&existing).Updates(map[string]interface{}{"dimension": unit.Dimension, "factor": unit.Factor}).Error; err != nil {
+				log.Printf("❌ Failed to update unit %s: %v", unit.Code, err)
+			}
+		}
+	}
+
+	reasons := []warehouseModels.AdjustmentReason{
+		{Code: "DAMAGE", Name: "Damaged goods", Direction: warehouseModels.AdjustmentDirectionDecrease},
+		{Code: "LOSS", Name: "Lost or stolen", Direction: warehouseModels.AdjustmentDirectionDecrease, RequiresApproval: true},
+		{Code: "SAMPLE", Name: "Samples", Direction: warehouseModels.AdjustmentDirectionDecrease},
+		{Code: "EXPIRED", Name: "Expired stock", Direction: warehouseModels.AdjustmentDirectionDecrease},
+		{Code: "FOUND", Name: "Found stock", Direction: warehouseModels.AdjustmentDirectionIncrease},
+		{Code: "CORRECTION", Name: "Data correction", Direction: warehouseModels.AdjustmentDirectionBoth},
+	}
+
+	for _, reason := range reasons {
+		var existing warehouseModels.AdjustmentReason
+		err := db.Where("code = ?", reason.Code).First(&existing).Error
+		if err == gorm.ErrRecordNotFound {
+			reason.Status = warehouseModels.AdjustmentReasonStatusActive
+			if err := db.Create(&reason).Error; err != nil {
+				log.Printf("❌ Failed to seed adjustment reason %s: %v", reason.Code, err)
+			} else {
+				log.Printf("✅ Seeded adjustment reason: %s", reason.Code)
+			}
 		}
 	}
 
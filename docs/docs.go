@@ -204,6 +204,283 @@ const docTemplate = `{
                 }
             }
         },
+        "/v1/api/adjustment-reasons": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves every adjustment reason code ordered by code, optionally filtered by status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-adjustments"
+                ],
+                "summary": "Get adjustment reasons",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reason status (active, inactive)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AdjustmentReason"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a reason code that adjustment lines can carry. Direction limits the reason to increases or decreases.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-adjustments"
+                ],
+                "summary": "Create an adjustment reason",
+                "parameters": [
+                    {
+                        "description": "Reason data",
+                        "name": "reason",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdjustmentReasonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.AdjustmentReason"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/adjustment-reasons/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a single adjustment reason code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-adjustments"
+                ],
+                "summary": "Get adjustment reason by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Adjustment Reason ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AdjustmentReason"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces a reason code. Set the status to inactive to retire a reason; posted movements keep the code they were recorded with.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-adjustments"
+                ],
+                "summary": "Update an adjustment reason",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Adjustment Reason ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason data",
+                        "name": "reason",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdjustmentReasonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AdjustmentReason"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/v1/api/category-products": {
             "get": {
                 "security": [
@@ -684,14 +961,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/api/offices": {
+        "/v1/api/customer-returns": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves paginated offices optionally filtered by search term",
+                "description": "Retrieves paginated customer returns, newest first, optionally filtered by warehouse and status",
                 "consumes": [
                     "application/json"
                 ],
@@ -699,26 +976,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "offices"
+                    "returns"
                 ],
-                "summary": "Get list of offices",
+                "summary": "Get customer returns",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Page number",
+                        "description": "Page number (default: 1)",
                         "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Page size",
+                        "description": "Page size (default: 10)",
                         "name": "pageSize",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Search term",
-                        "name": "searchTerm",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return status (draft, posted, cancelled)",
+                        "name": "status",
                         "in": "query"
                     }
                 ],
@@ -729,6 +1012,12 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -749,7 +1038,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new office with the provided information",
+                "description": "Drafts a return of stock issued with the origin document into a chosen warehouse. Batch, expiry and cost come from the original issue and no more than was issued can come back.",
                 "consumes": [
                     "application/json"
                 ],
@@ -757,17 +1046,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "offices"
+                    "returns"
                 ],
-                "summary": "Create a new office",
+                "summary": "Create a customer return",
                 "parameters": [
                     {
-                        "description": "Office data",
-                        "name": "office",
+                        "description": "Return data",
+                        "name": "return",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Office"
+                            "$ref": "#/definitions/dto.CustomerReturnRequest"
                         }
                     }
                 ],
@@ -775,81 +1064,62 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Office"
+                            "$ref": "#/definitions/model.CustomerReturn"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object"
-                        }
-                    }
-                }
-            }
-        },
-        "/v1/api/offices/active": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve all offices with active status",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "offices"
-                ],
-                "summary": "Get active offices",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                        "description": "Validation error",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Office"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
                             }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/api/offices/{id}": {
+        "/v1/api/customer-returns/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a specific office by its ID",
+                "description": "Retrieve a customer return with its lines",
                 "consumes": [
                     "application/json"
                 ],
@@ -857,13 +1127,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "offices"
+                    "returns"
                 ],
-                "summary": "Get office by ID",
+                "summary": "Get customer return by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Office ID",
+                        "description": "Customer Return ID (UUID format)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -873,7 +1143,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Office"
+                            "$ref": "#/definitions/model.CustomerReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
                         }
                     },
                     "401": {
@@ -887,16 +1163,24 @@ const docTemplate = `{
                         "schema": {
                             "type": "object"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/v1/api/customer-returns/{id}/cancel": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing office with new information",
+                "description": "Cancels a return that has not been posted. No stock is moved.",
                 "consumes": [
                     "application/json"
                 ],
@@ -904,61 +1188,78 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "offices"
+                    "returns"
                 ],
-                "summary": "Update an office",
+                "summary": "Cancel a customer return",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Office ID",
+                        "description": "Customer Return ID (UUID format)",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated office data",
-                        "name": "office",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Office"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Office"
+                            "$ref": "#/definitions/model.CustomerReturn"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Return already posted or cancelled",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/api/customer-returns/{id}/post": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete an office by its ID",
+                "description": "Receives every line into the warehouse as return_in stock entries carrying the origin document in reference_id. Quarantine lines are held and cannot be allocated, reserved or issued.",
                 "consumes": [
                     "application/json"
                 ],
@@ -966,48 +1267,78 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "offices"
+                    "returns"
                 ],
-                "summary": "Delete an office",
+                "summary": "Post a customer return",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Office ID",
+                        "description": "Customer Return ID (UUID format)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/model.CustomerReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Return not in draft, quantity over the issue or QC status conflict",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/api/products": {
+        "/v1/api/landed-costs": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves paginated products optionally filtered by search term",
+                "description": "Retrieves paginated landed cost documents, newest first, optionally filtered by goods receipt and status",
                 "consumes": [
                     "application/json"
                 ],
@@ -1015,9 +1346,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "landed-costs"
                 ],
-                "summary": "Get list of products",
+                "summary": "Get landed costs",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1033,8 +1364,14 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Search term to filter products by name or description",
-                        "name": "searchTerm",
+                        "description": "Goods Receipt ID (UUID format)",
+                        "name": "receiptId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Landed cost status (draft, posted, reversed, cancelled)",
+                        "name": "status",
                         "in": "query"
                     }
                 ],
@@ -1071,7 +1408,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new product with the provided information. Category must exist and be valid. Request body should only contain category_id, not the full category object.",
+                "description": "Drafts freight, insurance, duty or other charges against a goods receipt and previews their allocation over the receipt lines by value (quantity × price), quantity or weight. Quantities are net of supplier returns. Without lines every receipt line with stock left shares the charges; the weight method needs each line with its weight.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1079,29 +1416,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "landed-costs"
                 ],
-                "summary": "Create a new product",
+                "summary": "Create a landed cost",
                 "parameters": [
                     {
-                        "description": "Product data",
-                        "name": "product",
+                        "description": "Landed cost data",
+                        "name": "landedCost",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ProductCreateRequest"
+                            "$ref": "#/definitions/dto.LandedCostRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Product created successfully",
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Product"
+                            "$ref": "#/definitions/model.LandedCost"
                         }
                     },
                     "400": {
-                        "description": "Validation error (invalid input or category not found)",
+                        "description": "Validation error",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -1145,14 +1482,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/api/products/{id}": {
+        "/v1/api/landed-costs/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a specific product by its ID",
+                "description": "Retrieve a landed cost with its charges and allocation lines",
                 "consumes": [
                     "application/json"
                 ],
@@ -1160,13 +1497,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "landed-costs"
                 ],
-                "summary": "Get product by ID",
+                "summary": "Get landed cost by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID (UUID format)",
+                        "description": "Landed Cost ID (UUID format)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1176,7 +1513,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Product"
+                            "$ref": "#/definitions/model.LandedCost"
                         }
                     },
                     "400": {
@@ -1204,14 +1541,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/v1/api/landed-costs/{id}/cancel": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing product with new information. Category must exist and be valid. Request body should only contain category_id, not the full category object.",
+                "description": "Cancels a landed cost that has not been posted. No cost is changed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1219,36 +1558,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "landed-costs"
                 ],
-                "summary": "Update a product",
+                "summary": "Cancel a landed cost",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID (UUID format)",
+                        "description": "Landed Cost ID (UUID format)",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated product data",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ProductUpdateRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product updated successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Product"
+                            "$ref": "#/definitions/model.LandedCost"
                         }
                     },
                     "400": {
-                        "description": "Validation error (invalid input or category not found)",
+                        "description": "Landed cost already posted or cancelled",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -1275,8 +1605,73 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Product not found",
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/landed-costs/{id}/post": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allocates the charges again over the quantities still held after supplier returns and adds each line's share per small unit to the landed cost of its receipt line. From the landed cost date on, valuation counts the receipt at its purchase price plus the landed cost. The date must fall after the latest closed stock period.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "landed-costs"
+                ],
+                "summary": "Post a landed cost",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Landed Cost ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LandedCost"
+                        }
+                    },
+                    "400": {
+                        "description": "Landed cost not in draft or dated in a closed period",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -1304,14 +1699,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/api/landed-costs/{id}/reverse": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a product by its ID",
+                "description": "Takes a posted landed cost back out of the receipt line costs. The document and its allocation are kept with who reversed it, when and why. Valuation as of any moment before the reversal still includes it. Landed costs dated in a closed stock period cannot be reversed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1319,60 +1716,87 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "landed-costs"
                 ],
-                "summary": "Delete a product",
+                "summary": "Reverse a landed cost",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID (UUID format)",
+                        "description": "Landed Cost ID (UUID format)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Reversal reason",
+                        "name": "reverse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LandedCostReverseRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.LandedCost"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Validation error, landed cost not posted or dated in a closed period",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/api/unit-products": {
+        "/v1/api/offices": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves paginated unit products optionally filtered by search term",
+                "description": "Retrieves paginated offices optionally filtered by search term",
                 "consumes": [
                     "application/json"
                 ],
@@ -1380,25 +1804,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "unit-products"
+                    "offices"
                 ],
-                "summary": "Get list of unit products",
+                "summary": "Get list of offices",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Page number (default: 1)",
+                        "description": "Page number",
                         "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Page size (default: 10)",
+                        "description": "Page size",
                         "name": "pageSize",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Search term to filter unit products by name",
+                        "description": "Search term",
                         "name": "searchTerm",
                         "in": "query"
                     }
@@ -1410,12 +1834,6 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -1436,7 +1854,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new unit product with the provided information",
+                "description": "Create a new office with the provided information",
                 "consumes": [
                     "application/json"
                 ],
@@ -1444,17 +1862,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "unit-products"
+                    "offices"
                 ],
-                "summary": "Create a new unit product",
+                "summary": "Create a new office",
                 "parameters": [
                     {
-                        "description": "Unit Product data",
-                        "name": "unitProduct",
+                        "description": "Office data",
+                        "name": "office",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UnitProduct"
+                            "$ref": "#/definitions/model.Office"
                         }
                     }
                 ],
@@ -1462,7 +1880,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.UnitProduct"
+                            "$ref": "#/definitions/model.Office"
                         }
                     },
                     "400": {
@@ -1486,14 +1904,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/api/unit-products/{id}": {
+        "/v1/api/offices/active": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a specific unit product by its ID",
+                "description": "Retrieve all offices with active status",
                 "consumes": [
                     "application/json"
                 ],
@@ -1501,13 +1919,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "unit-products"
+                    "offices"
                 ],
-                "summary": "Get unit product by ID",
+                "summary": "Get active offices",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Office"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/offices/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific office by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offices"
+                ],
+                "summary": "Get office by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Unit Product ID (UUID format)",
+                        "description": "Office ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1517,13 +1978,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UnitProduct"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/model.Office"
                         }
                     },
                     "401": {
@@ -1532,8 +1987,8 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object"
                         }
@@ -1546,7 +2001,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing unit product with new information",
+                "description": "Update an existing office with new information",
                 "consumes": [
                     "application/json"
                 ],
@@ -1554,24 +2009,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "unit-products"
+                    "offices"
                 ],
-                "summary": "Update a unit product",
+                "summary": "Update an office",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Unit Product ID (UUID format)",
+                        "description": "Office ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated unit product data",
-                        "name": "unitProduct",
+                        "description": "Updated office data",
+                        "name": "office",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UnitProduct"
+                            "$ref": "#/definitions/model.Office"
                         }
                     }
                 ],
@@ -1579,7 +2034,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UnitProduct"
+                            "$ref": "#/definitions/model.Office"
                         }
                     },
                     "400": {
@@ -1608,7 +2063,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a unit product by its ID",
+                "description": "Delete an office by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1616,13 +2071,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "unit-products"
+                    "offices"
                 ],
-                "summary": "Delete a unit product",
+                "summary": "Delete an office",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Unit Product ID (UUID format)",
+                        "description": "Office ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1631,12 +2086,6 @@ const docTemplate = `{
                 "responses": {
                     "204": {
                         "description": "No Content",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
                         "schema": {
                             "type": "object"
                         }
@@ -1656,14 +2105,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/api/warehouses": {
+        "/v1/api/products": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves paginated warehouses optionally filtered by search term",
+                "description": "Retrieves paginated products optionally filtered by search term",
                 "consumes": [
                     "application/json"
                 ],
@@ -1671,26 +2120,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "warehouses"
+                    "products"
                 ],
-                "summary": "Get list of warehouses",
+                "summary": "Get list of products",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Page number",
+                        "description": "Page number (default: 1)",
                         "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Page size",
+                        "description": "Page size (default: 10)",
                         "name": "pageSize",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Search term",
-                        "name": "search",
+                        "description": "Search term to filter products by name or description",
+                        "name": "searchTerm",
                         "in": "query"
                     }
                 ],
@@ -1727,7 +2176,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new warehouse with the provided information",
+                "description": "Create a new product with the provided information. Category must exist and be valid. Request body should only contain category_id, not the full category object. Barcodes need a valid check digit, a unit of the product and must not belong to another product.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1735,56 +2184,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "warehouses"
+                    "products"
                 ],
-                "summary": "Create a new warehouse",
+                "summary": "Create a new product",
                 "parameters": [
                     {
-                        "description": "Warehouse data",
-                        "name": "warehouse",
+                        "description": "Product data",
+                        "name": "product",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Warehouse"
+                            "$ref": "#/definitions/dto.ProductCreateRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created",
+                        "description": "Product created successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.Warehouse"
+                            "$ref": "#/definitions/model.Product"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Validation error (invalid input or category not found)",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/api/warehouses/{id}": {
+        "/v1/api/products/barcode/{code}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a specific warehouse by its ID",
+                "description": "Returns the product and the packaging unit of a scanned EAN-13, EAN-8 or UPC-A barcode. UPC-A codes also match their EAN-13 form with a leading zero. Pass the unit code as the unit of stock quantities to receive or count in scanned packages.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1792,13 +2265,106 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "warehouses"
+                    "products"
                 ],
-                "summary": "Get warehouse by ID",
+                "summary": "Look up a product by barcode",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Warehouse ID",
+                        "type": "string",
+                        "description": "Scanned barcode",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.BarcodeMatch"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid barcode or check digit",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Barcode not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/products/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific product by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get product by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1808,7 +2374,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Warehouse"
+                            "$ref": "#/definitions/model.Product"
                         }
                     },
                     "400": {
@@ -1843,7 +2409,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing warehouse with new information",
+                "description": "Update an existing product with new information. Category must exist and be valid. Request body should only contain category_id, not the full category object. Barcodes need a valid check digit, a unit of the product and must not belong to another product. Omit units or barcodes to keep the current packaging hierarchy or barcodes. Once the product has stock, its base unit and the factors of its existing units cannot change.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1851,50 +2417,88 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "warehouses"
+                    "products"
                 ],
-                "summary": "Update a warehouse",
+                "summary": "Update a product",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Warehouse ID",
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated warehouse data",
-                        "name": "warehouse",
+                        "description": "Updated product data",
+                        "name": "product",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Warehouse"
+                            "$ref": "#/definitions/dto.ProductUpdateRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Product updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.Warehouse"
+                            "$ref": "#/definitions/model.Product"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Validation error (invalid input or category not found)",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object"
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     }
                 }
@@ -1905,7 +2509,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a warehouse by its ID",
+                "description": "Delete a product by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1913,13 +2517,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "warehouses"
+                    "products"
                 ],
-                "summary": "Delete a warehouse",
+                "summary": "Delete a product",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Warehouse ID",
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1929,7 +2533,7 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content",
                         "schema": {
-                            "type": "object"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1944,6 +2548,12 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1952,500 +2562,11906 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "contract.LoginRequest": {
-            "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
-            "properties": {
-                "password": {
-                    "type": "string",
-                    "example": "securepassword123"
-                },
-                "username": {
+        },
+        "/v1/api/products/{id}/forecast": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Projects demand per warehouse from outgoing stock movements (issues and transfers out) using a simple moving average or exponential smoothing, with a 95% confidence band. Seasonality is detected and applied once two years of history are available. The auto method picks whichever fits the history best.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "forecasts"
+                ],
+                "summary": "Get demand forecast of a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format), all warehouses with demand when empty",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Forecast method (auto, moving_average, exponential_smoothing; default: auto)",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period length (month, week; default: month)",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Complete periods of history to fit (default: 24 months or 104 weeks)",
+                        "name": "history",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Periods to forecast, starting with the current one (default: 3)",
+                        "name": "horizon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Moving average window in periods (default: 3)",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Exponential smoothing factor between 0 and 1 (default: 0.3)",
+                        "name": "alpha",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ProductForecast"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/products/{id}/stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated non-zero stock balances per warehouse and batch for a product. With asOf the balances are rebuilt from the stock ledger as of that moment, starting from the snapshot of the latest closed period.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-balances"
+                ],
+                "summary": "Get on-hand stock of a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Point in time (YYYY-MM-DD or RFC 3339, default: current balances)",
+                        "name": "asOf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/products/{id}/suppliers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the suppliers that sell a product with their SKU, lead time, minimum order quantity and last purchase price, preferred supplier first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get suppliers of a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ProductSupplier"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates or replaces a supplier's catalog entry for a product. Marking it preferred unmarks the product's other suppliers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Add or update a product supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Catalog entry",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductSupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductSupplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/products/{id}/suppliers/{supplierId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a supplier from a product's catalog",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Remove a product supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Supplier ID (UUID format)",
+                        "name": "supplierId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/purchase-orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated purchase orders, newest first, optionally filtered by warehouse and status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Get list of purchase orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order status (draft, approved, partially_received, received, closed)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a draft purchase order. Lines may be ordered in the product's large or small unit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Create a purchase order",
+                "parameters": [
+                    {
+                        "description": "Purchase order data",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/purchase-orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a purchase order with its lines and received quantities",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Get purchase order by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase Order ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the warehouse, supplier, dates, notes and lines of an order that is still in draft",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Update a draft purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase Order ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Purchase order data",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or order not in draft",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/purchase-orders/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Releases a draft purchase order for receiving",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Approve a purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase Order ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Order not in draft",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/purchase-orders/{id}/close": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Finishes an approved or (partially) received order; any outstanding quantity will no longer be received",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Close a purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase Order ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Order in draft or already closed",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/purchase-orders/{id}/receipts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists every delivery booked against a purchase order, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Get goods receipts of a purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase Order ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.GoodsReceipt"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Books delivered batches into the order's warehouse as receipt stock entries carrying the order ID, batch number and expiry. Over-receipt and price variance beyond the configured tolerances are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Receive goods against a purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase Order ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Received batches",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GoodsReceiptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, tolerance exceeded or order not approved",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/qc/batches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated batches on hand in a QC status, soonest expiry first. Without a status every batch awaiting or failing QC (quarantine or rejected) is listed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "qc"
+                ],
+                "summary": "Get batches by QC status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
+                        "name": "productId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "QC status (released, quarantine, rejected)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/qc/hold": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Puts a released batch back in quarantine pending a QC decision. The batch stays on hand but cannot be allocated, reserved or issued. Requires the QC role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "qc"
+                ],
+                "summary": "Hold a batch in quarantine",
+                "parameters": [
+                    {
+                        "description": "QC decision",
+                        "name": "decision",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.QCDecisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or batch not released",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User lacks the QC role",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/qc/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rejects a quarantined batch. Rejected stock can only leave through a supplier return or a write-off for disposal. Notes are required as the rejection reason. Requires the QC role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "qc"
+                ],
+                "summary": "Reject a quarantined batch",
+                "parameters": [
+                    {
+                        "description": "QC decision",
+                        "name": "decision",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.QCDecisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or batch not in quarantine",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User lacks the QC role",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/qc/release": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Releases a quarantined batch after QC so it counts as available and can be allocated, reserved and issued. Records a qc_change ledger entry. Requires the QC role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "qc"
+                ],
+                "summary": "Release a quarantined batch",
+                "parameters": [
+                    {
+                        "description": "QC decision",
+                        "name": "decision",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.QCDecisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or batch not in quarantine",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User lacks the QC role",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/recalls": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated batch recalls, newest first, optionally filtered by product and status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recalls"
+                ],
+                "summary": "Get recalls",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
+                        "name": "productId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Recall status (open, closed)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Opens a recall of one product batch. The batch is rejected by QC in every warehouse holding it so it can no longer be allocated, reserved, transferred or issued, and a return or disposal task is created for each of those warehouses. Stock of the batch received while the recall is open comes in rejected, and a warehouse without an open task for the recall gets a new one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recalls"
+                ],
+                "summary": "Open a recall",
+                "parameters": [
+                    {
+                        "description": "Recall data",
+                        "name": "recall",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RecallRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Recall"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or batch already under recall",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/recalls/trace": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reports where a batch came from, which documents it was issued to, where it is still on hand and every movement of it, before or without opening a recall",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recalls"
+                ],
+                "summary": "Trace a batch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
+                        "name": "productId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Batch number",
+                        "name": "batchNumber",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.BatchTrace"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/recalls/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a recall with its warehouse tasks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recalls"
+                ],
+                "summary": "Get recall by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recall ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Recall"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/recalls/{id}/close": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Closes a recall once every task is done and the batch is no longer on hand in any warehouse. Stock of the batch received afterwards is no longer rejected automatically.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recalls"
+                ],
+                "summary": "Close a recall",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recall ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Recall"
+                        }
+                    },
+                    "400": {
+                        "description": "Recall already closed, tasks still open or batch still on hand",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/recalls/{id}/tasks/{taskId}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves what is left of the batch out of the task's warehouse, as a return_out for returns or a write_off for disposals, and marks the task done. Stock that already left the warehouse is not moved again. Controlled products need the authorizing user and the counterparty.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recalls"
+                ],
+                "summary": "Complete a recall task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recall ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Recall Task ID (UUID format)",
+                        "name": "taskId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Completion data",
+                        "name": "task",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RecallTaskCompleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Recall"
+                        }
+                    },
+                    "400": {
+                        "description": "Recall closed, task already done or the batch cannot be moved",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/recalls/{id}/trace": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reports where the recalled batch came from, which documents it was issued to, where it is still on hand and every movement of it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recalls"
+                ],
+                "summary": "Trace a recalled batch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recall ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.BatchTrace"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/reorder-settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated min/max and reorder point settings, optionally filtered by warehouse and product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reorder"
+                ],
+                "summary": "Get reorder settings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
+                        "name": "productId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates or replaces the minimum stock, reorder point and maximum stock of a product in a warehouse",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reorder"
+                ],
+                "summary": "Set reorder levels",
+                "parameters": [
+                    {
+                        "description": "Reorder levels",
+                        "name": "setting",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReorderSettingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReorderSetting"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/reorder-settings/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stops replenishment suggestions for a product in a warehouse",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reorder"
+                ],
+                "summary": "Delete a reorder setting",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reorder Setting ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/reorder-suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compares released, unexpired on-hand stock plus the quantity still to be received on approved purchase orders, less the demand forecast over the preferred supplier's lead time, with the reorder settings and suggests order quantities up to maximum stock plus that demand, rounded up to whole large units",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reorder"
+                ],
+                "summary": "Get replenishment suggestions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Office ID (UUID format)",
+                        "name": "officeId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.ReorderSuggestion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-adjustments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated adjustment documents, newest first, optionally filtered by warehouse and status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-adjustments"
+                ],
+                "summary": "Get stock adjustments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Adjustment status (draft, pending_approval, approved, rejected, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a draft adjustment document. Every line needs an active reason code whose direction matches the sign of its quantity. No stock moves until the document is approved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-adjustments"
+                ],
+                "summary": "Create a stock adjustment",
+                "parameters": [
+                    {
+                        "description": "Adjustment data",
+                        "name": "adjustment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockAdjustment"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-adjustments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve an adjustment document with its lines and attachments",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-adjustments"
+                ],
+                "summary": "Get stock adjustment by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Adjustment ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockAdjustment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the warehouse, date, notes, authorization, counterparty and lines of an adjustment that is still in draft",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-adjustments"
+                ],
+                "summary": "Update a stock adjustment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Adjustment ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Adjustment data",
+                        "name": "adjustment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockAdjustment"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or adjustment not in draft",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-adjustments/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Posts every line as an adjustment stock entry carrying its reason code. Controlled product lines without an authorizing user are authorized by the approver. Only users with the adjustment approver role may approve, and the submitter cannot approve their own adjustment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-adjustments"
+                ],
+                "summary": "Approve a stock adjustment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Adjustment ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockAdjustment"
+                        }
+                    },
+                    "400": {
+                        "description": "Adjustment not pending approval, same approver or insufficient stock",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User lacks the adjustment approver role",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-adjustments/{id}/attachments": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Links a file already stored in file storage (photo, incident report) to an adjustment. Posted adjustments still accept attachments.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-adjustments"
+                ],
+                "summary": "Attach evidence to a stock adjustment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Adjustment ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Attachment data",
+                        "name": "attachment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockAdjustmentAttachmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockAdjustmentAttachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or adjustment rejected or cancelled",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-adjustments/{id}/attachments/{attachmentId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes evidence while the adjustment is in draft or waiting for approval",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-adjustments"
+                ],
+                "summary": "Remove an attachment from a stock adjustment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Adjustment ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID (UUID format)",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Attachment not found or adjustment already posted",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-adjustments/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels an adjustment that has not been posted. No stock is moved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-adjustments"
+                ],
+                "summary": "Cancel a stock adjustment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Adjustment ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockAdjustment"
+                        }
+                    },
+                    "400": {
+                        "description": "Adjustment already posted, rejected or cancelled",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-adjustments/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rejects an adjustment waiting for approval. No stock is moved. Only users with the adjustment approver role may reject.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-adjustments"
+                ],
+                "summary": "Reject a stock adjustment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Adjustment ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection reason",
+                        "name": "reject",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockAdjustmentRejectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockAdjustment"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or adjustment not pending approval",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User lacks the adjustment approver role",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-adjustments/{id}/submit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Values every line at batch cost and totals the document. Documents above the approval threshold, or carrying a reason that always needs approval, wait for approval; the rest are approved and posted immediately. Lines of controlled products need the counterparty, and the authorizing user unless the document waits for approval.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-adjustments"
+                ],
+                "summary": "Submit a stock adjustment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Adjustment ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockAdjustment"
+                        }
+                    },
+                    "400": {
+                        "description": "Adjustment not in draft or insufficient stock",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-alerts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated alerts raised by the daily stock scan, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-alerts"
+                ],
+                "summary": "Get list of stock alerts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alert type (near_expiry, expired)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by acknowledgement",
+                        "name": "acknowledged",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-alerts/{id}/acknowledge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks an alert as seen by the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-alerts"
+                ],
+                "summary": "Acknowledge a stock alert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Alert ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockAlert"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-availability": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns on-hand stock minus active reservations per warehouse and product. Expired batches are left out of on-hand. Filter by office to see every warehouse of that office.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-reservations"
+                ],
+                "summary": "Get available-to-promise stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
+                        "name": "productId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Office ID (UUID format)",
+                        "name": "officeId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.AvailabilityRow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-counts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated stock count sessions, newest first. Expected quantities of blind counts are hidden until counting is submitted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Get list of stock counts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Count status (counting, submitted, approved, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Starts a stock opname for a warehouse and freezes the current balance of every product and batch as the expected quantity",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Open a stock count",
+                "parameters": [
+                    {
+                        "description": "Stock count data",
+                        "name": "count",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockCountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-counts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a stock count with its lines. Expected quantities and variances of blind counts are hidden until counting is submitted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Get stock count by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Count ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-counts/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Posts every variance as an adjustment stock entry referencing the count session. Variances are recomputed against the balances at approval, and every counted batch ends up on exactly its counted quantity whatever moved since the snapshot. The approving user authorizes the variances; those of controlled products also need the counterparty.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Approve a stock count",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Count ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Approval data",
+                        "name": "approve",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockCountApproveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, count not submitted or insufficient stock",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-counts/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels a count that has not been approved. No stock is adjusted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Cancel a stock count",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Count ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Count already approved or cancelled",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-counts/{id}/counts": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stores counted quantities by line, or by product and batch. Stock found outside the snapshot is added with an expected quantity of zero.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Record counted quantities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Count ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Counted quantities",
+                        "name": "counts",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockCountEntriesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or count not open",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-counts/{id}/submit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Finishes counting and reveals variances for supervisor review. Every line must have been counted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Submit a stock count",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Count ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Uncounted lines or count not open",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-entries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated stock movements, newest first, optionally filtered by warehouse, product, batch, status and reason code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-entries"
+                ],
+                "summary": "Get stock ledger",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
+                        "name": "productId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Batch number",
+                        "name": "batchNumber",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Movement status (receipt, issue, adjustment, transfer_out, transfer_in, write_off, return_out, return_in, qc_change)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Adjustment reason code",
+                        "name": "reasonCode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-entries/issues": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Appends an outgoing movement to the ledger. Without a batch number the quantity is allocated across batches using the warehouse issue policy (FEFO by default, FIFO optional); expired batches are skipped and cannot be issued until written off, quarantined and rejected batches are skipped and cannot be issued at all. Stock reserved for other documents cannot be issued. Concurrent issues of the same product are serialized, so stock never drops below zero unless the warehouse allows negative stock. Returns one entry per consumed batch.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-entries"
+                ],
+                "summary": "Record a stock issue",
+                "parameters": [
+                    {
+                        "description": "Issue data",
+                        "name": "issue",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockIssueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StockEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or insufficient stock",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-entries/receipts": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Appends an incoming movement to the ledger. Previous stock is taken from the last entry of the same warehouse, product and batch. Receipts into warehouses that quarantine receipts are always held in quarantine until QC releases them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-entries"
+                ],
+                "summary": "Record a stock receipt",
+                "parameters": [
+                    {
+                        "description": "Receipt data",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockReceiptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-entries/write-offs": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes expired or unusable stock from a batch. Expired batches can only leave stock through a write-off.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-entries"
+                ],
+                "summary": "Write off a batch",
+                "parameters": [
+                    {
+                        "description": "Write-off data",
+                        "name": "writeOff",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockWriteOffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or insufficient stock",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-entries/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a single stock movement by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-entries"
+                ],
+                "summary": "Get stock entry by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Entry ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-periods": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated stock periods, latest month first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-periods"
+                ],
+                "summary": "Get list of stock periods",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period status (open, closed)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-periods/close": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Closes a past month: snapshots the closing stock balances and the valuation report (overall and per office), and rejects any later stock movement dated within or before the month. Balance and valuation queries as of the month end are then served from the snapshots. Months close in calendar order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-periods"
+                ],
+                "summary": "Close a stock period",
+                "parameters": [
+                    {
+                        "description": "Period to close",
+                        "name": "period",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockPeriodCloseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockPeriod"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid, unfinished or already closed period",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-periods/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a single stock period by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-periods"
+                ],
+                "summary": "Get stock period by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Period ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockPeriod"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-periods/{id}/reopen": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reopens the latest closed period and drops its snapshots so backdated corrections can be posted. The period has to be closed again afterwards.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-periods"
+                ],
+                "summary": "Reopen a stock period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Period ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockPeriod"
+                        }
+                    },
+                    "400": {
+                        "description": "Period not closed or not the latest closed period",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-reports/abc": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ranks products by the value of stock issued within the period and classifies them into A, B and C classes by cumulative share of the total consumption value. Products with no issue in the period are not listed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-reports"
+                ],
+                "summary": "Get ABC analysis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD or RFC 3339, default: one year before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD or RFC 3339, default: now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Office ID (UUID format)",
+                        "name": "officeId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Cumulative share (%) closing class A (default: 80)",
+                        "name": "classA",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Cumulative share (%) closing class B (default: 95)",
+                        "name": "classB",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ABCReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-reports/controlled-register": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Builds the monthly register of controlled products (narcotic, psychotropic, precursor) per office: opening balance, in, out and closing balance of each product with every movement of the month, its counterparty and authorizing user. Transfers between warehouses of the same office are shown apart from in and out. With format=csv the register is exported as a CSV file.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "stock-reports"
+                ],
+                "summary": "Get the controlled substance register",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Register month (YYYY-MM, default: current month)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Office ID (UUID format)",
+                        "name": "officeId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Controlled class (narcotic, psychotropic, precursor)",
+                        "name": "class",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ControlledRegister"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-reports/dead-stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists stock on hand per product and warehouse, grouped by product category, that had no outgoing movement (issue or transfer out) in the last given days (dead), or that would last longer than slowCoverDays at the outgoing rate of those days (slow-moving)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-reports"
+                ],
+                "summary": "Get dead and slow-moving stock",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Days without outgoing movement before stock counts as dead (default: 90)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days of cover above which moving stock counts as slow, 0 to skip (default: 180)",
+                        "name": "slowCoverDays",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Office ID (UUID format)",
+                        "name": "officeId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.DeadStockReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-reports/expiry": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists batches with remaining stock that expire within the given number of days, including already expired ones, grouped by office, branch and warehouse with quantity and value",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-reports"
+                ],
+                "summary": "Get near-expiry and expired stock",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Days ahead to look for expiring batches (default: 30)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Office ID (UUID format)",
+                        "name": "officeId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ExpiryReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-reports/shrinkage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Totals stock removed by adjustments carrying a reason code (damage, loss, sampling, ...), grouped by reason and warehouse with quantity and value",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-reports"
+                ],
+                "summary": "Get shrinkage by reason code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD or RFC 3339), open when empty",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD or RFC 3339, default: now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Office ID (UUID format)",
+                        "name": "officeId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ShrinkageReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-reports/stock-card": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the movement history (kartu stok) of a product in a warehouse over a period, read from the stock ledger. Each movement shows its in and out quantities, the balance before and after it, the document reference, batch and user. Opening balance, totals and closing balance cover the whole period; movements are paginated oldest first. With format=csv every movement of the period is exported as a CSV file.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "stock-reports"
+                ],
+                "summary": "Get stock card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
+                        "name": "productId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "warehouseId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Batch number, all batches when empty",
+                        "name": "batchNumber",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD or RFC 3339), open when empty",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD or RFC 3339, default: now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.StockCard"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-reports/valuation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Values stock on hand as of a date per warehouse and per category, using the FIFO or moving average method configured on each office, and reports the cost of goods issued within the period. A request spanning exactly one closed month (from its first day, as of its last day) is served from the period snapshot.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-reports"
+                ],
+                "summary": "Get stock valuation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Valuation date (YYYY-MM-DD or RFC 3339, default: now)",
+                        "name": "asOf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the cost of goods issued period (YYYY-MM-DD or RFC 3339, default: beginning of the ledger)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Office ID (UUID format)",
+                        "name": "officeId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ValuationReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-reservations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated stock reservations, newest first, optionally filtered by warehouse, product, reference document and status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-reservations"
+                ],
+                "summary": "Get stock reservations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
+                        "name": "productId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reference document ID (UUID format)",
+                        "name": "referenceId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reservation status (active, released, fulfilled, expired)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Holds stock for a confirmed document without issuing it. Fails when the warehouse has less available-to-promise stock than requested.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-reservations"
+                ],
+                "summary": "Reserve stock",
+                "parameters": [
+                    {
+                        "description": "Reservation data",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockReservationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockReservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or insufficient available stock",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-reservations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a single stock reservation by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-reservations"
+                ],
+                "summary": "Get stock reservation by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Reservation ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockReservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-reservations/{id}/fulfill": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issues the reserved quantity out of the warehouse and closes the reservation. Without a batch number the issue follows the warehouse FEFO/FIFO policy. Controlled products need the authorizing user and the counterparty.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-reservations"
+                ],
+                "summary": "Fulfil a stock reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Reservation ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fulfilment data",
+                        "name": "fulfill",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockReservationFulfillRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockReservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, reservation not active or insufficient stock",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-reservations/{id}/release": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the reserved quantity to available stock without issuing it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-reservations"
+                ],
+                "summary": "Release a stock reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Reservation ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockReservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Reservation not active",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-transfers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated inter-warehouse transfers, newest first, optionally filtered by status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-transfers"
+                ],
+                "summary": "Get list of stock transfers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transfer status (draft, shipped, in_transit, received, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a draft transfer between two warehouses and reserves its lines at the source. No stock moves until the transfer is shipped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-transfers"
+                ],
+                "summary": "Create a stock transfer",
+                "parameters": [
+                    {
+                        "description": "Transfer data",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or insufficient available stock",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-transfers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a transfer with its lines, received quantities and discrepancies",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-transfers"
+                ],
+                "summary": "Get stock transfer by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Transfer ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the warehouses, notes and lines of a transfer that is still in draft. Reservations are released and taken again for the new lines.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-transfers"
+                ],
+                "summary": "Update a draft stock transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Transfer ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transfer data",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, transfer not in draft or insufficient available stock",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-transfers/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels a transfer that has not been shipped yet and releases its reserved stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-transfers"
+                ],
+                "summary": "Cancel a stock transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Transfer ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Transfer not in draft",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-transfers/{id}/dispatch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks a shipped transfer as handed over to the carrier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-transfers"
+                ],
+                "summary": "Mark a stock transfer in transit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Transfer ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Transfer not shipped",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-transfers/{id}/receive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Puts arrived quantities into the destination warehouse as transfer_in stock entries. Partial receipts keep the transfer in transit; close records the remainder as a discrepancy.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-transfers"
+                ],
+                "summary": "Receive a stock transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Transfer ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Received quantities",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockTransferReceiveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or transfer not shipped",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/stock-transfers/{id}/ship": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fulfils the reservations of the transfer and issues every line out of the source warehouse as transfer_out stock entries. Lines without a batch number are allocated by the warehouse issue policy (FEFO or FIFO) and split into one line per issued batch.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-transfers"
+                ],
+                "summary": "Ship a stock transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock Transfer ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Transfer not in draft or insufficient stock",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/supplier-returns": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated supplier returns, newest first, optionally filtered by warehouse and status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Get supplier returns",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return status (draft, posted, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Drafts a return against a goods receipt. Product, batch and cost of each line come from the referenced receipt line and no more than is left of the receipt can be returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Create a supplier return",
+                "parameters": [
+                    {
+                        "description": "Return data",
+                        "name": "return",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SupplierReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.SupplierReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/supplier-returns/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a supplier return with its lines",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Get supplier return by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier Return ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SupplierReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/supplier-returns/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels a return that has not been posted. No stock is moved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Cancel a supplier return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier Return ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SupplierReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Return already posted or cancelled",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/supplier-returns/{id}/post": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issues every line out of the receiving warehouse as return_out stock entries. The entries carry the purchase order in order_id and the goods receipt in reference_id. Expired, quarantined and rejected batches may be returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Post a supplier return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier Return ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SupplierReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Return not in draft, quantity over the receipt or insufficient stock",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/suppliers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated suppliers with their contacts, optionally filtered by search term and status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Get list of suppliers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by code, name or city",
+                        "name": "searchTerm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Supplier status (active, inactive)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a supplier with its contacts and payment terms",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Create a supplier",
+                "parameters": [
+                    {
+                        "description": "Supplier data",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/suppliers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a supplier with its contacts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Get supplier by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the details and contacts of a supplier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Update a supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Supplier data",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a supplier together with its contacts and product catalog entries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Delete a supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/unit-products": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated unit products optionally filtered by search term",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "unit-products"
+                ],
+                "summary": "Get list of unit products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term to filter unit products by name",
+                        "name": "searchTerm",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new unit product with the provided information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "unit-products"
+                ],
+                "summary": "Create a new unit product",
+                "parameters": [
+                    {
+                        "description": "Unit Product data",
+                        "name": "unitProduct",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UnitProduct"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnitProduct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/unit-products/convert": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Converts a quantity between two units of the same dimension (mass, volume, length or count) using their standard factors. Units of different dimensions, or without a standard factor such as box, are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "unit-products"
+                ],
+                "summary": "Convert a quantity between units",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Quantity to convert",
+                        "name": "quantity",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit code to convert from, e.g. lb",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit code to convert to, e.g. kg",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.UnitConversion"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown or incompatible units",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/unit-products/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific unit product by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "unit-products"
+                ],
+                "summary": "Get unit product by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unit Product ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnitProduct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing unit product with new information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "unit-products"
+                ],
+                "summary": "Update a unit product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unit Product ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated unit product data",
+                        "name": "unitProduct",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UnitProduct"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnitProduct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a unit product by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "unit-products"
+                ],
+                "summary": "Delete a unit product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unit Product ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/warehouses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated warehouses optionally filtered by search term",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Get list of warehouses",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new warehouse with the provided information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Create a new warehouse",
+                "parameters": [
+                    {
+                        "description": "Warehouse data",
+                        "name": "warehouse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Warehouse"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Warehouse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/warehouses/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific warehouse by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Get warehouse by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Warehouse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing warehouse with new information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Update a warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated warehouse data",
+                        "name": "warehouse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Warehouse"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Warehouse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a warehouse by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Delete a warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api/warehouses/{id}/stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves paginated non-zero stock balances per product and batch for a warehouse. With asOf the balances are rebuilt from the stock ledger as of that moment, starting from the snapshot of the latest closed period.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-balances"
+                ],
+                "summary": "Get on-hand stock of a warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Point in time (YYYY-MM-DD or RFC 3339, default: current balances)",
+                        "name": "asOf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "contract.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "securepassword123"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "superadmin"
+                }
+            }
+        },
+        "contract.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user_identifier": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "office_id",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "office_id": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                }
+            }
+        },
+        "contract.RegisterResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.RegisterWithOfficeRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "office_code",
+                "office_name",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "office_address": {
+                    "type": "string"
+                },
+                "office_city": {
+                    "type": "string"
+                },
+                "office_code": {
+                    "description": "Office fields",
+                    "type": "string",
+                    "maxLength": 10,
+                    "minLength": 2
+                },
+                "office_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "office_phone": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "username": {
+                    "description": "User fields",
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                }
+            }
+        },
+        "contract.RegisterWithOfficeResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "office_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AdjustmentReasonRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "DAMAGE"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Broken or spoiled during storage or handling"
+                },
+                "direction": {
+                    "description": "increase, decrease or both, defaults to both",
+                    "type": "string",
+                    "example": "decrease"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Damaged goods"
+                },
+                "requires_approval": {
+                    "description": "Always route adjustments with this reason to approval",
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "description": "active or inactive, defaults to active",
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "dto.CategoryProductCreateRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "parent_id"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Category name",
+                    "type": "string",
+                    "example": "Precursor"
+                },
+                "parent_id": {
+                    "description": "example:\"123e4567-e89b-12d3-a456-426614174000\"",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.CustomerReturnLineRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "batch_number": {
+                    "description": "Returned batch, empty when the origin issued a single batch",
+                    "type": "string",
+                    "example": "BATCH-2024-001"
+                },
+                "disposition": {
+                    "description": "sellable or quarantine, defaults to sellable",
+                    "type": "string",
+                    "example": "quarantine"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Seal broken"
+                },
+                "product_id": {
+                    "description": "Returned product",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "quantity": {
+                    "description": "Quantity in the given unit",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 5
+                },
+                "unit": {
+                    "description": "Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit",
+                    "type": "string",
+                    "example": "box"
+                }
+            }
+        },
+        "dto.CustomerReturnRequest": {
+            "type": "object",
+            "required": [
+                "lines",
+                "origin_id",
+                "warehouse_id"
+            ],
+            "properties": {
+                "customer_name": {
+                    "type": "string",
+                    "example": "Apotek Sehat"
+                },
+                "date": {
+                    "description": "Movement date, defaults to now",
+                    "type": "string",
+                    "example": "2024-02-12T00:00:00Z"
+                },
+                "lines": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.CustomerReturnLineRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Returned at the counter"
+                },
+                "origin_id": {
+                    "description": "Document the stock was issued with",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Wrong item delivered"
+                },
+                "warehouse_id": {
+                    "description": "Receiving warehouse",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.GoodsReceiptLineRequest": {
+            "type": "object",
+            "required": [
+                "line_id",
+                "quantity"
+            ],
+            "properties": {
+                "batch_number": {
+                    "description": "Supplier batch number",
+                    "type": "string",
+                    "example": "BATCH-2024-001"
+                },
+                "expired_at": {
+                    "description": "Batch expiry date",
+                    "type": "string",
+                    "example": "2026-12-31T00:00:00Z"
+                },
+                "line_id": {
+                    "description": "Purchase order line ID",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "price": {
+                    "description": "Unit cost per small unit, defaults to the ordered price",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 10000
+                },
+                "qc_status": {
+                    "description": "released or quarantine, warehouses holding receipts for QC always quarantine",
+                    "type": "string",
+                    "example": "quarantine"
+                },
+                "quantity": {
+                    "description": "Received quantity in the given unit",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 120
+                },
+                "unit": {
+                    "description": "Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit",
+                    "type": "string",
+                    "example": "box"
+                }
+            }
+        },
+        "dto.GoodsReceiptRequest": {
+            "type": "object",
+            "required": [
+                "lines"
+            ],
+            "properties": {
+                "date": {
+                    "description": "Receipt date, defaults to now",
+                    "type": "string",
+                    "example": "2024-01-20T00:00:00Z"
+                },
+                "lines": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.GoodsReceiptLineRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Delivery note DN-4471"
+                }
+            }
+        },
+        "dto.LandedCostChargeRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "type"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1500000
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Sea freight Jakarta"
+                },
+                "type": {
+                    "description": "freight, insurance, duty or other",
+                    "type": "string",
+                    "example": "freight"
+                }
+            }
+        },
+        "dto.LandedCostLineRequest": {
+            "type": "object",
+            "required": [
+                "receipt_line_id"
+            ],
+            "properties": {
+                "receipt_line_id": {
+                    "description": "Goods receipt line",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "weight": {
+                    "description": "Total weight of the line, required by the weight method",
+                    "type": "number",
+                    "example": 250.5
+                }
+            }
+        },
+        "dto.LandedCostRequest": {
+            "type": "object",
+            "required": [
+                "charges",
+                "receipt_id"
+            ],
+            "properties": {
+                "charges": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.LandedCostChargeRequest"
+                    }
+                },
+                "date": {
+                    "description": "Date the cost takes effect, defaults to now",
+                    "type": "string",
+                    "example": "2024-02-15T00:00:00Z"
+                },
+                "lines": {
+                    "description": "Defaults to every line of the receipt",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LandedCostLineRequest"
+                    }
+                },
+                "method": {
+                    "description": "value, quantity or weight, defaults to value",
+                    "type": "string",
+                    "example": "value"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Import of February shipment"
+                },
+                "receipt_id": {
+                    "description": "Goods receipt the charges belong to",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "INV-FWD-2024-0042"
+                }
+            }
+        },
+        "dto.LandedCostReverseRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Freight invoice was credited by the forwarder"
+                }
+            }
+        },
+        "dto.ProductBarcodeRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "unit_id"
+            ],
+            "properties": {
+                "code": {
+                    "description": "EAN-13, EAN-8 or UPC-A with a valid check digit",
+                    "type": "string",
+                    "example": "8992772311113"
+                },
+                "unit_id": {
+                    "description": "Unit of the product the barcode is printed on",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.ProductCreateRequest": {
+            "type": "object",
+            "required": [
+                "category_id",
+                "code",
+                "name",
+                "purchase_price",
+                "selling_price"
+            ],
+            "properties": {
+                "barcodes": {
+                    "description": "EAN-13, EAN-8 or UPC-A barcodes of the packaging units",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProductBarcodeRequest"
+                    }
+                },
+                "category_id": {
+                    "description": "Foreign key to category",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "code": {
+                    "description": "Product code or SKU",
+                    "type": "string",
+                    "example": "PROD001"
+                },
+                "content_per_large_unit": {
+                    "description": "e.g., 12 pieces per box",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 12
+                },
+                "controlled": {
+                    "description": "Controlled substance under regulator reporting",
+                    "type": "boolean",
+                    "example": false
+                },
+                "controlled_class": {
+                    "description": "narcotic, psychotropic or precursor when controlled",
+                    "type": "string",
+                    "example": "precursor"
+                },
+                "indication": {
+                    "description": "Description or usage",
+                    "type": "string",
+                    "example": "High-performance laptop for professionals"
+                },
+                "large_unit": {
+                    "description": "e.g., box, pack",
+                    "type": "string",
+                    "example": "box"
+                },
+                "name": {
+                    "description": "Product name",
+                    "type": "string",
+                    "example": "Laptop Dell XPS 13"
+                },
+                "purchase_price": {
+                    "description": "Cost price",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 500
+                },
+                "selling_price": {
+                    "description": "Sale price",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 750
+                },
+                "small_unit": {
+                    "description": "e.g., piece, tablet",
+                    "type": "string",
+                    "example": "piece"
+                },
+                "units": {
+                    "description": "Packaging hierarchy, base unit first. When set, the small and large unit fields are derived from it.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProductUnitRequest"
+                    }
+                }
+            }
+        },
+        "dto.ProductSupplierRequest": {
+            "type": "object",
+            "required": [
+                "supplier_id"
+            ],
+            "properties": {
+                "is_preferred": {
+                    "description": "Vendor picked for reorder suggestions",
+                    "type": "boolean",
+                    "example": true
+                },
+                "lead_time_days": {
+                    "description": "Days from order to delivery",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 7
+                },
+                "min_order_quantity": {
+                    "description": "Minimum order in small units",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1200
+                },
+                "supplier_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "supplier_sku": {
+                    "description": "Supplier's own product code",
+                    "type": "string",
+                    "example": "KF-PCT-500"
+                }
+            }
+        },
+        "dto.ProductUnitRequest": {
+            "type": "object",
+            "required": [
+                "unit_id"
+            ],
+            "properties": {
+                "content": {
+                    "description": "Units of the previous level in one of this unit, ignored for the base unit",
+                    "type": "integer",
+                    "example": 10
+                },
+                "unit_id": {
+                    "description": "Unit product",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.ProductUpdateRequest": {
+            "type": "object",
+            "required": [
+                "category_id",
+                "code",
+                "name",
+                "purchase_price",
+                "selling_price"
+            ],
+            "properties": {
+                "barcodes": {
+                    "description": "EAN-13, EAN-8 or UPC-A barcodes of the packaging units. Omit to keep the current barcodes.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProductBarcodeRequest"
+                    }
+                },
+                "category_id": {
+                    "description": "Foreign key to category",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "code": {
+                    "description": "Product code or SKU",
+                    "type": "string",
+                    "example": "PROD001"
+                },
+                "content_per_large_unit": {
+                    "description": "e.g., 12 pieces per box",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 12
+                },
+                "controlled": {
+                    "description": "Controlled substance under regulator reporting",
+                    "type": "boolean",
+                    "example": false
+                },
+                "controlled_class": {
+                    "description": "narcotic, psychotropic or precursor when controlled",
+                    "type": "string",
+                    "example": "precursor"
+                },
+                "indication": {
+                    "description": "Description or usage",
+                    "type": "string",
+                    "example": "High-performance laptop for professionals"
+                },
+                "large_unit": {
+                    "description": "e.g., box, pack",
+                    "type": "string",
+                    "example": "box"
+                },
+                "name": {
+                    "description": "Product name",
+                    "type": "string",
+                    "example": "Laptop Dell XPS 13"
+                },
+                "purchase_price": {
+                    "description": "Cost price",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 500
+                },
+                "selling_price": {
+                    "description": "Sale price",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 750
+                },
+                "small_unit": {
+                    "description": "e.g., piece, tablet",
+                    "type": "string",
+                    "example": "piece"
+                },
+                "units": {
+                    "description": "Packaging hierarchy, base unit first. When set, the small and large unit fields are derived from it. Omit to keep the current hierarchy.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProductUnitRequest"
+                    }
+                }
+            }
+        },
+        "dto.PurchaseOrderLineRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "example": "Deliver with cold chain"
+                },
+                "price": {
+                    "description": "Price per ordered unit",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 120000
+                },
+                "product_id": {
+                    "description": "Ordered product",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "quantity": {
+                    "description": "Quantity in the ordered unit",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 10
+                },
+                "unit": {
+                    "description": "Any unit of the product, defaults to the small unit",
+                    "type": "string",
+                    "example": "box"
+                }
+            }
+        },
+        "dto.PurchaseOrderRequest": {
+            "type": "object",
+            "required": [
+                "lines",
+                "warehouse_id"
+            ],
+            "properties": {
+                "expected_date": {
+                    "description": "Expected delivery date",
+                    "type": "string",
+                    "example": "2024-01-22T00:00:00Z"
+                },
+                "lines": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.PurchaseOrderLineRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Monthly replenishment"
+                },
+                "order_date": {
+                    "description": "Defaults to now",
+                    "type": "string",
+                    "example": "2024-01-15T00:00:00Z"
+                },
+                "supplier_id": {
+                    "description": "Supplier to order from",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "supplier_name": {
+                    "description": "Free text when no supplier is set",
+                    "type": "string",
+                    "example": "PT Kimia Farma"
+                },
+                "warehouse_id": {
+                    "description": "Receiving warehouse",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.QCDecisionRequest": {
+            "type": "object",
+            "required": [
+                "batch_number",
+                "product_id",
+                "warehouse_id"
+            ],
+            "properties": {
+                "batch_number": {
+                    "description": "Batch the decision applies to",
+                    "type": "string",
+                    "example": "BATCH-2024-001"
+                },
+                "notes": {
+                    "description": "Required when rejecting",
+                    "type": "string",
+                    "example": "Certificate of analysis checked"
+                },
+                "product_id": {
+                    "description": "Product of the batch",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "reference_id": {
+                    "description": "Certificate of analysis or QC record",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "warehouse_id": {
+                    "description": "Warehouse holding the batch",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.RecallRequest": {
+            "type": "object",
+            "required": [
+                "batch_number",
+                "product_id",
+                "reason"
+            ],
+            "properties": {
+                "action": {
+                    "description": "return or dispose, defaults to return",
+                    "type": "string",
+                    "example": "return"
+                },
+                "batch_number": {
+                    "description": "Recalled batch",
+                    "type": "string",
+                    "example": "BATCH-2024-001"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Supplier collects from every branch"
+                },
+                "product_id": {
+                    "description": "Recalled product",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "reason": {
+                    "description": "Why the batch is recalled",
+                    "type": "string",
+                    "example": "Contamination found in batch"
+                },
+                "reference": {
+                    "description": "Recall notice number",
+                    "type": "string",
+                    "example": "BPOM/RC/2024/015"
+                },
+                "supplier_id": {
+                    "description": "Supplier or manufacturer issuing the recall",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.RecallTaskCompleteRequest": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Overrides the task action, return or dispose",
+                    "type": "string",
+                    "example": "dispose"
+                },
+                "authorized_by": {
+                    "description": "Authorizing user holding the controlled authorizer role, required for controlled products",
+                    "type": "integer",
+                    "example": 1
+                },
+                "counterparty": {
+                    "description": "Supplier or disposal party, required for controlled products",
+                    "type": "string",
+                    "example": "PT Kimia Farma courier"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Handed over to the supplier courier"
+                }
+            }
+        },
+        "dto.ReorderSettingRequest": {
+            "type": "object",
+            "required": [
+                "max_stock",
+                "product_id",
+                "warehouse_id"
+            ],
+            "properties": {
+                "max_stock": {
+                    "description": "Order up to this level",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 600
+                },
+                "min_stock": {
+                    "description": "Safety stock in small units",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 50
+                },
+                "product_id": {
+                    "description": "Replenished product",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "reorder_point": {
+                    "description": "Order at or below this level, defaults to min stock",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 120
+                },
+                "warehouse_id": {
+                    "description": "Stocking warehouse",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.StockAdjustmentAttachmentRequest": {
+            "type": "object",
+            "required": [
+                "file_name",
+                "url"
+            ],
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "file_name": {
+                    "type": "string",
+                    "example": "damage-photo-1.jpg"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://files.example.com/adjustments/damage-photo-1.jpg"
+                }
+            }
+        },
+        "dto.StockAdjustmentLineRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity",
+                "reason_code"
+            ],
+            "properties": {
+                "batch_number": {
+                    "description": "Adjusted batch",
+                    "type": "string",
+                    "example": "BATCH-2024-001"
+                },
+                "expired_at": {
+                    "description": "Batch expiry date for new batches",
+                    "type": "string",
+                    "example": "2026-12-31T00:00:00Z"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Carton crushed by forklift"
+                },
+                "price": {
+                    "description": "Cost per small unit for increases, decreases use the batch cost",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 5000
+                },
+                "product_id": {
+                    "description": "Adjusted product",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "quantity": {
+                    "description": "Signed quantity, negative reduces stock",
+                    "type": "integer",
+                    "example": -2
+                },
+                "reason_code": {
+                    "description": "Adjustment reason code",
+                    "type": "string",
+                    "example": "DAMAGE"
+                },
+                "unit": {
+                    "description": "Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit",
+                    "type": "string",
+                    "example": "box"
+                }
+            }
+        },
+        "dto.StockAdjustmentRejectRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Photos do not show the damaged cartons"
+                }
+            }
+        },
+        "dto.StockAdjustmentRequest": {
+            "type": "object",
+            "required": [
+                "lines",
+                "warehouse_id"
+            ],
+            "properties": {
+                "authorized_by": {
+                    "description": "Authorizing user holding the controlled authorizer role, required for controlled products unless the adjustment goes through approval",
+                    "type": "integer",
+                    "example": 1
+                },
+                "counterparty": {
+                    "description": "Where the stock came from or went to, required for controlled products",
+                    "type": "string",
+                    "example": "Destruction witnessed by the health office"
+                },
+                "date": {
+                    "description": "Movement date, defaults to now",
+                    "type": "string",
+                    "example": "2024-01-31T00:00:00Z"
+                },
+                "lines": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.StockAdjustmentLineRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Damage found during weekly inspection"
+                },
+                "warehouse_id": {
+                    "description": "Adjusted warehouse",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.StockCountApproveRequest": {
+            "type": "object",
+            "properties": {
+                "counterparty": {
+                    "description": "Recorded with the variances, required when a controlled product has one",
+                    "type": "string",
+                    "example": "Count witnessed by the pharmacist in charge"
+                }
+            }
+        },
+        "dto.StockCountEntriesRequest": {
+            "type": "object",
+            "required": [
+                "lines"
+            ],
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.StockCountLineRequest"
+                    }
+                }
+            }
+        },
+        "dto.StockCountLineRequest": {
+            "type": "object",
+            "properties": {
+                "batch_number": {
+                    "description": "Counted batch when no line ID is given",
+                    "type": "string",
+                    "example": "BATCH-2024-001"
+                },
+                "expired_at": {
+                    "description": "Batch expiry date for stock found outside the snapshot",
+                    "type": "string",
+                    "example": "2026-12-31T00:00:00Z"
+                },
+                "line_id": {
+                    "description": "Snapshot line, empty to match by product and batch",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "2 boxes found on lower shelf"
+                },
+                "product_id": {
+                    "description": "Counted product when no line ID is given",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "quantity": {
+                    "description": "Counted quantity in the given unit",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 98
+                },
+                "unit": {
+                    "description": "Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit",
+                    "type": "string",
+                    "example": "box"
+                }
+            }
+        },
+        "dto.StockCountRequest": {
+            "type": "object",
+            "required": [
+                "warehouse_id"
+            ],
+            "properties": {
+                "blind": {
+                    "description": "Hide expected quantities from counters",
+                    "type": "boolean",
+                    "example": true
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Year-end stock opname"
+                },
+                "warehouse_id": {
+                    "description": "Counted warehouse",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.StockIssueRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity",
+                "warehouse_id"
+            ],
+            "properties": {
+                "authorized_by": {
+                    "description": "Authorizing user holding the controlled authorizer role, required for controlled products",
+                    "type": "integer",
+                    "example": 1
+                },
+                "batch_number": {
+                    "description": "Batch to issue from, empty to allocate by FEFO/FIFO",
+                    "type": "string",
+                    "example": "BATCH-2024-001"
+                },
+                "counterparty": {
+                    "description": "Patient or prescriber, required for controlled products",
+                    "type": "string",
+                    "example": "Patient MR-001234"
+                },
+                "date": {
+                    "description": "Movement date, defaults to now",
+                    "type": "string",
+                    "example": "2024-01-20T00:00:00Z"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Dispensed to outpatient"
+                },
+                "product_id": {
+                    "description": "Issued product",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "quantity": {
+                    "description": "Quantity in the given unit",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 10
+                },
+                "reference_id": {
+                    "description": "Originating document",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "unit": {
+                    "description": "Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit",
+                    "type": "string",
+                    "example": "box"
+                },
+                "warehouse_id": {
+                    "description": "Source warehouse",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.StockPeriodCloseRequest": {
+            "type": "object",
+            "required": [
+                "period"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "example": "March closing"
+                },
+                "period": {
+                    "description": "Month to close, YYYY-MM",
+                    "type": "string",
+                    "example": "2024-03"
+                }
+            }
+        },
+        "dto.StockReceiptRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity",
+                "warehouse_id"
+            ],
+            "properties": {
+                "authorized_by": {
+                    "description": "Authorizing user holding the controlled authorizer role, required for controlled products",
+                    "type": "integer",
+                    "example": 1
+                },
+                "batch_number": {
+                    "description": "Supplier batch number",
+                    "type": "string",
+                    "example": "BATCH-2024-001"
+                },
+                "counterparty": {
+                    "description": "Supplier or sender, required for controlled products",
+                    "type": "string",
+                    "example": "PT Kimia Farma"
+                },
+                "date": {
+                    "description": "Movement date, defaults to now",
+                    "type": "string",
+                    "example": "2024-01-15T00:00:00Z"
+                },
+                "expired_at": {
+                    "description": "Batch expiry date",
+                    "type": "string",
+                    "example": "2026-12-31T00:00:00Z"
+                },
+                "margin": {
+                    "description": "Margin percentage",
+                    "type": "number",
+                    "example": 10
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Initial stock"
+                },
+                "order_id": {
+                    "description": "Originating order",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "price": {
+                    "description": "Unit cost per small unit",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 5000
+                },
+                "product_id": {
+                    "description": "Received product",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "qc_status": {
+                    "description": "released or quarantine, warehouses holding receipts for QC always quarantine",
+                    "type": "string",
+                    "example": "quarantine"
+                },
+                "quantity": {
+                    "description": "Quantity in the given unit",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 100
+                },
+                "reference_id": {
+                    "description": "Originating document",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "tax": {
+                    "description": "Tax percentage",
+                    "type": "number",
+                    "example": 11
+                },
+                "unit": {
+                    "description": "Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit",
+                    "type": "string",
+                    "example": "box"
+                },
+                "warehouse_id": {
+                    "description": "Destination warehouse",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.StockReservationFulfillRequest": {
+            "type": "object",
+            "properties": {
+                "authorized_by": {
+                    "description": "Authorizing user holding the controlled authorizer role, required for controlled products",
+                    "type": "integer",
+                    "example": 1
+                },
+                "counterparty": {
+                    "description": "Patient or receiving party, required for controlled products",
+                    "type": "string",
+                    "example": "Patient MR-001234"
+                }
+            }
+        },
+        "dto.StockReservationRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity",
+                "warehouse_id"
+            ],
+            "properties": {
+                "batch_number": {
+                    "description": "Batch to issue on fulfilment, empty to allocate by FEFO/FIFO",
+                    "type": "string",
+                    "example": "BATCH-2024-001"
+                },
+                "expires_at": {
+                    "description": "Release automatically after this time, empty to hold until released",
+                    "type": "string",
+                    "example": "2024-01-22T00:00:00Z"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Reserved for order SO-1024"
+                },
+                "product_id": {
+                    "description": "Reserved product",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "quantity": {
+                    "description": "Quantity in the given unit",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 10
+                },
+                "reference_id": {
+                    "description": "Document holding the reservation",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "reference_type": {
+                    "description": "Kind of document holding the reservation",
+                    "type": "string",
+                    "example": "sales_order"
+                },
+                "unit": {
+                    "description": "Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit",
+                    "type": "string",
+                    "example": "box"
+                },
+                "warehouse_id": {
+                    "description": "Warehouse holding the stock",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.StockTransferLineRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "batch_number": {
+                    "description": "Batch to ship, empty to allocate by FEFO/FIFO when shipped",
+                    "type": "string",
+                    "example": "BATCH-2024-001"
+                },
+                "product_id": {
+                    "description": "Transferred product",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "quantity": {
+                    "description": "Quantity in the given unit",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 24
+                },
+                "unit": {
+                    "description": "Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit",
+                    "type": "string",
+                    "example": "box"
+                }
+            }
+        },
+        "dto.StockTransferReceiveLineRequest": {
+            "type": "object",
+            "required": [
+                "line_id"
+            ],
+            "properties": {
+                "discrepancy_notes": {
+                    "description": "Why quantities differ",
+                    "type": "string",
+                    "example": "4 units broken in transit"
+                },
+                "line_id": {
+                    "description": "Transfer line ID",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "quantity": {
+                    "description": "Quantity received now",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 20
+                },
+                "unit": {
+                    "description": "Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit",
                     "type": "string",
-                    "example": "superadmin"
+                    "example": "box"
+                }
+            }
+        },
+        "dto.StockTransferReceiveRequest": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "description": "Record any outstanding quantity as a discrepancy and finish the transfer",
+                    "type": "boolean",
+                    "example": false
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StockTransferReceiveLineRequest"
+                    }
+                }
+            }
+        },
+        "dto.StockTransferRequest": {
+            "type": "object",
+            "required": [
+                "destination_warehouse_id",
+                "lines",
+                "source_warehouse_id"
+            ],
+            "properties": {
+                "destination_warehouse_id": {
+                    "description": "Receiving warehouse",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174001"
+                },
+                "lines": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.StockTransferLineRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Monthly replenishment for branch pharmacy"
+                },
+                "source_warehouse_id": {
+                    "description": "Shipping warehouse",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.StockWriteOffRequest": {
+            "type": "object",
+            "required": [
+                "batch_number",
+                "product_id",
+                "warehouse_id"
+            ],
+            "properties": {
+                "authorized_by": {
+                    "description": "Authorizing user holding the controlled authorizer role, required for controlled products",
+                    "type": "integer",
+                    "example": 1
+                },
+                "batch_number": {
+                    "description": "Written-off batch",
+                    "type": "string",
+                    "example": "BATCH-2024-001"
+                },
+                "counterparty": {
+                    "description": "Where the stock went, required for controlled products",
+                    "type": "string",
+                    "example": "Destruction witnessed by the health office"
+                },
+                "date": {
+                    "description": "Movement date, defaults to now",
+                    "type": "string",
+                    "example": "2024-02-01T00:00:00Z"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Expired, sent for destruction"
+                },
+                "product_id": {
+                    "description": "Written-off product",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "quantity": {
+                    "description": "Quantity in the given unit, 0 writes off the whole batch",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "reference_id": {
+                    "description": "Originating document",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "unit": {
+                    "description": "Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit",
+                    "type": "string",
+                    "example": "box"
+                },
+                "warehouse_id": {
+                    "description": "Warehouse holding the batch",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.SupplierContactRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "budi@supplier.co.id"
+                },
+                "is_primary": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+62-812-3456-7890"
+                },
+                "position": {
+                    "type": "string",
+                    "example": "Sales Manager"
+                }
+            }
+        },
+        "dto.SupplierRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Budi Utomo No. 1"
+                },
+                "city": {
+                    "type": "string",
+                    "example": "Jakarta"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "SUP001"
+                },
+                "contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SupplierContactRequest"
+                    }
+                },
+                "email": {
+                    "type": "string",
+                    "example": "order@supplier.co.id"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "PT Kimia Farma Trading"
+                },
+                "payment_term_days": {
+                    "description": "Days until invoices are due, 0 for cash",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 30
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+62-21-384-7709"
+                },
+                "status": {
+                    "description": "active or inactive, defaults to active",
+                    "type": "string",
+                    "example": "active"
+                },
+                "tax_number": {
+                    "type": "string",
+                    "example": "01.234.567.8-901.000"
+                }
+            }
+        },
+        "dto.SupplierReturnLineRequest": {
+            "type": "object",
+            "required": [
+                "quantity",
+                "receipt_line_id"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "example": "Leaking bottles"
+                },
+                "quantity": {
+                    "description": "Quantity in the given unit",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 20
+                },
+                "receipt_line_id": {
+                    "description": "Goods receipt line the batch came in with",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "unit": {
+                    "description": "Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit",
+                    "type": "string",
+                    "example": "box"
+                }
+            }
+        },
+        "dto.SupplierReturnRequest": {
+            "type": "object",
+            "required": [
+                "lines",
+                "receipt_id"
+            ],
+            "properties": {
+                "date": {
+                    "description": "Movement date, defaults to now",
+                    "type": "string",
+                    "example": "2024-02-10T00:00:00Z"
+                },
+                "lines": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.SupplierReturnLineRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Collected by supplier courier"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Damaged on arrival"
+                },
+                "receipt_id": {
+                    "description": "Original goods receipt",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "model.AdjustmentReason": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "requires_approval": {
+                    "description": "Always route adjustments with this reason to approval, whatever their value",
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Branch": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "office": {
+                    "$ref": "#/definitions/model.Office"
+                },
+                "office_id": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Warehouse"
+                    }
+                }
+            }
+        },
+        "model.CategoryProduct": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Timestamp when created",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Unique identifier",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Category name",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "Parent category ID",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Timestamp when updated",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerReturn": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CustomerReturnLine"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "origin_id": {
+                    "type": "string"
+                },
+                "posted_at": {
+                    "type": "string"
+                },
+                "posted_by": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerReturnLine": {
+            "type": "object",
+            "properties": {
+                "batch_number": {
+                    "type": "string"
+                },
+                "conversion_residual": {
+                    "description": "Quantity given less quantity stored, in small units, when a standard unit conversion was rounded",
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "disposition": {
+                    "description": "sellable or quarantine",
+                    "type": "string"
+                },
+                "expired_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Unit cost of the original issue",
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "return_id": {
+                    "type": "string"
+                },
+                "stock_entry_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.GoodsReceipt": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GoodsReceiptLine"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "received_by": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.GoodsReceiptLine": {
+            "type": "object",
+            "properties": {
+                "batch_number": {
+                    "type": "string"
+                },
+                "conversion_residual": {
+                    "description": "Quantity given less quantity stored, in small units, when a standard unit conversion was rounded",
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expired_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "landed_cost": {
+                    "description": "Per small unit, from posted landed cost documents",
+                    "type": "number"
+                },
+                "order_line_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Unit cost per small unit",
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "qc_status": {
+                    "description": "QC status the batch was received in",
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "receipt_id": {
+                    "type": "string"
+                },
+                "returned_quantity": {
+                    "description": "Sent back to the supplier through supplier returns",
+                    "type": "integer"
+                },
+                "stock_entry_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LandedCost": {
+            "type": "object",
+            "properties": {
+                "charges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LandedCostCharge"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LandedCostLine"
+                    }
+                },
+                "method": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "posted_at": {
+                    "type": "string"
+                },
+                "posted_by": {
+                    "type": "integer"
+                },
+                "receipt_id": {
+                    "type": "string"
+                },
+                "reference": {
+                    "description": "Invoice number of the forwarder, insurer or customs",
+                    "type": "string"
+                },
+                "reversal_reason": {
+                    "type": "string"
+                },
+                "reversed_at": {
+                    "type": "string"
+                },
+                "reversed_by": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LandedCostCharge": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "landed_cost_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LandedCostLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Allocated share of the charges",
+                    "type": "number"
+                },
+                "batch_number": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "landed_cost_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Purchase price per small unit of the receipt line",
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "Quantity still held in small units, received less returned to the supplier",
+                    "type": "integer"
+                },
+                "receipt_line_id": {
+                    "type": "string"
+                },
+                "stock_entry_id": {
+                    "description": "Receipt movement whose cost is raised",
+                    "type": "string"
+                },
+                "unit_cost": {
+                    "description": "Amount per small unit added to the purchase price",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "Total weight of the line, used by the weight method",
+                    "type": "number"
+                }
+            }
+        },
+        "model.Office": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "branches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Branch"
+                    }
+                },
+                "city": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "e.g., active, inactive",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "valuation_method": {
+                    "description": "average or fifo",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Product": {
+            "type": "object",
+            "properties": {
+                "barcodes": {
+                    "description": "Barcodes printed on the packaging units",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductBarcode"
+                    }
+                },
+                "category": {
+                    "$ref": "#/definitions/model.CategoryProduct"
+                },
+                "category_id": {
+                    "description": "Foreign key",
+                    "type": "string"
+                },
+                "code": {
+                    "description": "Product code or SKU",
+                    "type": "string"
+                },
+                "content_per_large_unit": {
+                    "description": "e.g., 12 pieces per box",
+                    "type": "integer"
+                },
+                "controlled": {
+                    "description": "Movements need an authorizing user and a counterparty",
+                    "type": "boolean"
+                },
+                "controlled_class": {
+                    "description": "narcotic, psychotropic or precursor when controlled",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Timestamp when created",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Unique identifier",
+                    "type": "string"
+                },
+                "indication": {
+                    "description": "Description or usage",
+                    "type": "string"
+                },
+                "large_unit": {
+                    "description": "e.g., box, pack",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Product name",
+                    "type": "string"
+                },
+                "purchase_price": {
+                    "description": "Cost price",
+                    "type": "number"
+                },
+                "selling_price": {
+                    "description": "Sale price",
+                    "type": "number"
+                },
+                "small_unit": {
+                    "description": "e.g., piece, tablet",
+                    "type": "string"
+                },
+                "units": {
+                    "description": "Packaging hierarchy, base unit first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductUnit"
+                    }
+                },
+                "updated_at": {
+                    "description": "Timestamp when updated",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductBarcode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "EAN-13, EAN-8 or UPC-A",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "unit": {
+                    "$ref": "#/definitions/model.UnitProduct"
+                },
+                "unit_id": {
+                    "description": "Packaging unit the barcode is printed on",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductSupplier": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_preferred": {
+                    "description": "Vendor picked for reorder suggestions",
+                    "type": "boolean"
+                },
+                "last_purchase_price": {
+                    "description": "Per small unit, updated on goods receipt",
+                    "type": "number"
+                },
+                "last_purchased_at": {
+                    "type": "string"
+                },
+                "lead_time_days": {
+                    "type": "integer"
+                },
+                "min_order_quantity": {
+                    "description": "In small units",
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "supplier": {
+                    "$ref": "#/definitions/model.Supplier"
+                },
+                "supplier_id": {
+                    "type": "string"
+                },
+                "supplier_sku": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductUnit": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Units of the level below in one of this unit, 1 for the base unit",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "factor": {
+                    "description": "Base units in one of this unit",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "unit": {
+                    "$ref": "#/definitions/model.UnitProduct"
+                },
+                "unit_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PurchaseOrder": {
+            "type": "object",
+            "properties": {
+                "approved_at": {
+                    "type": "string"
+                },
+                "approved_by": {
+                    "type": "integer"
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "closed_by": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "expected_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PurchaseOrderLine"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "order_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier_id": {
+                    "type": "string"
+                },
+                "supplier_name": {
+                    "description": "Copied from the supplier when one is set",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "description": "Receiving warehouse",
+                    "type": "string"
+                }
+            }
+        },
+        "model.PurchaseOrderLine": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Price per ordered unit",
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "received_quantity": {
+                    "type": "integer"
+                },
+                "unit": {
+                    "description": "Ordered unit, any unit of the product",
+                    "type": "string"
+                },
+                "unit_content": {
+                    "description": "Small units per ordered unit",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Recall": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Default action of the tasks: return or dispose",
+                    "type": "string"
+                },
+                "batch_number": {
+                    "description": "A batch has at most one open recall",
+                    "type": "string"
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "closed_by": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "opened_at": {
+                    "type": "string"
+                },
+                "opened_by": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reference": {
+                    "description": "Recall notice number of the supplier or regulator",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier_id": {
+                    "description": "Supplier that issued the recall",
+                    "type": "string"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RecallTask"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.RecallTask": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "authorized_by": {
+                    "description": "Authorizing user of the movement, required for controlled products",
+                    "type": "integer"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "completed_by": {
+                    "type": "integer"
+                },
+                "counterparty": {
+                    "description": "Supplier or disposal party, required for controlled products",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "done_quantity": {
+                    "description": "Returned or disposed when the task was completed",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "On hand when the task was created, in small units",
+                    "type": "integer"
+                },
+                "recall_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "stock_entry_id": {
+                    "description": "Movement that removed the stock, nil when it had already left",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReorderSetting": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "max_stock": {
+                    "description": "Order up to this level",
+                    "type": "integer"
+                },
+                "min_stock": {
+                    "description": "Safety stock",
+                    "type": "integer"
+                },
+                "product": {
+                    "$ref": "#/definitions/model.Product"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "reorder_point": {
+                    "description": "Order when on-hand stock falls to this level",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StockAdjustment": {
+            "type": "object",
+            "properties": {
+                "approved_at": {
+                    "type": "string"
+                },
+                "approved_by": {
+                    "type": "integer"
+                },
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockAdjustmentAttachment"
+                    }
+                },
+                "authorized_by": {
+                    "description": "Authorizing user of controlled product lines, the approver when left empty",
+                    "type": "integer"
+                },
+                "counterparty": {
+                    "description": "Where controlled product lines came from or went to",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockAdjustmentLine"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "rejected_at": {
+                    "type": "string"
+                },
+                "rejected_by": {
+                    "type": "integer"
+                },
+                "rejection_notes": {
+                    "type": "string"
+                },
+                "requires_approval": {
+                    "description": "Set on submit from the value threshold and reason codes",
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "submitted_by": {
+                    "type": "integer"
+                },
+                "total_value": {
+                    "description": "Sum of line values, increases and decreases alike",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StockAdjustmentAttachment": {
+            "type": "object",
+            "properties": {
+                "adjustment_id": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "uploaded_by": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StockAdjustmentLine": {
+            "type": "object",
+            "properties": {
+                "adjustment_id": {
+                    "type": "string"
+                },
+                "batch_number": {
+                    "type": "string"
+                },
+                "conversion_residual": {
+                    "description": "Quantity given less quantity stored, in small units, when a standard unit conversion was rounded",
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expired_at": {
+                    "description": "Expiry for batches created by an increase",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Unit cost; decreases take the batch cost on submit",
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "Signed, negative reduces stock",
+                    "type": "integer"
+                },
+                "reason_code": {
+                    "type": "string"
+                },
+                "reason_id": {
+                    "type": "string"
+                },
+                "stock_entry_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Absolute quantity times price",
+                    "type": "number"
+                }
+            }
+        },
+        "model.StockAlert": {
+            "type": "object",
+            "properties": {
+                "acknowledged_at": {
+                    "type": "string"
+                },
+                "acknowledged_by": {
+                    "type": "integer"
+                },
+                "alert_date": {
+                    "type": "string"
+                },
+                "batch_number": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expired_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StockCount": {
+            "type": "object",
+            "properties": {
+                "approved_at": {
+                    "type": "string"
+                },
+                "approved_by": {
+                    "type": "integer"
+                },
+                "blind": {
+                    "description": "Hide expected quantities from counters until the count is submitted",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "expected_hidden": {
+                    "description": "ExpectedHidden is set on responses where expected quantities were masked for a blind count",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockCountLine"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "snapshot_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "submitted_by": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StockCountLine": {
+            "type": "object",
+            "properties": {
+                "batch_number": {
+                    "type": "string"
+                },
+                "conversion_residual": {
+                    "description": "Quantity counted less quantity stored, in small units, when a standard unit conversion was rounded",
+                    "type": "number"
+                },
+                "count_id": {
+                    "type": "string"
+                },
+                "counted_by": {
+                    "type": "integer"
+                },
+                "counted_quantity": {
+                    "description": "Nil until counted",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expected_quantity": {
+                    "type": "integer"
+                },
+                "expired_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "variance_quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.StockEntry": {
+            "type": "object",
+            "properties": {
+                "authorized_by": {
+                    "description": "User who authorized a movement of a controlled product",
+                    "type": "integer"
+                },
+                "batch_number": {
+                    "type": "string"
+                },
+                "conversion_residual": {
+                    "description": "Quantity given less quantity stored, in small units, when a standard unit conversion was rounded",
+                    "type": "number"
+                },
+                "counterparty": {
+                    "description": "Supplier, patient, prescriber or warehouse on the other side of a controlled movement",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "User who recorded the movement",
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "expired_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "margin": {
+                    "type": "number"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "previous_stock": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "qc_status": {
+                    "description": "QC status of the batch after the movement",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "Signed movement quantity in small units",
+                    "type": "integer"
+                },
+                "reason_code": {
+                    "description": "Adjustment reason, kept for shrinkage reporting",
+                    "type": "string"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StockPeriod": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string"
+                },
+                "closed_by": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "end_at": {
+                    "description": "Last instant of the month",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "period": {
+                    "description": "YYYY-MM",
+                    "type": "string"
+                },
+                "reopened_at": {
+                    "type": "string"
+                },
+                "reopened_by": {
+                    "type": "integer"
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StockReservation": {
+            "type": "object",
+            "properties": {
+                "batch_number": {
+                    "description": "Empty to issue by the warehouse policy on fulfilment",
+                    "type": "string"
+                },
+                "conversion_residual": {
+                    "description": "Quantity given less quantity stored, in small units, when a standard unit conversion was rounded",
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "description": "Nil holds the stock until released",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "In small units",
+                    "type": "integer"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "reference_type": {
+                    "description": "e.g. sales_order, stock_transfer",
+                    "type": "string"
+                },
+                "released_at": {
+                    "type": "string"
+                },
+                "released_by": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StockTransfer": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "destination_warehouse_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockTransferLine"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "received_by": {
+                    "type": "integer"
+                },
+                "shipped_at": {
+                    "type": "string"
+                },
+                "shipped_by": {
+                    "type": "integer"
+                },
+                "source_warehouse_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StockTransferLine": {
+            "type": "object",
+            "properties": {
+                "batch_number": {
+                    "type": "string"
+                },
+                "conversion_residual": {
+                    "description": "Quantity given less quantity stored, in small units, when a standard unit conversion was rounded",
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "discrepancy_notes": {
+                    "type": "string"
+                },
+                "discrepancy_quantity": {
+                    "type": "integer"
+                },
+                "expired_at": {
+                    "description": "Captured from the outgoing movement when shipped",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Captured from the outgoing movement when shipped",
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "received_quantity": {
+                    "type": "integer"
+                },
+                "transfer_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Supplier": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SupplierContact"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "payment_term_days": {
+                    "description": "Days until invoices are due, 0 for cash",
+                    "type": "integer"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tax_number": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SupplierContact": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "supplier_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SupplierReturn": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SupplierReturnLine"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "posted_at": {
+                    "type": "string"
+                },
+                "posted_by": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "receipt_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SupplierReturnLine": {
+            "type": "object",
+            "properties": {
+                "batch_number": {
+                    "type": "string"
+                },
+                "conversion_residual": {
+                    "description": "Quantity given less quantity stored, in small units, when a standard unit conversion was rounded",
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Unit cost of the receipt line",
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "receipt_line_id": {
+                    "type": "string"
+                },
+                "return_id": {
+                    "type": "string"
+                },
+                "stock_entry_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.UnitProduct": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Product code or SKU",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Timestamp when created",
+                    "type": "string"
+                },
+                "dimension": {
+                    "description": "mass, volume, length or count",
+                    "type": "string"
+                },
+                "factor": {
+                    "description": "Base units of the dimension in one of this unit, 0 when the size differs per product",
+                    "type": "number"
+                },
+                "id": {
+                    "description": "Unique identifier",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Product name",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Timestamp when updated",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Warehouse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "allow_negative_stock": {
+                    "description": "Let issues drive balances below zero",
+                    "type": "boolean"
+                },
+                "branch_id": {
+                    "description": "nullable for now",
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "issue_policy": {
+                    "description": "fefo or fifo",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "office_id": {
+                    "description": "nullable for now",
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "quarantine_receipts": {
+                    "description": "Receive every receipt into quarantine until QC releases it",
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "repository.AvailabilityRow": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "on_hand": {
+                    "type": "integer"
+                },
+                "product_code": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "reserved": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                },
+                "warehouse_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.ABCClassSummary": {
+            "type": "object",
+            "properties": {
+                "class": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "share": {
+                    "description": "Percent of the total consumption value",
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "service.ABCProduct": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "class": {
+                    "type": "string"
+                },
+                "cumulative_share": {
+                    "type": "number"
+                },
+                "product_code": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "share": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "service.ABCReport": {
+            "type": "object",
+            "properties": {
+                "class_a": {
+                    "description": "Cumulative share (%) closing class A",
+                    "type": "number"
+                },
+                "class_b": {
+                    "description": "Cumulative share (%) closing class B",
+                    "type": "number"
+                },
+                "classes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ABCClassSummary"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ABCProduct"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "service.BarcodeMatch": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "factor": {
+                    "description": "Small units in one scanned package",
+                    "type": "integer"
+                },
+                "product": {
+                    "$ref": "#/definitions/model.Product"
+                },
+                "unit": {
+                    "$ref": "#/definitions/model.UnitProduct"
+                }
+            }
+        },
+        "service.BatchDocument": {
+            "type": "object",
+            "properties": {
+                "first_date": {
+                    "type": "string"
+                },
+                "last_date": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "In small units, always positive",
+                    "type": "integer"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                },
+                "warehouse_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.BatchHolding": {
+            "type": "object",
+            "properties": {
+                "qc_status": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                },
+                "warehouse_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.BatchMovement": {
+            "type": "object",
+            "properties": {
+                "created_by": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "qc_status": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                },
+                "warehouse_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.BatchTrace": {
+            "type": "object",
+            "properties": {
+                "batch_number": {
+                    "type": "string"
+                },
+                "consumed": {
+                    "description": "Issued to consuming documents",
+                    "type": "integer"
+                },
+                "consumers": {
+                    "description": "Documents the batch was issued to",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.BatchDocument"
+                    }
+                },
+                "expired_at": {
+                    "type": "string"
+                },
+                "holdings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.BatchHolding"
+                    }
+                },
+                "movements": {
+                    "description": "Every ledger movement, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.BatchMovement"
+                    }
+                },
+                "on_hand": {
+                    "type": "integer"
+                },
+                "product_code": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "received": {
+                    "description": "Received from suppliers",
+                    "type": "integer"
+                },
+                "sources": {
+                    "description": "Goods receipts and direct receipts",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.BatchDocument"
+                    }
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.CategoryValuation": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "cost_of_goods_issued": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "service.ControlledMovement": {
+            "type": "object",
+            "properties": {
+                "authorized_by": {
+                    "type": "integer"
+                },
+                "authorized_by_name": {
+                    "type": "string"
+                },
+                "balance": {
+                    "description": "Office balance after the movement",
+                    "type": "integer"
+                },
+                "batch_number": {
+                    "type": "string"
+                },
+                "counterparty": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "entry_id": {
+                    "type": "string"
+                },
+                "in": {
+                    "type": "integer"
+                },
+                "internal_transfer": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "out": {
+                    "type": "integer"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "warehouse_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.ControlledRegister": {
+            "type": "object",
+            "properties": {
+                "class": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "offices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ControlledRegisterOffice"
+                    }
+                },
+                "period": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.ControlledRegisterOffice": {
+            "type": "object",
+            "properties": {
+                "office_id": {
+                    "type": "string"
+                },
+                "office_name": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ControlledRegisterProduct"
+                    }
+                }
+            }
+        },
+        "service.ControlledRegisterProduct": {
+            "type": "object",
+            "properties": {
+                "closing": {
+                    "type": "integer"
+                },
+                "controlled_class": {
+                    "type": "string"
+                },
+                "in": {
+                    "type": "integer"
+                },
+                "internal_transfer": {
+                    "description": "Net of transfers within the office, non-zero while goods are in transit",
+                    "type": "integer"
+                },
+                "movements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ControlledMovement"
+                    }
+                },
+                "opening": {
+                    "type": "integer"
+                },
+                "out": {
+                    "type": "integer"
+                },
+                "product_code": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
                 }
             }
         },
-        "contract.LoginResponse": {
+        "service.DeadStockCategory": {
             "type": "object",
             "properties": {
-                "role": {
+                "category_id": {
                     "type": "string"
                 },
-                "token": {
+                "category_name": {
                     "type": "string"
                 },
-                "user_identifier": {
-                    "type": "integer"
+                "dead_value": {
+                    "type": "number"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.DeadStockItem"
+                    }
+                },
+                "slow_value": {
+                    "type": "number"
                 }
             }
         },
-        "contract.RegisterRequest": {
+        "service.DeadStockItem": {
             "type": "object",
-            "required": [
-                "email",
-                "office_id",
-                "password",
-                "username"
-            ],
             "properties": {
-                "email": {
+                "class": {
                     "type": "string"
                 },
-                "office_id": {
+                "cover_days": {
+                    "description": "Days the stock lasts at the recent outgoing rate",
+                    "type": "integer"
+                },
+                "idle_days": {
+                    "description": "Days since the last outgoing movement, or since receipt",
+                    "type": "integer"
+                },
+                "last_outgoing_at": {
+                    "description": "Nil when the stock never left the warehouse",
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "outgoing_quantity": {
+                    "type": "integer"
                 },
-                "username": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 3
+                "product_code": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                },
+                "warehouse_name": {
+                    "type": "string"
                 }
             }
         },
-        "contract.RegisterResponse": {
+        "service.DeadStockReport": {
             "type": "object",
             "properties": {
-                "message": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.DeadStockCategory"
+                    }
+                },
+                "days": {
+                    "type": "integer"
+                },
+                "dead_quantity": {
+                    "type": "integer"
+                },
+                "dead_value": {
+                    "type": "number"
+                },
+                "generated_at": {
                     "type": "string"
+                },
+                "slow_cover_days": {
+                    "type": "integer"
+                },
+                "slow_quantity": {
+                    "type": "integer"
+                },
+                "slow_value": {
+                    "type": "number"
                 }
             }
         },
-        "contract.RegisterWithOfficeRequest": {
+        "service.DemandPoint": {
             "type": "object",
-            "required": [
-                "email",
-                "office_code",
-                "office_name",
-                "password",
-                "username"
-            ],
             "properties": {
-                "email": {
+                "period_start": {
                     "type": "string"
                 },
-                "office_address": {
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "service.ExpiringBatch": {
+            "type": "object",
+            "properties": {
+                "batch_number": {
                     "type": "string"
                 },
-                "office_city": {
+                "days_to_expiry": {
+                    "description": "Negative once the batch has expired",
+                    "type": "integer"
+                },
+                "expired": {
+                    "type": "boolean"
+                },
+                "expired_at": {
                     "type": "string"
                 },
-                "office_code": {
-                    "description": "Office fields",
-                    "type": "string",
-                    "maxLength": 10,
-                    "minLength": 2
+                "price": {
+                    "type": "number"
                 },
-                "office_name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
+                "product_code": {
+                    "type": "string"
                 },
-                "office_phone": {
+                "product_id": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "product_name": {
+                    "type": "string"
                 },
-                "username": {
-                    "description": "User fields",
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 3
+                "quantity": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
                 }
             }
         },
-        "contract.RegisterWithOfficeResponse": {
+        "service.ExpiryBranchGroup": {
             "type": "object",
             "properties": {
-                "message": {
+                "branch_id": {
                     "type": "string"
                 },
-                "office_id": {
+                "branch_name": {
                     "type": "string"
                 },
-                "user_id": {
+                "total_quantity": {
                     "type": "integer"
+                },
+                "total_value": {
+                    "type": "number"
+                },
+                "warehouses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ExpiryWarehouseGroup"
+                    }
                 }
             }
         },
-        "dto.CategoryProductCreateRequest": {
+        "service.ExpiryOfficeGroup": {
             "type": "object",
-            "required": [
-                "name",
-                "parent_id"
-            ],
             "properties": {
-                "name": {
-                    "description": "Category name",
-                    "type": "string",
-                    "example": "Precursor"
+                "branches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ExpiryBranchGroup"
+                    }
                 },
-                "parent_id": {
-                    "description": "example:\"123e4567-e89b-12d3-a456-426614174000\"",
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "office_id": {
+                    "type": "string"
+                },
+                "office_name": {
+                    "type": "string"
+                },
+                "total_quantity": {
+                    "type": "integer"
+                },
+                "total_value": {
+                    "type": "number"
                 }
             }
         },
-        "dto.ProductCreateRequest": {
+        "service.ExpiryReport": {
             "type": "object",
-            "required": [
-                "category_id",
-                "code",
-                "content_per_large_unit",
-                "large_unit",
-                "name",
-                "purchase_price",
-                "selling_price",
-                "small_unit"
-            ],
             "properties": {
-                "category_id": {
-                    "description": "Foreign key to category",
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "days": {
+                    "type": "integer"
                 },
-                "code": {
-                    "description": "Product code or SKU",
-                    "type": "string",
-                    "example": "PROD001"
+                "generated_at": {
+                    "type": "string"
                 },
-                "content_per_large_unit": {
-                    "description": "e.g., 12 pieces per box",
-                    "type": "integer",
-                    "minimum": 1,
-                    "example": 12
+                "offices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ExpiryOfficeGroup"
+                    }
                 },
-                "indication": {
-                    "description": "Description or usage",
-                    "type": "string",
-                    "example": "High-performance laptop for professionals"
+                "total_quantity": {
+                    "type": "integer"
                 },
-                "large_unit": {
-                    "description": "e.g., box, pack",
-                    "type": "string",
-                    "example": "box"
+                "total_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "service.ExpiryWarehouseGroup": {
+            "type": "object",
+            "properties": {
+                "batches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ExpiringBatch"
+                    }
                 },
-                "name": {
-                    "description": "Product name",
-                    "type": "string",
-                    "example": "Laptop Dell XPS 13"
+                "total_quantity": {
+                    "type": "integer"
                 },
-                "purchase_price": {
-                    "description": "Cost price",
-                    "type": "number",
-                    "minimum": 0,
-                    "example": 500
+                "total_value": {
+                    "type": "number"
                 },
-                "selling_price": {
-                    "description": "Sale price",
-                    "type": "number",
-                    "minimum": 0,
-                    "example": 750
+                "warehouse_id": {
+                    "type": "string"
                 },
-                "small_unit": {
-                    "description": "e.g., piece, tablet",
-                    "type": "string",
-                    "example": "piece"
+                "warehouse_name": {
+                    "type": "string"
                 }
             }
         },
-        "dto.ProductUpdateRequest": {
+        "service.ForecastPoint": {
             "type": "object",
-            "required": [
-                "category_id",
-                "code",
-                "content_per_large_unit",
-                "large_unit",
-                "name",
-                "purchase_price",
-                "selling_price",
-                "small_unit"
-            ],
             "properties": {
-                "category_id": {
-                    "description": "Foreign key to category",
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "lower": {
+                    "description": "Lower bound of the confidence band, never below zero",
+                    "type": "number"
                 },
-                "code": {
-                    "description": "Product code or SKU",
-                    "type": "string",
-                    "example": "PROD001"
+                "period_start": {
+                    "type": "string"
                 },
-                "content_per_large_unit": {
-                    "description": "e.g., 12 pieces per box",
-                    "type": "integer",
-                    "minimum": 1,
-                    "example": 12
+                "quantity": {
+                    "type": "number"
                 },
-                "indication": {
-                    "description": "Description or usage",
-                    "type": "string",
-                    "example": "High-performance laptop for professionals"
+                "upper": {
+                    "type": "number"
+                }
+            }
+        },
+        "service.ProductForecast": {
+            "type": "object",
+            "properties": {
+                "confidence_level": {
+                    "type": "number"
                 },
-                "large_unit": {
-                    "description": "e.g., box, pack",
-                    "type": "string",
-                    "example": "box"
+                "history": {
+                    "type": "integer"
+                },
+                "horizon": {
+                    "type": "integer"
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
                 },
-                "name": {
-                    "description": "Product name",
-                    "type": "string",
-                    "example": "Laptop Dell XPS 13"
+                "product_code": {
+                    "type": "string"
                 },
-                "purchase_price": {
-                    "description": "Cost price",
-                    "type": "number",
-                    "minimum": 0,
-                    "example": 500
+                "product_id": {
+                    "type": "string"
                 },
-                "selling_price": {
-                    "description": "Sale price",
-                    "type": "number",
-                    "minimum": 0,
-                    "example": 750
+                "product_name": {
+                    "type": "string"
                 },
-                "small_unit": {
-                    "description": "e.g., piece, tablet",
-                    "type": "string",
-                    "example": "piece"
+                "unit": {
+                    "type": "string"
+                },
+                "warehouses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.WarehouseForecast"
+                    }
                 }
             }
         },
-        "model.Branch": {
+        "service.ReorderSuggestion": {
             "type": "object",
             "properties": {
-                "address": {
+                "below_minimum": {
+                    "type": "boolean"
+                },
+                "content_per_large_unit": {
+                    "type": "integer"
+                },
+                "forecast_daily_demand": {
+                    "type": "number"
+                },
+                "large_unit": {
                     "type": "string"
                 },
-                "city": {
+                "large_unit_quantity": {
+                    "type": "integer"
+                },
+                "lead_time_days": {
+                    "type": "integer"
+                },
+                "lead_time_demand": {
+                    "description": "Forecast demand over the supplier lead time",
+                    "type": "integer"
+                },
+                "max_stock": {
+                    "type": "integer"
+                },
+                "min_order_quantity": {
+                    "type": "integer"
+                },
+                "min_stock": {
+                    "type": "integer"
+                },
+                "on_hand": {
+                    "description": "Released, unexpired stock",
+                    "type": "integer"
+                },
+                "on_order": {
+                    "description": "Still to be received on approved purchase orders",
+                    "type": "integer"
+                },
+                "product_code": {
                     "type": "string"
                 },
-                "code": {
+                "product_id": {
                     "type": "string"
                 },
-                "created_at": {
+                "product_name": {
                     "type": "string"
                 },
-                "id": {
+                "projected_stock": {
+                    "description": "On hand and on order minus lead time demand",
+                    "type": "integer"
+                },
+                "reorder_point": {
+                    "type": "integer"
+                },
+                "small_unit": {
                     "type": "string"
                 },
-                "name": {
+                "suggested_quantity": {
+                    "description": "In small units, a whole number of large units",
+                    "type": "integer"
+                },
+                "supplier_id": {
+                    "description": "Preferred vendor from the product-supplier catalog, if any",
                     "type": "string"
                 },
-                "office": {
-                    "$ref": "#/definitions/model.Office"
+                "supplier_name": {
+                    "type": "string"
                 },
-                "office_id": {
+                "warehouse_id": {
                     "type": "string"
                 },
-                "phone": {
+                "warehouse_name": {
                     "type": "string"
+                }
+            }
+        },
+        "service.ShrinkageReason": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer"
                 },
-                "status": {
+                "reason_code": {
                     "type": "string"
                 },
-                "updated_at": {
+                "reason_name": {
                     "type": "string"
                 },
+                "value": {
+                    "type": "number"
+                },
                 "warehouses": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Warehouse"
+                        "$ref": "#/definitions/service.ShrinkageWarehouse"
                     }
                 }
             }
         },
-        "model.CategoryProduct": {
+        "service.ShrinkageReport": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "description": "Timestamp when created",
+                "from": {
                     "type": "string"
                 },
-                "id": {
-                    "description": "Unique identifier",
-                    "type": "string"
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ShrinkageReason"
+                    }
                 },
-                "name": {
-                    "description": "Category name",
+                "to": {
                     "type": "string"
                 },
-                "parent_id": {
-                    "description": "Parent category ID",
+                "total_quantity": {
+                    "type": "integer"
+                },
+                "total_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "service.ShrinkageWarehouse": {
+            "type": "object",
+            "properties": {
+                "movements": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                },
+                "warehouse_id": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "description": "Timestamp when updated",
+                "warehouse_name": {
                     "type": "string"
                 }
             }
         },
-        "model.Office": {
+        "service.StockCard": {
             "type": "object",
             "properties": {
-                "address": {
+                "batch_number": {
                     "type": "string"
                 },
-                "branches": {
+                "closing_balance": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "movements": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Branch"
+                        "$ref": "#/definitions/service.StockCardMovement"
                     }
                 },
-                "city": {
-                    "type": "string"
+                "opening_balance": {
+                    "type": "integer"
                 },
-                "code": {
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "product_code": {
                     "type": "string"
                 },
-                "created_at": {
+                "product_id": {
                     "type": "string"
                 },
-                "id": {
+                "product_name": {
                     "type": "string"
                 },
-                "name": {
+                "to": {
                     "type": "string"
                 },
-                "phone": {
+                "total_count": {
+                    "type": "integer"
+                },
+                "total_in": {
+                    "type": "integer"
+                },
+                "total_out": {
+                    "type": "integer"
+                },
+                "unit": {
                     "type": "string"
                 },
-                "status": {
-                    "description": "e.g., active, inactive",
+                "warehouse_id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "warehouse_name": {
                     "type": "string"
                 }
             }
         },
-        "model.Product": {
+        "service.StockCardMovement": {
             "type": "object",
             "properties": {
-                "category": {
-                    "$ref": "#/definitions/model.CategoryProduct"
-                },
-                "category_id": {
-                    "description": "Foreign key",
-                    "type": "string"
+                "balance": {
+                    "description": "Balance after the movement",
+                    "type": "integer"
                 },
-                "code": {
-                    "description": "Product code or SKU",
+                "batch_number": {
                     "type": "string"
                 },
-                "content_per_large_unit": {
-                    "description": "e.g., 12 pieces per box",
+                "created_by": {
                     "type": "integer"
                 },
-                "created_at": {
-                    "description": "Timestamp when created",
+                "date": {
                     "type": "string"
                 },
-                "id": {
-                    "description": "Unique identifier",
+                "entry_id": {
                     "type": "string"
                 },
-                "indication": {
-                    "description": "Description or usage",
+                "expired_at": {
                     "type": "string"
                 },
-                "large_unit": {
-                    "description": "e.g., box, pack",
+                "in": {
+                    "type": "integer"
+                },
+                "notes": {
                     "type": "string"
                 },
-                "name": {
-                    "description": "Product name",
+                "opening": {
+                    "description": "Balance before the movement",
+                    "type": "integer"
+                },
+                "order_id": {
                     "type": "string"
                 },
-                "purchase_price": {
-                    "description": "Cost price",
-                    "type": "number"
+                "out": {
+                    "type": "integer"
                 },
-                "selling_price": {
-                    "description": "Sale price",
-                    "type": "number"
+                "reason_code": {
+                    "type": "string"
                 },
-                "small_unit": {
-                    "description": "e.g., piece, tablet",
+                "reference_id": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "description": "Timestamp when updated",
+                "status": {
+                    "type": "string"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "model.UnitProduct": {
+        "service.UnitConversion": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "Product code or SKU",
+                "dimension": {
                     "type": "string"
                 },
-                "created_at": {
-                    "description": "Timestamp when created",
+                "from": {
                     "type": "string"
                 },
-                "id": {
-                    "description": "Unique identifier",
-                    "type": "string"
+                "quantity": {
+                    "type": "number"
                 },
-                "name": {
-                    "description": "Product name",
-                    "type": "string"
+                "result": {
+                    "type": "number"
                 },
-                "updated_at": {
-                    "description": "Timestamp when updated",
+                "to": {
                     "type": "string"
                 }
             }
         },
-        "model.Warehouse": {
+        "service.ValuationReport": {
             "type": "object",
             "properties": {
-                "address": {
+                "as_of": {
                     "type": "string"
                 },
-                "branch_id": {
-                    "description": "nullable for now",
-                    "type": "string"
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.CategoryValuation"
+                    }
                 },
-                "code": {
+                "cost_of_goods_issued": {
+                    "type": "number"
+                },
+                "from": {
                     "type": "string"
                 },
-                "created_at": {
+                "total_quantity": {
+                    "type": "integer"
+                },
+                "total_value": {
+                    "type": "number"
+                },
+                "warehouses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.WarehouseValuation"
+                    }
+                }
+            }
+        },
+        "service.WarehouseForecast": {
+            "type": "object",
+            "properties": {
+                "forecast": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ForecastPoint"
+                    }
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.DemandPoint"
+                    }
+                },
+                "mean_absolute_error": {
+                    "description": "Of one-step forecasts over the history",
+                    "type": "number"
+                },
+                "method": {
+                    "description": "Method used, the best fit when auto was requested",
                     "type": "string"
                 },
-                "id": {
+                "seasonal": {
+                    "type": "boolean"
+                },
+                "seasonal_indices": {
+                    "description": "Demand of each period in the season relative to the average",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "warehouse_id": {
                     "type": "string"
                 },
-                "name": {
+                "warehouse_name": {
                     "type": "string"
+                }
+            }
+        },
+        "service.WarehouseValuation": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.CategoryValuation"
+                    }
+                },
+                "cost_of_goods_issued": {
+                    "type": "number"
                 },
                 "office_id": {
-                    "description": "nullable for now",
                     "type": "string"
                 },
-                "phone": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "valuation_method": {
                     "type": "string"
                 },
-                "status": {
+                "value": {
+                    "type": "number"
+                },
+                "warehouse_id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "warehouse_name": {
                     "type": "string"
                 }
             }
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/google/uuid"
+)
+
+// LandedCostChargeRequest represents one extra charge on a receipt
+type LandedCostChargeRequest struct {
+	Type        string  `json:"type" validate:"required" example:"freight"` // freight, insurance, duty or other
+	Description string  `json:"description" example:"Sea freight Jakarta"`
+	Amount      float64 `json:"amount" validate:"required,gt=0" example:"1500000"`
+}
+
+// LandedCostLineRequest represents a receipt line to allocate the charges to
+type LandedCostLineRequest struct {
+	ReceiptLineID uuid.UUID `json:"receipt_line_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Goods receipt line
+	Weight        float64   `json:"weight" example:"250.5"`                                                             // Total weight of the line, required by the weight method
+}
+
+// LandedCostRequest represents the request body for allocating extra charges to a goods receipt
+type LandedCostRequest struct {
+	ReceiptID uuid.UUID                 `json:"receipt_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Goods receipt the charges belong to
+	Method    string                    `json:"method" example:"value"`                                                        // value, quantity or weight, defaults to value
+	Date      time.Time                 `json:"date" example:"2024-02-15T00:00:00Z"`                                           // Date the cost takes effect, defaults to now
+	Reference string                    `json:"reference" example:"INV-FWD-2024-0042"`
+	Notes     string                    `json:"notes" example:"Import of February shipment"`
+	Charges   []LandedCostChargeRequest `json:"charges" validate:"required,min=1,dive"`
+	Lines     []LandedCostLineRequest   `json:"lines" validate:"dive"` // Defaults to every line of the receipt
+}
+
+// LandedCostReverseRequest represents the request body for reversing a posted landed cost
+type LandedCostReverseRequest struct {
+	Reason string `json:"reason" validate:"required" example:"Freight invoice was credited by the forwarder"`
+}
+
+// ToLandedCost converts LandedCostRequest to LandedCost model
+func (req *LandedCostRequest) ToLandedCost() *model.LandedCost {
+	charges := make([]model.LandedCostCharge, len(req.Charges))
+	for i, charge := range req.Charges {
+		charges[i] = model.LandedCostCharge{
+			Type:        charge.Type,
+			Description: charge.Description,
+			Amount:      charge.Amount,
+		}
+	}
+	lines := make([]model.LandedCostLine, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = model.LandedCostLine{
+			ReceiptLineID: line.ReceiptLineID,
+			Weight:        line.Weight,
+		}
+	}
+	return &model.LandedCost{
+		ReceiptID: req.ReceiptID,
+		Method:    req.Method,
+		Date:      req.Date,
+		Reference: req.Reference,
+		Notes:     req.Notes,
+		Charges:   charges,
+		Lines:     lines,
+	}
+}
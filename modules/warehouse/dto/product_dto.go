@@ -7,28 +7,48 @@ import (
 
 // ProductCreateRequest represents the request body for creating a product
 type ProductCreateRequest struct {
-	Code                string    `json:"code" validate:"required" example:"PROD001"`                                     // Product code or SKU
-	Name                string    `json:"name" validate:"required" example:"Laptop Dell XPS 13"`                          // Product name
-	LargeUnit           string    `json:"large_unit" validate:"required" example:"box"`                                   // e.g., box, pack
-	ContentPerLargeUnit int       `json:"content_per_large_unit" validate:"required,min=1" example:"12"`                  // e.g., 12 pieces per box
-	SmallUnit           string    `json:"small_unit" validate:"required" example:"piece"`                                 // e.g., piece, tablet
-	PurchasePrice       float64   `json:"purchase_price" validate:"required,min=0" example:"500.00"`                      // Cost price
-	SellingPrice        float64   `json:"selling_price" validate:"required,min=0" example:"750.00"`                       // Sale price
-	CategoryID          uuid.UUID `json:"category_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Foreign key to category
-	Indication          string    `json:"indication" example:"High-performance laptop for professionals"`                 // Description or usage
+	Code                string                  `json:"code" validate:"required" example:"PROD001"`                                     // Product code or SKU
+	Name                string                  `json:"name" validate:"required" example:"Laptop Dell XPS 13"`                          // Product name
+	LargeUnit           string                  `json:"large_unit" example:"box"`                                                       // e.g., box, pack
+	ContentPerLargeUnit int                     `json:"content_per_large_unit" validate:"min=0" example:"12"`                           // e.g., 12 pieces per box
+	SmallUnit           string                  `json:"small_unit" example:"piece"`                                                     // e.g., piece, tablet
+	PurchasePrice       float64                 `json:"purchase_price" validate:"required,min=0" example:"500.00"`                      // Cost price
+	SellingPrice        float64                 `json:"selling_price" validate:"required,min=0" example:"750.00"`                       // Sale price
+	CategoryID          uuid.UUID               `json:"category_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Foreign key to category
+	Indication          string                  `json:"indication" example:"High-performance laptop for professionals"`                 // Description or usage
+	Controlled          bool                    `json:"controlled" example:"false"`                                                     // Controlled substance under regulator reporting
+	ControlledClass     string                  `json:"controlled_class" example:"precursor"`                                           // narcotic, psychotropic or precursor when controlled
+	Units               []ProductUnitRequest    `json:"units"`                                                                          // Packaging hierarchy, base unit first. When set, the small and large unit fields are derived from it.
+	Barcodes            []ProductBarcodeRequest `json:"barcodes"`                                                                       // EAN-13, EAN-8 or UPC-A barcodes of the packaging units
 }
 
 // ProductUpdateRequest represents the request body for updating a product
 type ProductUpdateRequest struct {
-	Code                string    `json:"code" validate:"required" example:"PROD001"`                                     // Product code or SKU
-	Name                string    `json:"name" validate:"required" example:"Laptop Dell XPS 13"`                          // Product name
-	LargeUnit           string    `json:"large_unit" validate:"required" example:"box"`                                   // e.g., box, pack
-	ContentPerLargeUnit int       `json:"content_per_large_unit" validate:"required,min=1" example:"12"`                  // e.g., 12 pieces per box
-	SmallUnit           string    `json:"small_unit" validate:"required" example:"piece"`                                 // e.g., piece, tablet
-	PurchasePrice       float64   `json:"purchase_price" validate:"required,min=0" example:"500.00"`                      // Cost price
-	SellingPrice        float64   `json:"selling_price" validate:"required,min=0" example:"750.00"`                       // Sale price
-	CategoryID          uuid.UUID `json:"category_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Foreign key to category
-	Indication          string    `json:"indication" example:"High-performance laptop for professionals"`                 // Description or usage
+	Code                string                  `json:"code" validate:"required" example:"PROD001"`                                     // Product code or SKU
+	Name                string                  `json:"name" validate:"required" example:"Laptop Dell XPS 13"`                          // Product name
+	LargeUnit           string                  `json:"large_unit" example:"box"`                                                       // e.g., box, pack
+	ContentPerLargeUnit int                     `json:"content_per_large_unit" validate:"min=0" example:"12"`                           // e.g., 12 pieces per box
+	SmallUnit           string                  `json:"small_unit" example:"piece"`                                                     // e.g., piece, tablet
+	PurchasePrice       float64                 `json:"purchase_price" validate:"required,min=0" example:"500.00"`                      // Cost price
+	SellingPrice        float64                 `json:"selling_price" validate:"required,min=0" example:"750.00"`                       // Sale price
+	CategoryID          uuid.UUID               `json:"category_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Foreign key to category
+	Indication          string                  `json:"indication" example:"High-performance laptop for professionals"`                 // Description or usage
+	Controlled          bool                    `json:"controlled" example:"false"`                                                     // Controlled substance under regulator reporting
+	ControlledClass     string                  `json:"controlled_class" example:"precursor"`                                           // narcotic, psychotropic or precursor when controlled
+	Units               []ProductUnitRequest    `json:"units"`                                                                          // Packaging hierarchy, base unit first. When set, the small and large unit fields are derived from it. Omit to keep the current hierarchy.
+	Barcodes            []ProductBarcodeRequest `json:"barcodes"`                                                                       // EAN-13, EAN-8 or UPC-A barcodes of the packaging units. Omit to keep the current barcodes.
+}
+
+// ProductUnitRequest is one level of a product's packaging hierarchy
+type ProductUnitRequest struct {
+	UnitID  uuid.UUID `json:"unit_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Unit product
+	Content int       `json:"content" example:"10"`                                                       // Units of the previous level in one of this unit, ignored for the base unit
+}
+
+// ProductBarcodeRequest is a barcode printed on one packaging unit of a product
+type ProductBarcodeRequest struct {
+	Code   string    `json:"code" validate:"required" example:"8992772311113"`                           // EAN-13, EAN-8 or UPC-A with a valid check digit
+	UnitID uuid.UUID `json:"unit_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Unit of the product the barcode is printed on
 }
 
 // ToProduct converts ProductCreateRequest to Product model
@@ -43,6 +63,10 @@ func (req *ProductCreateRequest) ToProduct() *model.Product {
 		SellingPrice:        req.SellingPrice,
 		CategoryID:          req.CategoryID,
 		Indication:          req.Indication,
+		Controlled:          req.Controlled,
+		ControlledClass:     req.ControlledClass,
+		Units:               toProductUnits(req.Units),
+		Barcodes:            toProductBarcodes(req.Barcodes),
 	}
 }
 
@@ -58,5 +82,41 @@ func (req *ProductUpdateRequest) ToProduct() *model.Product {
 		SellingPrice:        req.SellingPrice,
 		CategoryID:          req.CategoryID,
 		Indication:          req.Indication,
+		Controlled:          req.Controlled,
+		ControlledClass:     req.ControlledClass,
+		Units:               toProductUnits(req.Units),
+		Barcodes:            toProductBarcodes(req.Barcodes),
+	}
+}
+
+// toProductUnits converts the packaging levels of a product request, keeping
+// their order. Levels left out of the request stay nil.
+func toProductUnits(levels []ProductUnitRequest) []model.ProductUnit {
+	if levels == nil {
+		return nil
+	}
+	units := make([]model.ProductUnit, len(levels))
+	for i, level := range levels {
+		units[i] = model.ProductUnit{
+			UnitID:  level.UnitID,
+			Content: level.Content,
+		}
+	}
+	return units
+}
+
+// toProductBarcodes converts the barcodes of a product request. Barcodes left
+// out of the request stay nil.
+func toProductBarcodes(barcodes []ProductBarcodeRequest) []model.ProductBarcode {
+	if barcodes == nil {
+		return nil
+	}
+	result := make([]model.ProductBarcode, len(barcodes))
+	for i, barcode := range barcodes {
+		result[i] = model.ProductBarcode{
+			Code:   barcode.Code,
+			UnitID: barcode.UnitID,
+		}
 	}
+	return result
 }
@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/google/uuid"
+)
+
+// PurchaseOrderLineRequest represents one ordered product
+type PurchaseOrderLineRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Ordered product
+	Unit      string    `json:"unit" example:"box"`                                                            // Any unit of the product, defaults to the small unit
+	Quantity  int       `json:"quantity" validate:"required,min=1" example:"10"`                               // Quantity in the ordered unit
+	Price     float64   `json:"price" validate:"min=0" example:"120000"`                                       // Price per ordered unit
+	Notes     string    `json:"notes" example:"Deliver with cold chain"`
+}
+
+// PurchaseOrderRequest represents the request body for creating or editing a draft purchase order
+type PurchaseOrderRequest struct {
+	WarehouseID  uuid.UUID                  `json:"warehouse_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Receiving warehouse
+	SupplierID   *uuid.UUID                 `json:"supplier_id" example:"123e4567-e89b-12d3-a456-426614174000"`                      // Supplier to order from
+	SupplierName string                     `json:"supplier_name" example:"PT Kimia Farma"`                                          // Free text when no supplier is set
+	OrderDate    time.Time                  `json:"order_date" example:"2024-01-15T00:00:00Z"`                                       // Defaults to now
+	ExpectedDate *time.Time                 `json:"expected_date" example:"2024-01-22T00:00:00Z"`                                    // Expected delivery date
+	Notes        string                     `json:"notes" example:"Monthly replenishment"`
+	Lines        []PurchaseOrderLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+// GoodsReceiptLineRequest represents one received batch of an order line
+type GoodsReceiptLineRequest struct {
+	LineID      uuid.UUID `json:"line_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Purchase order line ID
+	BatchNumber string    `json:"batch_number" example:"BATCH-2024-001"`                                      // Supplier batch number
+	ExpiredAt   time.Time `json:"expired_at" example:"2026-12-31T00:00:00Z"`                                  // Batch expiry date
+	Quantity    int       `json:"quantity" validate:"required,min=1" example:"120"`                           // Received quantity in the given unit
+	Unit        string    `json:"unit" example:"box"`                                                         // Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit
+	Price       float64   `json:"price" validate:"min=0" example:"10000"`                                     // Unit cost per small unit, defaults to the ordered price
+	QCStatus    string    `json:"qc_status" example:"quarantine"`                                             // released or quarantine, warehouses holding receipts for QC always quarantine
+}
+
+// GoodsReceiptRequest represents the request body for receiving goods against a purchase order
+type GoodsReceiptRequest struct {
+	Date  time.Time                 `json:"date" example:"2024-01-20T00:00:00Z"` // Receipt date, defaults to now
+	Notes string                    `json:"notes" example:"Delivery note DN-4471"`
+	Lines []GoodsReceiptLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+// ToPurchaseOrder converts PurchaseOrderRequest to PurchaseOrder model
+func (req *PurchaseOrderRequest) ToPurchaseOrder() *model.PurchaseOrder {
+	lines := make([]model.PurchaseOrderLine, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		lines = append(lines, model.PurchaseOrderLine{
+			ProductID: line.ProductID,
+			Unit:      line.Unit,
+			Quantity:  line.Quantity,
+			Price:     line.Price,
+			Notes:     line.Notes,
+		})
+	}
+	return &model.PurchaseOrder{
+		WarehouseID:  req.WarehouseID,
+		SupplierID:   req.SupplierID,
+		SupplierName: req.SupplierName,
+		OrderDate:    req.OrderDate,
+		ExpectedDate: req.ExpectedDate,
+		Notes:        req.Notes,
+		Lines:        lines,
+	}
+}
+
+// ToGoodsReceipt converts GoodsReceiptRequest to GoodsReceipt model
+func (req *GoodsReceiptRequest) ToGoodsReceipt() *model.GoodsReceipt {
+	lines := make([]model.GoodsReceiptLine, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		lines = append(lines, model.GoodsReceiptLine{
+			OrderLineID: line.LineID,
+			BatchNumber: line.BatchNumber,
+			ExpiredAt:   line.ExpiredAt,
+			Quantity:    line.Quantity,
+			Unit:        line.Unit,
+			Price:       line.Price,
+			QCStatus:    line.QCStatus,
+		})
+	}
+	return &model.GoodsReceipt{
+		Date:  req.Date,
+		Notes: req.Notes,
+		Lines: lines,
+	}
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/google/uuid"
+)
+
+// RecallRequest represents the request body for opening a batch recall
+type RecallRequest struct {
+	ProductID   uuid.UUID  `json:"product_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Recalled product
+	BatchNumber string     `json:"batch_number" validate:"required" example:"BATCH-2024-001"`                     // Recalled batch
+	SupplierID  *uuid.UUID `json:"supplier_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`          // Supplier or manufacturer issuing the recall
+	Reference   string     `json:"reference" example:"BPOM/RC/2024/015"`                                          // Recall notice number
+	Reason      string     `json:"reason" validate:"required" example:"Contamination found in batch"`             // Why the batch is recalled
+	Action      string     `json:"action" example:"return"`                                                       // return or dispose, defaults to return
+	Notes       string     `json:"notes" example:"Supplier collects from every branch"`
+}
+
+// RecallTaskCompleteRequest represents the request body for completing a recall task
+type RecallTaskCompleteRequest struct {
+	Action       string `json:"action" example:"dispose"`                      // Overrides the task action, return or dispose
+	AuthorizedBy uint   `json:"authorized_by" example:"1"`                     // Authorizing user holding the controlled authorizer role, required for controlled products
+	Counterparty string `json:"counterparty" example:"PT Kimia Farma courier"` // Supplier or disposal party, required for controlled products
+	Notes        string `json:"notes" example:"Handed over to the supplier courier"`
+}
+
+// ToRecall converts RecallRequest to Recall model
+func (req *RecallRequest) ToRecall() *model.Recall {
+	return &model.Recall{
+		ProductID:   req.ProductID,
+		BatchNumber: req.BatchNumber,
+		SupplierID:  req.SupplierID,
+		Reference:   req.Reference,
+		Reason:      req.Reason,
+		Action:      req.Action,
+		Notes:       req.Notes,
+	}
+}
@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/google/uuid"
+)
+
+// ReorderSettingRequest represents the request body for setting replenishment levels of a product in a warehouse
+type ReorderSettingRequest struct {
+	WarehouseID  uuid.UUID `json:"warehouse_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Stocking warehouse
+	ProductID    uuid.UUID `json:"product_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`   // Replenished product
+	MinStock     int       `json:"min_stock" validate:"min=0" example:"50"`                                         // Safety stock in small units
+	ReorderPoint int       `json:"reorder_point" validate:"min=0" example:"120"`                                    // Order at or below this level, defaults to min stock
+	MaxStock     int       `json:"max_stock" validate:"required,min=1" example:"600"`                               // Order up to this level
+}
+
+// ToReorderSetting converts ReorderSettingRequest to ReorderSetting model
+func (req *ReorderSettingRequest) ToReorderSetting() *model.ReorderSetting {
+	return &model.ReorderSetting{
+		WarehouseID:  req.WarehouseID,
+		ProductID:    req.ProductID,
+		MinStock:     req.MinStock,
+		ReorderPoint: req.ReorderPoint,
+		MaxStock:     req.MaxStock,
+	}
+}
@@ -0,0 +1,98 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/google/uuid"
+)
+
+// AdjustmentReasonRequest represents the request body for creating or updating an adjustment reason code
+type AdjustmentReasonRequest struct {
+	Code             string `json:"code" validate:"required" example:"DAMAGE"`
+	Name             string `json:"name" validate:"required" example:"Damaged goods"`
+	Description      string `json:"description" example:"Broken or spoiled during storage or handling"`
+	Direction        string `json:"direction" example:"decrease"`      // increase, decrease or both, defaults to both
+	RequiresApproval bool   `json:"requires_approval" example:"false"` // Always route adjustments with this reason to approval
+	Status           string `json:"status" example:"active"`           // active or inactive, defaults to active
+}
+
+// StockAdjustmentLineRequest represents one signed correction of a product/batch
+type StockAdjustmentLineRequest struct {
+	ProductID   uuid.UUID `json:"product_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Adjusted product
+	BatchNumber string    `json:"batch_number" example:"BATCH-2024-001"`                                         // Adjusted batch
+	ExpiredAt   time.Time `json:"expired_at" example:"2026-12-31T00:00:00Z"`                                     // Batch expiry date for new batches
+	ReasonCode  string    `json:"reason_code" validate:"required" example:"DAMAGE"`                              // Adjustment reason code
+	Quantity    int       `json:"quantity" validate:"required" example:"-2"`                                     // Signed quantity, negative reduces stock
+	Unit        string    `json:"unit" example:"box"`                                                            // Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit
+	Price       float64   `json:"price" validate:"min=0" example:"5000"`                                         // Cost per small unit for increases, decreases use the batch cost
+	Notes       string    `json:"notes" example:"Carton crushed by forklift"`
+}
+
+// StockAdjustmentRequest represents the request body for creating or updating a stock adjustment document
+type StockAdjustmentRequest struct {
+	WarehouseID  uuid.UUID                    `json:"warehouse_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Adjusted warehouse
+	Date         time.Time                    `json:"date" example:"2024-01-31T00:00:00Z"`                                             // Movement date, defaults to now
+	Notes        string                       `json:"notes" example:"Damage found during weekly inspection"`
+	AuthorizedBy uint                         `json:"authorized_by" example:"1"`                                         // Authorizing user holding the controlled authorizer role, required for controlled products unless the adjustment goes through approval
+	Counterparty string                       `json:"counterparty" example:"Destruction witnessed by the health office"` // Where the stock came from or went to, required for controlled products
+	Lines        []StockAdjustmentLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+// StockAdjustmentRejectRequest represents the request body for rejecting an adjustment
+type StockAdjustmentRejectRequest struct {
+	Reason string `json:"reason" validate:"required" example:"Photos do not show the damaged cartons"`
+}
+
+// StockAdjustmentAttachmentRequest represents supporting evidence already stored in file storage
+type StockAdjustmentAttachmentRequest struct {
+	FileName    string `json:"file_name" validate:"required" example:"damage-photo-1.jpg"`
+	URL         string `json:"url" validate:"required" example:"https://files.example.com/adjustments/damage-photo-1.jpg"`
+	ContentType string `json:"content_type" example:"image/jpeg"`
+}
+
+// ToAdjustmentReason converts AdjustmentReasonRequest to AdjustmentReason model
+func (req *AdjustmentReasonRequest) ToAdjustmentReason() *model.AdjustmentReason {
+	return &model.AdjustmentReason{
+		Code:             req.Code,
+		Name:             req.Name,
+		Description:      req.Description,
+		Direction:        req.Direction,
+		RequiresApproval: req.RequiresApproval,
+		Status:           req.Status,
+	}
+}
+
+// ToStockAdjustment converts StockAdjustmentRequest to StockAdjustment model
+func (req *StockAdjustmentRequest) ToStockAdjustment() *model.StockAdjustment {
+	lines := make([]model.StockAdjustmentLine, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = model.StockAdjustmentLine{
+			ProductID:   line.ProductID,
+			BatchNumber: line.BatchNumber,
+			ExpiredAt:   line.ExpiredAt,
+			ReasonCode:  line.ReasonCode,
+			Quantity:    line.Quantity,
+			Unit:        line.Unit,
+			Price:       line.Price,
+			Notes:       line.Notes,
+		}
+	}
+	return &model.StockAdjustment{
+		WarehouseID:  req.WarehouseID,
+		Date:         req.Date,
+		Notes:        req.Notes,
+		AuthorizedBy: req.AuthorizedBy,
+		Counterparty: req.Counterparty,
+		Lines:        lines,
+	}
+}
+
+// ToAttachment converts StockAdjustmentAttachmentRequest to StockAdjustmentAttachment model
+func (req *StockAdjustmentAttachmentRequest) ToAttachment() *model.StockAdjustmentAttachment {
+	return &model.StockAdjustmentAttachment{
+		FileName:    req.FileName,
+		URL:         req.URL,
+		ContentType: req.ContentType,
+	}
+}
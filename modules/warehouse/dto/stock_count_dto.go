@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/google/uuid"
+)
+
+// StockCountRequest represents the request body for opening a stock count session
+type StockCountRequest struct {
+	WarehouseID uuid.UUID `json:"warehouse_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Counted warehouse
+	Blind       bool      `json:"blind" example:"true"`                                                            // Hide expected quantities from counters
+	Notes       string    `json:"notes" example:"Year-end stock opname"`
+}
+
+// StockCountLineRequest represents one counted quantity
+type StockCountLineRequest struct {
+	LineID      uuid.UUID `json:"line_id" example:"123e4567-e89b-12d3-a456-426614174000"`    // Snapshot line, empty to match by product and batch
+	ProductID   uuid.UUID `json:"product_id" example:"123e4567-e89b-12d3-a456-426614174000"` // Counted product when no line ID is given
+	BatchNumber string    `json:"batch_number" example:"BATCH-2024-001"`                     // Counted batch when no line ID is given
+	ExpiredAt   time.Time `json:"expired_at" example:"2026-12-31T00:00:00Z"`                 // Batch expiry date for stock found outside the snapshot
+	Quantity    int       `json:"quantity" validate:"min=0" example:"98"`                    // Counted quantity in the given unit
+	Unit        string    `json:"unit" example:"box"`                                        // Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit
+	Notes       string    `json:"notes" example:"2 boxes found on lower shelf"`
+}
+
+// StockCountEntriesRequest represents the request body for recording counted quantities
+type StockCountEntriesRequest struct {
+	Lines []StockCountLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+// StockCountApproveRequest represents the request body for approving a stock count
+type StockCountApproveRequest struct {
+	Counterparty string `json:"counterparty" example:"Count witnessed by the pharmacist in charge"` // Recorded with the variances, required when a controlled product has one
+}
+
+// ToStockCount converts StockCountRequest to StockCount model
+func (req *StockCountRequest) ToStockCount() *model.StockCount {
+	return &model.StockCount{
+		WarehouseID: req.WarehouseID,
+		Blind:       req.Blind,
+		Notes:       req.Notes,
+	}
+}
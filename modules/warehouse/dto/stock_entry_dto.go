@@ -0,0 +1,133 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/google/uuid"
+)
+
+// StockReceiptRequest represents the request body for receiving stock into a warehouse
+type StockReceiptRequest struct {
+	WarehouseID  uuid.UUID `json:"warehouse_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Destination warehouse
+	ProductID    uuid.UUID `json:"product_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`   // Received product
+	BatchNumber  string    `json:"batch_number" example:"BATCH-2024-001"`                                           // Supplier batch number
+	ExpiredAt    time.Time `json:"expired_at" example:"2026-12-31T00:00:00Z"`                                       // Batch expiry date
+	Date         time.Time `json:"date" example:"2024-01-15T00:00:00Z"`                                             // Movement date, defaults to now
+	Quantity     int       `json:"quantity" validate:"required,min=1" example:"100"`                                // Quantity in the given unit
+	Unit         string    `json:"unit" example:"box"`                                                              // Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit
+	Price        float64   `json:"price" validate:"min=0" example:"5000"`                                           // Unit cost per small unit
+	Margin       float64   `json:"margin" example:"10"`                                                             // Margin percentage
+	Tax          float64   `json:"tax" example:"11"`                                                                // Tax percentage
+	QCStatus     string    `json:"qc_status" example:"quarantine"`                                                  // released or quarantine, warehouses holding receipts for QC always quarantine
+	OrderID      uuid.UUID `json:"order_id" example:"123e4567-e89b-12d3-a456-426614174000"`                         // Originating order
+	ReferenceID  uuid.UUID `json:"reference_id" example:"123e4567-e89b-12d3-a456-426614174000"`                     // Originating document
+	Notes        string    `json:"notes" example:"Initial stock"`
+	AuthorizedBy uint      `json:"authorized_by" example:"1"`             // Authorizing user holding the controlled authorizer role, required for controlled products
+	Counterparty string    `json:"counterparty" example:"PT Kimia Farma"` // Supplier or sender, required for controlled products
+}
+
+// StockIssueRequest represents the request body for issuing stock out of a warehouse
+type StockIssueRequest struct {
+	WarehouseID  uuid.UUID `json:"warehouse_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Source warehouse
+	ProductID    uuid.UUID `json:"product_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`   // Issued product
+	BatchNumber  string    `json:"batch_number" example:"BATCH-2024-001"`                                           // Batch to issue from, empty to allocate by FEFO/FIFO
+	Date         time.Time `json:"date" example:"2024-01-20T00:00:00Z"`                                             // Movement date, defaults to now
+	Quantity     int       `json:"quantity" validate:"required,min=1" example:"10"`                                 // Quantity in the given unit
+	Unit         string    `json:"unit" example:"box"`                                                              // Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit
+	ReferenceID  uuid.UUID `json:"reference_id" example:"123e4567-e89b-12d3-a456-426614174000"`                     // Originating document
+	Notes        string    `json:"notes" example:"Dispensed to outpatient"`
+	AuthorizedBy uint      `json:"authorized_by" example:"1"`                // Authorizing user holding the controlled authorizer role, required for controlled products
+	Counterparty string    `json:"counterparty" example:"Patient MR-001234"` // Patient or prescriber, required for controlled products
+}
+
+// StockWriteOffRequest represents the request body for writing off an expired or unusable batch
+type StockWriteOffRequest struct {
+	WarehouseID  uuid.UUID `json:"warehouse_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Warehouse holding the batch
+	ProductID    uuid.UUID `json:"product_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`   // Written-off product
+	BatchNumber  string    `json:"batch_number" validate:"required" example:"BATCH-2024-001"`                       // Written-off batch
+	Date         time.Time `json:"date" example:"2024-02-01T00:00:00Z"`                                             // Movement date, defaults to now
+	Quantity     int       `json:"quantity" validate:"min=0" example:"0"`                                           // Quantity in the given unit, 0 writes off the whole batch
+	Unit         string    `json:"unit" example:"box"`                                                              // Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit
+	ReferenceID  uuid.UUID `json:"reference_id" example:"123e4567-e89b-12d3-a456-426614174000"`                     // Originating document
+	Notes        string    `json:"notes" example:"Expired, sent for destruction"`
+	AuthorizedBy uint      `json:"authorized_by" example:"1"`                                         // Authorizing user holding the controlled authorizer role, required for controlled products
+	Counterparty string    `json:"counterparty" example:"Destruction witnessed by the health office"` // Where the stock went, required for controlled products
+}
+
+// QCDecisionRequest represents the request body for a QC decision on a batch
+type QCDecisionRequest struct {
+	WarehouseID uuid.UUID `json:"warehouse_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Warehouse holding the batch
+	ProductID   uuid.UUID `json:"product_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`   // Product of the batch
+	BatchNumber string    `json:"batch_number" validate:"required" example:"BATCH-2024-001"`                       // Batch the decision applies to
+	ReferenceID uuid.UUID `json:"reference_id" example:"123e4567-e89b-12d3-a456-426614174000"`                     // Certificate of analysis or QC record
+	Notes       string    `json:"notes" example:"Certificate of analysis checked"`                                 // Required when rejecting
+}
+
+// ToStockEntry converts StockReceiptRequest to StockEntry model
+func (req *StockReceiptRequest) ToStockEntry() *model.StockEntry {
+	return &model.StockEntry{
+		WarehouseID:  req.WarehouseID,
+		ProductID:    req.ProductID,
+		BatchNumber:  req.BatchNumber,
+		ExpiredAt:    req.ExpiredAt,
+		Date:         req.Date,
+		Quantity:     req.Quantity,
+		Unit:         req.Unit,
+		Price:        req.Price,
+		Margin:       req.Margin,
+		Tax:          req.Tax,
+		Status:       model.StockEntryStatusReceipt,
+		QCStatus:     req.QCStatus,
+		OrderID:      req.OrderID,
+		ReferenceID:  req.ReferenceID,
+		Notes:        req.Notes,
+		AuthorizedBy: req.AuthorizedBy,
+		Counterparty: req.Counterparty,
+	}
+}
+
+// ToStockEntry converts StockIssueRequest to StockEntry model
+func (req *StockIssueRequest) ToStockEntry() *model.StockEntry {
+	return &model.StockEntry{
+		WarehouseID:  req.WarehouseID,
+		ProductID:    req.ProductID,
+		BatchNumber:  req.BatchNumber,
+		Date:         req.Date,
+		Quantity:     -req.Quantity,
+		Unit:         req.Unit,
+		Status:       model.StockEntryStatusIssue,
+		ReferenceID:  req.ReferenceID,
+		Notes:        req.Notes,
+		AuthorizedBy: req.AuthorizedBy,
+		Counterparty: req.Counterparty,
+	}
+}
+
+// ToStockEntry converts StockWriteOffRequest to StockEntry model
+func (req *StockWriteOffRequest) ToStockEntry() *model.StockEntry {
+	return &model.StockEntry{
+		WarehouseID:  req.WarehouseID,
+		ProductID:    req.ProductID,
+		BatchNumber:  req.BatchNumber,
+		Date:         req.Date,
+		Quantity:     -req.Quantity,
+		Unit:         req.Unit,
+		Status:       model.StockEntryStatusWriteOff,
+		ReferenceID:  req.ReferenceID,
+		Notes:        req.Notes,
+		AuthorizedBy: req.AuthorizedBy,
+		Counterparty: req.Counterparty,
+	}
+}
+
+// ToStockEntry converts QCDecisionRequest to StockEntry model
+func (req *QCDecisionRequest) ToStockEntry() *model.StockEntry {
+	return &model.StockEntry{
+		WarehouseID: req.WarehouseID,
+		ProductID:   req.ProductID,
+		BatchNumber: req.BatchNumber,
+		ReferenceID: req.ReferenceID,
+		Notes:       req.Notes,
+	}
+}
@@ -0,0 +1,7 @@
+package dto
+
+// StockPeriodCloseRequest represents the request body for closing a stock period
+type StockPeriodCloseRequest struct {
+	Period string `json:"period" validate:"required" example:"2024-03"` // Month to close, YYYY-MM
+	Notes  string `json:"notes" example:"March closing"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/google/uuid"
+)
+
+// StockReservationRequest represents the request body for reserving stock for a confirmed document
+type StockReservationRequest struct {
+	WarehouseID   uuid.UUID  `json:"warehouse_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Warehouse holding the stock
+	ProductID     uuid.UUID  `json:"product_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`   // Reserved product
+	BatchNumber   string     `json:"batch_number" example:"BATCH-2024-001"`                                           // Batch to issue on fulfilment, empty to allocate by FEFO/FIFO
+	Quantity      int        `json:"quantity" validate:"required,min=1" example:"10"`                                 // Quantity in the given unit
+	Unit          string     `json:"unit" example:"box"`                                                              // Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit
+	ReferenceType string     `json:"reference_type" example:"sales_order"`                                            // Kind of document holding the reservation
+	ReferenceID   uuid.UUID  `json:"reference_id" example:"123e4567-e89b-12d3-a456-426614174000"`                     // Document holding the reservation
+	ExpiresAt     *time.Time `json:"expires_at" example:"2024-01-22T00:00:00Z"`                                       // Release automatically after this time, empty to hold until released
+	Notes         string     `json:"notes" example:"Reserved for order SO-1024"`
+}
+
+// StockReservationFulfillRequest represents the request body for fulfilling a reservation
+type StockReservationFulfillRequest struct {
+	AuthorizedBy uint   `json:"authorized_by" example:"1"`                // Authorizing user holding the controlled authorizer role, required for controlled products
+	Counterparty string `json:"counterparty" example:"Patient MR-001234"` // Patient or receiving party, required for controlled products
+}
+
+// ToStockReservation converts StockReservationRequest to StockReservation model
+func (req *StockReservationRequest) ToStockReservation() *model.StockReservation {
+	return &model.StockReservation{
+		WarehouseID:   req.WarehouseID,
+		ProductID:     req.ProductID,
+		BatchNumber:   req.BatchNumber,
+		Quantity:      req.Quantity,
+		Unit:          req.Unit,
+		ReferenceType: req.ReferenceType,
+		ReferenceID:   req.ReferenceID,
+		ExpiresAt:     req.ExpiresAt,
+		Notes:         req.Notes,
+	}
+}
@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/google/uuid"
+)
+
+// SupplierReturnLineRequest represents the quantity of one received batch sent back
+type SupplierReturnLineRequest struct {
+	ReceiptLineID uuid.UUID `json:"receipt_line_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Goods receipt line the batch came in with
+	Quantity      int       `json:"quantity" validate:"required,min=1" example:"20"`                                    // Quantity in the given unit
+	Unit          string    `json:"unit" example:"box"`                                                                 // Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit
+	Notes         string    `json:"notes" example:"Leaking bottles"`
+}
+
+// SupplierReturnRequest represents the request body for returning received goods to the supplier
+type SupplierReturnRequest struct {
+	ReceiptID uuid.UUID                   `json:"receipt_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Original goods receipt
+	Date      time.Time                   `json:"date" example:"2024-02-10T00:00:00Z"`                                           // Movement date, defaults to now
+	Reason    string                      `json:"reason" example:"Damaged on arrival"`
+	Notes     string                      `json:"notes" example:"Collected by supplier courier"`
+	Lines     []SupplierReturnLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+// CustomerReturnLineRequest represents the returned quantity of one issued batch
+type CustomerReturnLineRequest struct {
+	ProductID   uuid.UUID `json:"product_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Returned product
+	BatchNumber string    `json:"batch_number" example:"BATCH-2024-001"`                                         // Returned batch, empty when the origin issued a single batch
+	Quantity    int       `json:"quantity" validate:"required,min=1" example:"5"`                                // Quantity in the given unit
+	Unit        string    `json:"unit" example:"box"`                                                            // Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit
+	Disposition string    `json:"disposition" example:"quarantine"`                                              // sellable or quarantine, defaults to sellable
+	Notes       string    `json:"notes" example:"Seal broken"`
+}
+
+// CustomerReturnRequest represents the request body for taking stock back from a customer
+type CustomerReturnRequest struct {
+	OriginID     uuid.UUID                   `json:"origin_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`    // Document the stock was issued with
+	WarehouseID  uuid.UUID                   `json:"warehouse_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Receiving warehouse
+	CustomerName string                      `json:"customer_name" example:"Apotek Sehat"`
+	Date         time.Time                   `json:"date" example:"2024-02-12T00:00:00Z"` // Movement date, defaults to now
+	Reason       string                      `json:"reason" example:"Wrong item delivered"`
+	Notes        string                      `json:"notes" example:"Returned at the counter"`
+	Lines        []CustomerReturnLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+// ToSupplierReturn converts SupplierReturnRequest to SupplierReturn model
+func (req *SupplierReturnRequest) ToSupplierReturn() *model.SupplierReturn {
+	lines := make([]model.SupplierReturnLine, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = model.SupplierReturnLine{
+			ReceiptLineID: line.ReceiptLineID,
+			Quantity:      line.Quantity,
+			Unit:          line.Unit,
+			Notes:         line.Notes,
+		}
+	}
+	return &model.SupplierReturn{
+		ReceiptID: req.ReceiptID,
+		Date:      req.Date,
+		Reason:    req.Reason,
+		Notes:     req.Notes,
+		Lines:     lines,
+	}
+}
+
+// ToCustomerReturn converts CustomerReturnRequest to CustomerReturn model
+func (req *CustomerReturnRequest) ToCustomerReturn() *model.CustomerReturn {
+	lines := make([]model.CustomerReturnLine, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = model.CustomerReturnLine{
+			ProductID:   line.ProductID,
+			BatchNumber: line.BatchNumber,
+			Quantity:    line.Quantity,
+			Unit:        line.Unit,
+			Disposition: line.Disposition,
+			Notes:       line.Notes,
+		}
+	}
+	return &model.CustomerReturn{
+		OriginID:     req.OriginID,
+		WarehouseID:  req.WarehouseID,
+		CustomerName: req.CustomerName,
+		Date:         req.Date,
+		Reason:       req.Reason,
+		Notes:        req.Notes,
+		Lines:        lines,
+	}
+}
@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/google/uuid"
+)
+
+// StockTransferLineRequest represents one product/batch to transfer
+type StockTransferLineRequest struct {
+	ProductID   uuid.UUID `json:"product_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Transferred product
+	BatchNumber string    `json:"batch_number" example:"BATCH-2024-001"`                                         // Batch to ship, empty to allocate by FEFO/FIFO when shipped
+	Quantity    int       `json:"quantity" validate:"required,min=1" example:"24"`                               // Quantity in the given unit
+	Unit        string    `json:"unit" example:"box"`                                                            // Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit
+}
+
+// StockTransferRequest represents the request body for creating or editing a draft transfer
+type StockTransferRequest struct {
+	SourceWarehouseID      uuid.UUID                  `json:"source_warehouse_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`      // Shipping warehouse
+	DestinationWarehouseID uuid.UUID                  `json:"destination_warehouse_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174001"` // Receiving warehouse
+	Notes                  string                     `json:"notes" example:"Monthly replenishment for branch pharmacy"`
+	Lines                  []StockTransferLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+// StockTransferReceiveLineRequest represents the quantity that arrived for one transfer line
+type StockTransferReceiveLineRequest struct {
+	LineID           uuid.UUID `json:"line_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"` // Transfer line ID
+	Quantity         int       `json:"quantity" validate:"min=0" example:"20"`                                     // Quantity received now
+	Unit             string    `json:"unit" example:"box"`                                                         // Unit of the quantity, a unit of the product or a standard unit of the same dimension (e.g. lb for kg, rounded to whole small units), defaults to the small unit
+	DiscrepancyNotes string    `json:"discrepancy_notes" example:"4 units broken in transit"`                      // Why quantities differ
+}
+
+// StockTransferReceiveRequest represents the request body for receiving a transfer
+type StockTransferReceiveRequest struct {
+	Lines []StockTransferReceiveLineRequest `json:"lines"`
+	Close bool                              `json:"close" example:"false"` // Record any outstanding quantity as a discrepancy and finish the transfer
+}
+
+// ToStockTransfer converts StockTransferRequest to StockTransfer model
+func (req *StockTransferRequest) ToStockTransfer() *model.StockTransfer {
+	lines := make([]model.StockTransferLine, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		lines = append(lines, model.StockTransferLine{
+			ProductID:   line.ProductID,
+			BatchNumber: line.BatchNumber,
+			Quantity:    line.Quantity,
+			Unit:        line.Unit,
+		})
+	}
+	return &model.StockTransfer{
+		SourceWarehouseID:      req.SourceWarehouseID,
+		DestinationWarehouseID: req.DestinationWarehouseID,
+		Notes:                  req.Notes,
+		Lines:                  lines,
+	}
+}
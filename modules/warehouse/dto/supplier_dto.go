@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/google/uuid"
+)
+
+// SupplierContactRequest represents a person to reach at a supplier
+type SupplierContactRequest struct {
+	Name      string `json:"name" validate:"required" example:"Budi Santoso"`
+	Position  string `json:"position" example:"Sales Manager"`
+	Phone     string `json:"phone" example:"+62-812-3456-7890"`
+	Email     string `json:"email" example:"budi@supplier.co.id"`
+	IsPrimary bool   `json:"is_primary" example:"true"`
+}
+
+// SupplierRequest represents the request body for creating or updating a supplier
+type SupplierRequest struct {
+	Code            string                   `json:"code" validate:"required" example:"SUP001"`
+	Name            string                   `json:"name" validate:"required" example:"PT Kimia Farma Trading"`
+	Address         string                   `json:"address" example:"Jl. Budi Utomo No. 1"`
+	City            string                   `json:"city" example:"Jakarta"`
+	Phone           string                   `json:"phone" example:"+62-21-384-7709"`
+	Email           string                   `json:"email" example:"order@supplier.co.id"`
+	TaxNumber       string                   `json:"tax_number" example:"01.234.567.8-901.000"`
+	PaymentTermDays int                      `json:"payment_term_days" validate:"min=0" example:"30"` // Days until invoices are due, 0 for cash
+	Status          string                   `json:"status" example:"active"`                         // active or inactive, defaults to active
+	Contacts        []SupplierContactRequest `json:"contacts" validate:"dive"`
+}
+
+// ProductSupplierRequest represents the request body for adding a supplier to a product's catalog
+type ProductSupplierRequest struct {
+	SupplierID       uuid.UUID `json:"supplier_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	SupplierSKU      string    `json:"supplier_sku" example:"KF-PCT-500"`                  // Supplier's own product code
+	LeadTimeDays     int       `json:"lead_time_days" validate:"min=0" example:"7"`        // Days from order to delivery
+	MinOrderQuantity int       `json:"min_order_quantity" validate:"min=0" example:"1200"` // Minimum order in small units
+	IsPreferred      bool      `json:"is_preferred" example:"true"`                        // Vendor picked for reorder suggestions
+}
+
+// ToSupplier converts SupplierRequest to Supplier model
+func (req *SupplierRequest) ToSupplier() *model.Supplier {
+	contacts := make([]model.SupplierContact, 0, len(req.Contacts))
+	for _, contact := range req.Contacts {
+		contacts = append(contacts, model.SupplierContact{
+			Name:      contact.Name,
+			Position:  contact.Position,
+			Phone:     contact.Phone,
+			Email:     contact.Email,
+			IsPrimary: contact.IsPrimary,
+		})
+	}
+	return &model.Supplier{
+		Code:            req.Code,
+		Name:            req.Name,
+		Address:         req.Address,
+		City:            req.City,
+		Phone:           req.Phone,
+		Email:           req.Email,
+		TaxNumber:       req.TaxNumber,
+		PaymentTermDays: req.PaymentTermDays,
+		Status:          req.Status,
+		Contacts:        contacts,
+	}
+}
+
+// ToProductSupplier converts ProductSupplierRequest to ProductSupplier model
+func (req *ProductSupplierRequest) ToProductSupplier(productID uuid.UUID) *model.ProductSupplier {
+	return &model.ProductSupplier{
+		ProductID:        productID,
+		SupplierID:       req.SupplierID,
+		SupplierSKU:      req.SupplierSKU,
+		LeadTimeDays:     req.LeadTimeDays,
+		MinOrderQuantity: req.MinOrderQuantity,
+		IsPreferred:      req.IsPreferred,
+	}
+}
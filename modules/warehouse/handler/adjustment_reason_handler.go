@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type AdjustmentReasonHandler struct {
+	service service.AdjustmentReasonService
+}
+
+func NewAdjustmentReasonHandler(service service.AdjustmentReasonService) *AdjustmentReasonHandler {
+	return &AdjustmentReasonHandler{service: service}
+}
+
+func (h *AdjustmentReasonHandler) RegisterRoutes(g *echo.Group) {
+	rg := g.Group("/adjustment-reasons")
+	rg.GET("", h.GetAll)
+	rg.POST("", h.Create)
+	rg.GET("/:id", h.GetByID)
+	rg.PUT("/:id", h.Update)
+}
+
+// GetAll godoc
+// @Summary      Get adjustment reasons
+// @Description  Retrieves every adjustment reason code ordered by code, optionally filtered by status
+// @Tags         stock-adjustments
+// @Accept       json
+// @Produce      json
+// @Param        status  query     string  false  "Reason status (active, inactive)"
+// @Success      200     {array}   model.AdjustmentReason
+// @Failure      401     {object}  object
+// @Failure      500     {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/adjustment-reasons [get]
+func (h *AdjustmentReasonHandler) GetAll(c echo.Context) error {
+	reasons, err := h.service.GetAll(c.QueryParam("status"))
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+	return contract.SingleSuccess(c, reasons)
+}
+
+// Create godoc
+// @Summary      Create an adjustment reason
+// @Description  Adds a reason code that adjustment lines can carry. Direction limits the reason to increases or decreases.
+// @Tags         stock-adjustments
+// @Accept       json
+// @Produce      json
+// @Param        reason  body      dto.AdjustmentReasonRequest  true  "Reason data"
+// @Success      201     {object}  model.AdjustmentReason
+// @Failure      400     {object}  object{success=bool,error=string}  "Validation error"
+// @Failure      401     {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500     {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/adjustment-reasons [post]
+func (h *AdjustmentReasonHandler) Create(c echo.Context) error {
+	var req dto.AdjustmentReasonRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	reason := req.ToAdjustmentReason()
+	if err := h.service.Create(reason); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.AdjustmentReason]{
+		Success: true,
+		Data:    *reason,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get adjustment reason by ID
+// @Description  Retrieve a single adjustment reason code
+// @Tags         stock-adjustments
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Adjustment Reason ID (UUID format)"
+// @Success      200  {object}  model.AdjustmentReason
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      404  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/adjustment-reasons/{id} [get]
+func (h *AdjustmentReasonHandler) GetByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	reason, err := h.service.GetByID(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
+			Success: false,
+			Error:   err.Error(),
+		})
+	}
+	if reason == nil {
+		return c.JSON(http.StatusNotFound, contract.APIResponse[any]{
+			Success: false,
+			Error:   "adjustment reason not found",
+		})
+	}
+	return contract.SingleSuccess(c, *reason)
+}
+
+// Update godoc
+// @Summary      Update an adjustment reason
+// @Description  Replaces a reason code. Set the status to inactive to retire a reason; posted movements keep the code they were recorded with.
+// @Tags         stock-adjustments
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                       true  "Adjustment Reason ID (UUID format)"
+// @Param        reason  body      dto.AdjustmentReasonRequest  true  "Reason data"
+// @Success      200     {object}  model.AdjustmentReason
+// @Failure      400     {object}  object{success=bool,error=string}  "Validation error"
+// @Failure      401     {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500     {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/adjustment-reasons/{id} [put]
+func (h *AdjustmentReasonHandler) Update(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	var req dto.AdjustmentReasonRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	reason := req.ToAdjustmentReason()
+	if err := h.service.Update(id, reason); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+	return contract.SingleSuccess(c, *reason)
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type CustomerReturnHandler struct {
+	service service.CustomerReturnService
+}
+
+func NewCustomerReturnHandler(service service.CustomerReturnService) *CustomerReturnHandler {
+	return &CustomerReturnHandler{service: service}
+}
+
+func (h *CustomerReturnHandler) RegisterRoutes(g *echo.Group) {
+	rg := g.Group("/customer-returns")
+	rg.GET("", h.GetAll)
+	rg.POST("", h.Create)
+	rg.GET("/:id", h.GetByID)
+	rg.POST("/:id/post", h.Post)
+	rg.POST("/:id/cancel", h.Cancel)
+}
+
+// GetAll godoc
+// @Summary      Get customer returns
+// @Description  Retrieves paginated customer returns, newest first, optionally filtered by warehouse and status
+// @Tags         returns
+// @Accept       json
+// @Produce      json
+// @Param        page         query     int     false  "Page number (default: 1)"
+// @Param        pageSize     query     int     false  "Page size (default: 10)"
+// @Param        warehouseId  query     string  false  "Warehouse ID (UUID format)"
+// @Param        status       query     string  false  "Return status (draft, posted, cancelled)"
+// @Success      200          {object}  object
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/customer-returns [get]
+func (h *CustomerReturnHandler) GetAll(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+
+	returns, total, err := h.service.GetAll(page, pageSize, warehouseID, c.QueryParam("status"))
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return contract.PaginatedSuccess(c, returns, total, page, pageSize)
+}
+
+// Create godoc
+// @Summary      Create a customer return
+// @Description  Drafts a return of stock issued with the origin document into a chosen warehouse. Batch, expiry and cost come from the original issue and no more than was issued can come back.
+// @Tags         returns
+// @Accept       json
+// @Produce      json
+// @Param        return  body      dto.CustomerReturnRequest  true  "Return data"
+// @Success      201     {object}  model.CustomerReturn
+// @Failure      400     {object}  object{success=bool,error=string}  "Validation error"
+// @Failure      401     {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500     {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/customer-returns [post]
+func (h *CustomerReturnHandler) Create(c echo.Context) error {
+	var req dto.CustomerReturnRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	ret := req.ToCustomerReturn()
+	ret.CreatedBy = currentUserID(c)
+	if err := h.service.Create(ret); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.CustomerReturn]{
+		Success: true,
+		Data:    *ret,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get customer return by ID
+// @Description  Retrieve a customer return with its lines
+// @Tags         returns
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Customer Return ID (UUID format)"
+// @Success      200  {object}  model.CustomerReturn
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      404  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/customer-returns/{id} [get]
+func (h *CustomerReturnHandler) GetByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	ret, err := h.service.GetByID(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
+			Success: false,
+			Error:   err.Error(),
+		})
+	}
+	if ret == nil {
+		return c.JSON(http.StatusNotFound, contract.APIResponse[any]{
+			Success: false,
+			Error:   "customer return not found",
+		})
+	}
+	return contract.SingleSuccess(c, *ret)
+}
+
+// Post godoc
+// @Summary      Post a customer return
+// @Description  Receives every line into the warehouse as return_in stock entries carrying the origin document in reference_id. Quarantine lines are held and cannot be allocated, reserved or issued.
+// @Tags         returns
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Customer Return ID (UUID format)"
+// @Success      200  {object}  model.CustomerReturn
+// @Failure      400  {object}  object{success=bool,error=string}  "Return not in draft, quantity over the issue or QC status conflict"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/customer-returns/{id}/post [post]
+func (h *CustomerReturnHandler) Post(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	ret, err := h.service.Post(id, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *ret)
+}
+
+// Cancel godoc
+// @Summary      Cancel a customer return
+// @Description  Cancels a return that has not been posted. No stock is moved.
+// @Tags         returns
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Customer Return ID (UUID format)"
+// @Success      200  {object}  model.CustomerReturn
+// @Failure      400  {object}  object{success=bool,error=string}  "Return already posted or cancelled"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/customer-returns/{id}/cancel [post]
+func (h *CustomerReturnHandler) Cancel(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	ret, err := h.service.Cancel(id)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *ret)
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type ForecastHandler struct {
+	service service.ForecastService
+}
+
+func NewForecastHandler(service service.ForecastService) *ForecastHandler {
+	return &ForecastHandler{service: service}
+}
+
+func (h *ForecastHandler) RegisterRoutes(g *echo.Group) {
+	g.GET("/products/:id/forecast", h.GetProductForecast)
+}
+
+// GetProductForecast godoc
+// @Summary      Get demand forecast of a product
+// @Description  Projects demand per warehouse from outgoing stock movements (issues and transfers out) using a simple moving average or exponential smoothing, with a 95% confidence band. Seasonality is detected and applied once two years of history are available. The auto method picks whichever fits the history best.
+// @Tags         forecasts
+// @Accept       json
+// @Produce      json
+// @Param        id           path      string  true   "Product ID (UUID format)"
+// @Param        warehouseId  query     string  false  "Warehouse ID (UUID format), all warehouses with demand when empty"
+// @Param        method       query     string  false  "Forecast method (auto, moving_average, exponential_smoothing; default: auto)"
+// @Param        interval     query     string  false  "Period length (month, week; default: month)"
+// @Param        history      query     int     false  "Complete periods of history to fit (default: 24 months or 104 weeks)"
+// @Param        horizon      query     int     false  "Periods to forecast, starting with the current one (default: 3)"
+// @Param        window       query     int     false  "Moving average window in periods (default: 3)"
+// @Param        alpha        query     number  false  "Exponential smoothing factor between 0 and 1 (default: 0.3)"
+// @Success      200          {object}  service.ProductForecast
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/products/{id}/forecast [get]
+func (h *ForecastHandler) GetProductForecast(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+
+	options := service.ForecastOptions{
+		Method:   c.QueryParam("method"),
+		Interval: c.QueryParam("interval"),
+	}
+	for name, target := range map[string]*int{"history": &options.History, "horizon": &options.Horizon, "window": &options.Window} {
+		if v := c.QueryParam(name); v != "" {
+			if *target, err = parsePositiveInt(v); err != nil {
+				return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+					Success: false,
+					Error:   "invalid " + name + " value",
+				})
+			}
+		}
+	}
+	if a := c.QueryParam("alpha"); a != "" {
+		if options.Alpha, err = strconv.ParseFloat(a, 64); err != nil || options.Alpha <= 0 {
+			return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+				Success: false,
+				Error:   "invalid alpha value",
+			})
+		}
+	}
+
+	forecast, err := h.service.GetProductForecast(id, warehouseID, options)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *forecast)
+}
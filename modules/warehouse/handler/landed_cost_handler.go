@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type LandedCostHandler struct {
+	service service.LandedCostService
+}
+
+func NewLandedCostHandler(service service.LandedCostService) *LandedCostHandler {
+	return &LandedCostHandler{service: service}
+}
+
+func (h *LandedCostHandler) RegisterRoutes(g *echo.Group) {
+	rg := g.Group("/landed-costs")
+	rg.GET("", h.GetAll)
+	rg.POST("", h.Create)
+	rg.GET("/:id", h.GetByID)
+	rg.POST("/:id/post", h.Post)
+	rg.POST("/:id/reverse", h.Reverse)
+	rg.POST("/:id/cancel", h.Cancel)
+}
+
+// GetAll godoc
+// @Summary      Get landed costs
+// @Description  Retrieves paginated landed cost documents, newest first, optionally filtered by goods receipt and status
+// @Tags         landed-costs
+// @Accept       json
+// @Produce      json
+// @Param        page       query     int     false  "Page number (default: 1)"
+// @Param        pageSize   query     int     false  "Page size (default: 10)"
+// @Param        receiptId  query     string  false  "Goods Receipt ID (UUID format)"
+// @Param        status     query     string  false  "Landed cost status (draft, posted, reversed, cancelled)"
+// @Success      200        {object}  object
+// @Failure      400        {object}  object
+// @Failure      401        {object}  object
+// @Failure      500        {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/landed-costs [get]
+func (h *LandedCostHandler) GetAll(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	receiptID, err := parseOptionalUUID(c.QueryParam("receiptId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid receiptId format",
+		})
+	}
+
+	costs, total, err := h.service.GetAll(page, pageSize, receiptID, c.QueryParam("status"))
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return contract.PaginatedSuccess(c, costs, total, page, pageSize)
+}
+
+// Create godoc
+// @Summary      Create a landed cost
+// @Description  Drafts freight, insurance, duty or other charges against a goods receipt and previews their allocation over the receipt lines by value (quantity × price), quantity or weight. Quantities are net of supplier returns. Without lines every receipt line with stock left shares the charges; the weight method needs each line with its weight.
+// @Tags         landed-costs
+// @Accept       json
+// @Produce      json
+// @Param        landedCost  body      dto.LandedCostRequest  true  "Landed cost data"
+// @Success      201         {object}  model.LandedCost
+// @Failure      400         {object}  object{success=bool,error=string}  "Validation error"
+// @Failure      401         {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500         {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/landed-costs [post]
+func (h *LandedCostHandler) Create(c echo.Context) error {
+	var req dto.LandedCostRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	cost := req.ToLandedCost()
+	cost.CreatedBy = currentUserID(c)
+	if err := h.service.Create(cost); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.LandedCost]{
+		Success: true,
+		Data:    *cost,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get landed cost by ID
+// @Description  Retrieve a landed cost with its charges and allocation lines
+// @Tags         landed-costs
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Landed Cost ID (UUID format)"
+// @Success      200  {object}  model.LandedCost
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      404  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/landed-costs/{id} [get]
+func (h *LandedCostHandler) GetByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	cost, err := h.service.GetByID(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
+			Success: false,
+			Error:   err.Error(),
+		})
+	}
+	if cost == nil {
+		return c.JSON(http.StatusNotFound, contract.APIResponse[any]{
+			Success: false,
+			Error:   "landed cost not found",
+		})
+	}
+	return contract.SingleSuccess(c, *cost)
+}
+
+// Post godoc
+// @Summary      Post a landed cost
+// @Description  Allocates the charges again over the quantities still held after supplier returns and adds each line's share per small unit to the landed cost of its receipt line. From the landed cost date on, valuation counts the receipt at its purchase price plus the landed cost. The date must fall after the latest closed stock period.
+// @Tags         landed-costs
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Landed Cost ID (UUID format)"
+// @Success      200  {object}  model.LandedCost
+// @Failure      400  {object}  object{success=bool,error=string}  "Landed cost not in draft or dated in a closed period"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/landed-costs/{id}/post [post]
+func (h *LandedCostHandler) Post(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	cost, err := h.service.Post(id, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *cost)
+}
+
+// Reverse godoc
+// @Summary      Reverse a landed cost
+// @Description  Takes a posted landed cost back out of the receipt line costs. The document and its allocation are kept with who reversed it, when and why. Valuation as of any moment before the reversal still includes it. Landed costs dated in a closed stock period cannot be reversed.
+// @Tags         landed-costs
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                        true  "Landed Cost ID (UUID format)"
+// @Param        reverse  body      dto.LandedCostReverseRequest  true  "Reversal reason"
+// @Success      200      {object}  model.LandedCost
+// @Failure      400      {object}  object{success=bool,error=string}  "Validation error, landed cost not posted or dated in a closed period"
+// @Failure      401      {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500      {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/landed-costs/{id}/reverse [post]
+func (h *LandedCostHandler) Reverse(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	var req dto.LandedCostReverseRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	cost, err := h.service.Reverse(id, req.Reason, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *cost)
+}
+
+// Cancel godoc
+// @Summary      Cancel a landed cost
+// @Description  Cancels a landed cost that has not been posted. No cost is changed.
+// @Tags         landed-costs
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Landed Cost ID (UUID format)"
+// @Success      200  {object}  model.LandedCost
+// @Failure      400  {object}  object{success=bool,error=string}  "Landed cost already posted or cancelled"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/landed-costs/{id}/cancel [post]
+func (h *LandedCostHandler) Cancel(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	cost, err := h.service.Cancel(id)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *cost)
+}
@@ -129,10 +129,7 @@ func (h *OfficeHandler) Create(c echo.Context) error {
 	}
 
 	if err := h.service.Create(&office); err != nil {
-		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
-			Success: false,
-			Error:   err.Error(),
-		})
+		return serviceErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusCreated, contract.APIResponse[model.Office]{
@@ -166,10 +163,7 @@ func (h *OfficeHandler) Update(c echo.Context) error {
 	}
 
 	if err := h.service.Update(id, &office); err != nil {
-		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
-			Success: false,
-			Error:   err.Error(),
-		})
+		return serviceErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, contract.APIResponse[model.Office]{
@@ -25,6 +25,7 @@ func (h *ProductHandler) RegisterRoutes(g *echo.Group) {
 	pg := g.Group("/products")
 	pg.GET("", h.GetAll)
 	pg.POST("", h.Create)
+	pg.GET("/barcode/:code", h.GetByBarcode)
 	pg.GET("/:id", h.GetByID)
 	pg.PUT("/:id", h.Update)
 	pg.DELETE("/:id", h.Delete)
@@ -81,7 +82,7 @@ func (h *ProductHandler) GetAll(c echo.Context) error {
 
 // Create godoc
 // @Summary      Create a new product
-// @Description  Create a new product with the provided information. Category must exist and be valid. Request body should only contain category_id, not the full category object.
+// @Description  Create a new product with the provided information. Category must exist and be valid. Request body should only contain category_id, not the full category object. Barcodes need a valid check digit, a unit of the product and must not belong to another product.
 // @Tags         products
 // @Accept       json
 // @Produce      json
@@ -174,9 +175,37 @@ func (h *ProductHandler) GetByID(c echo.Context) error {
 	})
 }
 
+// GetByBarcode godoc
+// @Summary      Look up a product by barcode
+// @Description  Returns the product and the packaging unit of a scanned EAN-13, EAN-8 or UPC-A barcode. UPC-A codes also match their EAN-13 form with a leading zero. Pass the unit code as the unit of stock quantities to receive or count in scanned packages.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        code  path      string  true  "Scanned barcode"
+// @Success      200   {object}  service.BarcodeMatch
+// @Failure      400   {object}  object{success=bool,error=string}  "Invalid barcode or check digit"
+// @Failure      401   {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      404   {object}  object{success=bool,error=string}  "Barcode not found"
+// @Failure      500   {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/products/barcode/{code} [get]
+func (h *ProductHandler) GetByBarcode(c echo.Context) error {
+	match, err := h.service.GetByBarcode(c.Param("code"))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+	if match == nil {
+		return c.JSON(http.StatusNotFound, contract.APIResponse[any]{
+			Success: false,
+			Error:   "barcode not found",
+		})
+	}
+	return contract.SingleSuccess(c, *match)
+}
+
 // Update godoc
 // @Summary      Update a product
-// @Description  Update an existing product with new information. Category must exist and be valid. Request body should only contain category_id, not the full category object.
+// @Description  Update an existing product with new information. Category must exist and be valid. Request body should only contain category_id, not the full category object. Barcodes need a valid check digit, a unit of the product and must not belong to another product. Omit units or barcodes to keep the current packaging hierarchy or barcodes. Once the product has stock, its base unit and the factors of its existing units cannot change.
 // @Tags         products
 // @Accept       json
 // @Produce      json
@@ -280,14 +309,18 @@ func (h *ProductHandler) validateCreateRequest(req *dto.ProductCreateRequest) er
 	if req.Name == "" {
 		return errors.New("product name is required")
 	}
-	if req.LargeUnit == "" {
-		return errors.New("large unit is required")
-	}
-	if req.SmallUnit == "" {
-		return errors.New("small unit is required")
-	}
-	if req.ContentPerLargeUnit <= 0 {
-		return errors.New("content per large unit must be greater than 0")
+	// Products with a packaging hierarchy derive their units from it, and an
+	// update without units keeps the hierarchy the product already has
+	if req.Units != nil && len(req.Units) == 0 {
+		if req.LargeUnit == "" {
+			return errors.New("large unit is required")
+		}
+		if req.SmallUnit == "" {
+			return errors.New("small unit is required")
+		}
+		if req.ContentPerLargeUnit <= 0 {
+			return errors.New("content per large unit must be greater than 0")
+		}
 	}
 	if req.PurchasePrice < 0 {
 		return errors.New("purchase price cannot be negative")
@@ -309,14 +342,17 @@ func (h *ProductHandler) validateUpdateRequest(req *dto.ProductUpdateRequest) er
 	if req.Name == "" {
 		return errors.New("product name is required")
 	}
-	if req.LargeUnit == "" {
-		return errors.New("large unit is required")
-	}
-	if req.SmallUnit == "" {
-		return errors.New("small unit is required")
-	}
-	if req.ContentPerLargeUnit <= 0 {
-		return errors.New("content per large unit must be greater than 0")
+	// Products with a packaging hierarchy derive their units from it
+	if len(req.Units) == 0 {
+		if req.LargeUnit == "" {
+			return errors.New("large unit is required")
+		}
+		if req.SmallUnit == "" {
+			return errors.New("small unit is required")
+		}
+		if req.ContentPerLargeUnit <= 0 {
+			return errors.New("content per large unit must be greater than 0")
+		}
 	}
 	if req.PurchasePrice < 0 {
 		return errors.New("purchase price cannot be negative")
@@ -342,6 +378,9 @@ func isValidationError(err error) bool {
 		"must be greater than 0",
 		"not found",
 		"invalid",
+		"insufficient",
+		"must be written off",
+		"cannot be issued",
 	}
 
 	for _, validationErr := range validationErrors {
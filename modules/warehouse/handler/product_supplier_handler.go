@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type ProductSupplierHandler struct {
+	service service.ProductSupplierService
+}
+
+func NewProductSupplierHandler(service service.ProductSupplierService) *ProductSupplierHandler {
+	return &ProductSupplierHandler{service: service}
+}
+
+func (h *ProductSupplierHandler) RegisterRoutes(g *echo.Group) {
+	g.GET("/products/:id/suppliers", h.GetByProduct)
+	g.PUT("/products/:id/suppliers", h.Save)
+	g.DELETE("/products/:id/suppliers/:supplierId", h.Delete)
+}
+
+// GetByProduct godoc
+// @Summary      Get suppliers of a product
+// @Description  Lists the suppliers that sell a product with their SKU, lead time, minimum order quantity and last purchase price, preferred supplier first
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Product ID (UUID format)"
+// @Success      200  {array}   model.ProductSupplier
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/products/{id}/suppliers [get]
+func (h *ProductSupplierHandler) GetByProduct(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	links, err := h.service.GetByProduct(id)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, links)
+}
+
+// Save godoc
+// @Summary      Add or update a product supplier
+// @Description  Creates or replaces a supplier's catalog entry for a product. Marking it preferred unmarks the product's other suppliers.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                      true  "Product ID (UUID format)"
+// @Param        supplier  body      dto.ProductSupplierRequest  true  "Catalog entry"
+// @Success      200       {object}  model.ProductSupplier
+// @Failure      400       {object}  object{success=bool,error=string}  "Validation error"
+// @Failure      401       {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500       {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/products/{id}/suppliers [put]
+func (h *ProductSupplierHandler) Save(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	var req dto.ProductSupplierRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	link := req.ToProductSupplier(id)
+	if err := h.service.Save(link); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, contract.APIResponse[model.ProductSupplier]{
+		Success: true,
+		Data:    *link,
+	})
+}
+
+// Delete godoc
+// @Summary      Remove a product supplier
+// @Description  Removes a supplier from a product's catalog
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string  true  "Product ID (UUID format)"
+// @Param        supplierId  path      string  true  "Supplier ID (UUID format)"
+// @Success      204 {string} string "No Content"
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/products/{id}/suppliers/{supplierId} [delete]
+func (h *ProductSupplierHandler) Delete(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+	supplierID, err := uuid.Parse(c.Param("supplierId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid supplierId format",
+		})
+	}
+
+	if err := h.service.Delete(id, supplierID); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
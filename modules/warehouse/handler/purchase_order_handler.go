@@ -0,0 +1,313 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type PurchaseOrderHandler struct {
+	service service.PurchaseOrderService
+}
+
+func NewPurchaseOrderHandler(service service.PurchaseOrderService) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{service: service}
+}
+
+func (h *PurchaseOrderHandler) RegisterRoutes(g *echo.Group) {
+	pg := g.Group("/purchase-orders")
+	pg.GET("", h.GetAll)
+	pg.POST("", h.Create)
+	pg.GET("/:id", h.GetByID)
+	pg.PUT("/:id", h.Update)
+	pg.POST("/:id/approve", h.Approve)
+	pg.GET("/:id/receipts", h.GetReceipts)
+	pg.POST("/:id/receipts", h.Receive)
+	pg.POST("/:id/close", h.Close)
+}
+
+// GetAll godoc
+// @Summary      Get list of purchase orders
+// @Description  Retrieves paginated purchase orders, newest first, optionally filtered by warehouse and status
+// @Tags         purchase-orders
+// @Accept       json
+// @Produce      json
+// @Param        page         query     int     false  "Page number (default: 1)"
+// @Param        pageSize     query     int     false  "Page size (default: 10)"
+// @Param        warehouseId  query     string  false  "Warehouse ID (UUID format)"
+// @Param        status       query     string  false  "Order status (draft, approved, partially_received, received, closed)"
+// @Success      200          {object}  object
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/purchase-orders [get]
+func (h *PurchaseOrderHandler) GetAll(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+
+	orders, total, err := h.service.GetAll(page, pageSize, warehouseID, c.QueryParam("status"))
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return contract.PaginatedSuccess(c, orders, total, page, pageSize)
+}
+
+// Create godoc
+// @Summary      Create a purchase order
+// @Description  Creates a draft purchase order. Lines may be ordered in the product's large or small unit.
+// @Tags         purchase-orders
+// @Accept       json
+// @Produce      json
+// @Param        order  body      dto.PurchaseOrderRequest  true  "Purchase order data"
+// @Success      201    {object}  model.PurchaseOrder
+// @Failure      400    {object}  object{success=bool,error=string}  "Validation error"
+// @Failure      401    {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500    {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/purchase-orders [post]
+func (h *PurchaseOrderHandler) Create(c echo.Context) error {
+	var req dto.PurchaseOrderRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	order := req.ToPurchaseOrder()
+	order.CreatedBy = currentUserID(c)
+	if err := h.service.Create(order); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.PurchaseOrder]{
+		Success: true,
+		Data:    *order,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get purchase order by ID
+// @Description  Retrieve a purchase order with its lines and received quantities
+// @Tags         purchase-orders
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Purchase Order ID (UUID format)"
+// @Success      200  {object}  model.PurchaseOrder
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      404  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/purchase-orders/{id} [get]
+func (h *PurchaseOrderHandler) GetByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	order, err := h.service.GetByID(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
+			Success: false,
+			Error:   err.Error(),
+		})
+	}
+	if order == nil {
+		return c.JSON(http.StatusNotFound, contract.APIResponse[any]{
+			Success: false,
+			Error:   "purchase order not found",
+		})
+	}
+	return contract.SingleSuccess(c, *order)
+}
+
+// Update godoc
+// @Summary      Update a draft purchase order
+// @Description  Replaces the warehouse, supplier, dates, notes and lines of an order that is still in draft
+// @Tags         purchase-orders
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                    true  "Purchase Order ID (UUID format)"
+// @Param        order  body      dto.PurchaseOrderRequest  true  "Purchase order data"
+// @Success      200    {object}  model.PurchaseOrder
+// @Failure      400    {object}  object{success=bool,error=string}  "Validation error or order not in draft"
+// @Failure      401    {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500    {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/purchase-orders/{id} [put]
+func (h *PurchaseOrderHandler) Update(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	var req dto.PurchaseOrderRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	order := req.ToPurchaseOrder()
+	if err := h.service.Update(id, order); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *order)
+}
+
+// Approve godoc
+// @Summary      Approve a purchase order
+// @Description  Releases a draft purchase order for receiving
+// @Tags         purchase-orders
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Purchase Order ID (UUID format)"
+// @Success      200  {object}  model.PurchaseOrder
+// @Failure      400  {object}  object{success=bool,error=string}  "Order not in draft"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/purchase-orders/{id}/approve [post]
+func (h *PurchaseOrderHandler) Approve(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	order, err := h.service.Approve(id, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *order)
+}
+
+// GetReceipts godoc
+// @Summary      Get goods receipts of a purchase order
+// @Description  Lists every delivery booked against a purchase order, oldest first
+// @Tags         purchase-orders
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Purchase Order ID (UUID format)"
+// @Success      200  {array}   model.GoodsReceipt
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/purchase-orders/{id}/receipts [get]
+func (h *PurchaseOrderHandler) GetReceipts(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	receipts, err := h.service.GetReceipts(id)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, receipts)
+}
+
+// Receive godoc
+// @Summary      Receive goods against a purchase order
+// @Description  Books delivered batches into the order's warehouse as receipt stock entries carrying the order ID, batch number and expiry. Over-receipt and price variance beyond the configured tolerances are rejected.
+// @Tags         purchase-orders
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                   true  "Purchase Order ID (UUID format)"
+// @Param        receipt  body      dto.GoodsReceiptRequest  true  "Received batches"
+// @Success      201      {object}  model.PurchaseOrder
+// @Failure      400      {object}  object{success=bool,error=string}  "Validation error, tolerance exceeded or order not approved"
+// @Failure      401      {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500      {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/purchase-orders/{id}/receipts [post]
+func (h *PurchaseOrderHandler) Receive(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	var req dto.GoodsReceiptRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	receipt := req.ToGoodsReceipt()
+	receipt.ReceivedBy = currentUserID(c)
+	order, err := h.service.Receive(id, receipt)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.PurchaseOrder]{
+		Success: true,
+		Data:    *order,
+	})
+}
+
+// Close godoc
+// @Summary      Close a purchase order
+// @Description  Finishes an approved or (partially) received order; any outstanding quantity will no longer be received
+// @Tags         purchase-orders
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Purchase Order ID (UUID format)"
+// @Success      200  {object}  model.PurchaseOrder
+// @Failure      400  {object}  object{success=bool,error=string}  "Order in draft or already closed"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/purchase-orders/{id}/close [post]
+func (h *PurchaseOrderHandler) Close(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	order, err := h.service.Close(id, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *order)
+}
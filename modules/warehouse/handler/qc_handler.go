@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/core/auth"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/labstack/echo/v4"
+)
+
+type QCHandler struct {
+	service service.QCService
+	role    string // Role required for QC decisions
+}
+
+func NewQCHandler(service service.QCService, role string) *QCHandler {
+	return &QCHandler{service: service, role: role}
+}
+
+func (h *QCHandler) RegisterRoutes(g *echo.Group) {
+	qg := g.Group("/qc")
+	qg.GET("/batches", h.GetBatches)
+	qg.POST("/hold", h.Hold, auth.RoleMiddleware(h.role))
+	qg.POST("/release", h.Release, auth.RoleMiddleware(h.role))
+	qg.POST("/reject", h.Reject, auth.RoleMiddleware(h.role))
+}
+
+// GetBatches godoc
+// @Summary      Get batches by QC status
+// @Description  Retrieves paginated batches on hand in a QC status, soonest expiry first. Without a status every batch awaiting or failing QC (quarantine or rejected) is listed.
+// @Tags         qc
+// @Accept       json
+// @Produce      json
+// @Param        page         query     int     false  "Page number (default: 1)"
+// @Param        pageSize     query     int     false  "Page size (default: 10)"
+// @Param        warehouseId  query     string  false  "Warehouse ID (UUID format)"
+// @Param        productId    query     string  false  "Product ID (UUID format)"
+// @Param        status       query     string  false  "QC status (released, quarantine, rejected)"
+// @Success      200          {object}  object
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/qc/batches [get]
+func (h *QCHandler) GetBatches(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+	productID, err := parseOptionalUUID(c.QueryParam("productId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid productId format",
+		})
+	}
+
+	balances, total, err := h.service.GetBatches(warehouseID, productID, c.QueryParam("status"), page, pageSize)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.PaginatedSuccess(c, balances, total, page, pageSize)
+}
+
+// Hold godoc
+// @Summary      Hold a batch in quarantine
+// @Description  Puts a released batch back in quarantine pending a QC decision. The batch stays on hand but cannot be allocated, reserved or issued. Requires the QC role.
+// @Tags         qc
+// @Accept       json
+// @Produce      json
+// @Param        decision  body      dto.QCDecisionRequest  true  "QC decision"
+// @Success      201       {object}  model.StockEntry
+// @Failure      400       {object}  object{success=bool,error=string}  "Validation error or batch not released"
+// @Failure      401       {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      403       {object}  object{error=string}               "User lacks the QC role"
+// @Failure      500       {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/qc/hold [post]
+func (h *QCHandler) Hold(c echo.Context) error {
+	return h.decide(c, h.service.Hold)
+}
+
+// Release godoc
+// @Summary      Release a quarantined batch
+// @Description  Releases a quarantined batch after QC so it counts as available and can be allocated, reserved and issued. Records a qc_change ledger entry. Requires the QC role.
+// @Tags         qc
+// @Accept       json
+// @Produce      json
+// @Param        decision  body      dto.QCDecisionRequest  true  "QC decision"
+// @Success      201       {object}  model.StockEntry
+// @Failure      400       {object}  object{success=bool,error=string}  "Validation error or batch not in quarantine"
+// @Failure      401       {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      403       {object}  object{error=string}               "User lacks the QC role"
+// @Failure      500       {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/qc/release [post]
+func (h *QCHandler) Release(c echo.Context) error {
+	return h.decide(c, h.service.Release)
+}
+
+// Reject godoc
+// @Summary      Reject a quarantined batch
+// @Description  Rejects a quarantined batch. Rejected stock can only leave through a supplier return or a write-off for disposal. Notes are required as the rejection reason. Requires the QC role.
+// @Tags         qc
+// @Accept       json
+// @Produce      json
+// @Param        decision  body      dto.QCDecisionRequest  true  "QC decision"
+// @Success      201       {object}  model.StockEntry
+// @Failure      400       {object}  object{success=bool,error=string}  "Validation error or batch not in quarantine"
+// @Failure      401       {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      403       {object}  object{error=string}               "User lacks the QC role"
+// @Failure      500       {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/qc/reject [post]
+func (h *QCHandler) Reject(c echo.Context) error {
+	return h.decide(c, h.service.Reject)
+}
+
+// decide binds the decision and records it with the given service method
+func (h *QCHandler) decide(c echo.Context, record func(*model.StockEntry) error) error {
+	var req dto.QCDecisionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	entry := req.ToStockEntry()
+	entry.CreatedBy = currentUserID(c)
+	if err := record(entry); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.StockEntry]{
+		Success: true,
+		Data:    *entry,
+	})
+}
@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type RecallHandler struct {
+	service service.RecallService
+}
+
+func NewRecallHandler(service service.RecallService) *RecallHandler {
+	return &RecallHandler{service: service}
+}
+
+func (h *RecallHandler) RegisterRoutes(g *echo.Group) {
+	rg := g.Group("/recalls")
+	rg.GET("", h.GetAll)
+	rg.POST("", h.Open)
+	rg.GET("/trace", h.TraceBatch)
+	rg.GET("/:id", h.GetByID)
+	rg.GET("/:id/trace", h.Trace)
+	rg.POST("/:id/tasks/:taskId/complete", h.CompleteTask)
+	rg.POST("/:id/close", h.Close)
+}
+
+// GetAll godoc
+// @Summary      Get recalls
+// @Description  Retrieves paginated batch recalls, newest first, optionally filtered by product and status
+// @Tags         recalls
+// @Accept       json
+// @Produce      json
+// @Param        page       query     int     false  "Page number (default: 1)"
+// @Param        pageSize   query     int     false  "Page size (default: 10)"
+// @Param        productId  query     string  false  "Product ID (UUID format)"
+// @Param        status     query     string  false  "Recall status (open, closed)"
+// @Success      200        {object}  object
+// @Failure      400        {object}  object
+// @Failure      401        {object}  object
+// @Failure      500        {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/recalls [get]
+func (h *RecallHandler) GetAll(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	productID, err := parseOptionalUUID(c.QueryParam("productId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid productId format",
+		})
+	}
+
+	recalls, total, err := h.service.GetAll(page, pageSize, productID, c.QueryParam("status"))
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return contract.PaginatedSuccess(c, recalls, total, page, pageSize)
+}
+
+// Open godoc
+// @Summary      Open a recall
+// @Description  Opens a recall of one product batch. The batch is rejected by QC in every warehouse holding it so it can no longer be allocated, reserved, transferred or issued, and a return or disposal task is created for each of those warehouses. Stock of the batch received while the recall is open comes in rejected, and a warehouse without an open task for the recall gets a new one.
+// @Tags         recalls
+// @Accept       json
+// @Produce      json
+// @Param        recall  body      dto.RecallRequest  true  "Recall data"
+// @Success      201     {object}  model.Recall
+// @Failure      400     {object}  object{success=bool,error=string}  "Validation error or batch already under recall"
+// @Failure      401     {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500     {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/recalls [post]
+func (h *RecallHandler) Open(c echo.Context) error {
+	var req dto.RecallRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	recall := req.ToRecall()
+	recall.OpenedBy = currentUserID(c)
+	if err := h.service.Open(recall); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.Recall]{
+		Success: true,
+		Data:    *recall,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get recall by ID
+// @Description  Retrieve a recall with its warehouse tasks
+// @Tags         recalls
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Recall ID (UUID format)"
+// @Success      200  {object}  model.Recall
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      404  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/recalls/{id} [get]
+func (h *RecallHandler) GetByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	recall, err := h.service.GetByID(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
+			Success: false,
+			Error:   err.Error(),
+		})
+	}
+	if recall == nil {
+		return c.JSON(http.StatusNotFound, contract.APIResponse[any]{
+			Success: false,
+			Error:   "recall not found",
+		})
+	}
+	return contract.SingleSuccess(c, *recall)
+}
+
+// Trace godoc
+// @Summary      Trace a recalled batch
+// @Description  Reports where the recalled batch came from, which documents it was issued to, where it is still on hand and every movement of it
+// @Tags         recalls
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Recall ID (UUID format)"
+// @Success      200  {object}  service.BatchTrace
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      404  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/recalls/{id}/trace [get]
+func (h *RecallHandler) Trace(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	recall, err := h.service.GetByID(id)
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+	if recall == nil {
+		return c.JSON(http.StatusNotFound, contract.APIResponse[any]{
+			Success: false,
+			Error:   "recall not found",
+		})
+	}
+
+	trace, err := h.service.GetTrace(recall.ProductID, recall.BatchNumber)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+	return contract.SingleSuccess(c, *trace)
+}
+
+// TraceBatch godoc
+// @Summary      Trace a batch
+// @Description  Reports where a batch came from, which documents it was issued to, where it is still on hand and every movement of it, before or without opening a recall
+// @Tags         recalls
+// @Accept       json
+// @Produce      json
+// @Param        productId    query     string  true  "Product ID (UUID format)"
+// @Param        batchNumber  query     string  true  "Batch number"
+// @Success      200          {object}  service.BatchTrace
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/recalls/trace [get]
+func (h *RecallHandler) TraceBatch(c echo.Context) error {
+	productID, err := uuid.Parse(c.QueryParam("productId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid productId format",
+		})
+	}
+
+	trace, err := h.service.GetTrace(productID, c.QueryParam("batchNumber"))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+	return contract.SingleSuccess(c, *trace)
+}
+
+// CompleteTask godoc
+// @Summary      Complete a recall task
+// @Description  Moves what is left of the batch out of the task's warehouse, as a return_out for returns or a write_off for disposals, and marks the task done. Stock that already left the warehouse is not moved again. Controlled products need the authorizing user and the counterparty.
+// @Tags         recalls
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                         true   "Recall ID (UUID format)"
+// @Param        taskId  path      string                         true   "Recall Task ID (UUID format)"
+// @Param        task    body      dto.RecallTaskCompleteRequest  false  "Completion data"
+// @Success      200     {object}  model.Recall
+// @Failure      400     {object}  object{success=bool,error=string}  "Recall closed, task already done or the batch cannot be moved"
+// @Failure      401     {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500     {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/recalls/{id}/tasks/{taskId}/complete [post]
+func (h *RecallHandler) CompleteTask(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+	taskID, err := uuid.Parse(c.Param("taskId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid taskId format",
+		})
+	}
+
+	var req dto.RecallTaskCompleteRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	recall, err := h.service.CompleteTask(id, taskID, req.Action, req.Notes, req.AuthorizedBy, req.Counterparty, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *recall)
+}
+
+// Close godoc
+// @Summary      Close a recall
+// @Description  Closes a recall once every task is done and the batch is no longer on hand in any warehouse. Stock of the batch received afterwards is no longer rejected automatically.
+// @Tags         recalls
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Recall ID (UUID format)"
+// @Success      200  {object}  model.Recall
+// @Failure      400  {object}  object{success=bool,error=string}  "Recall already closed, tasks still open or batch still on hand"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/recalls/{id}/close [post]
+func (h *RecallHandler) Close(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	recall, err := h.service.Close(id, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *recall)
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type ReorderHandler struct {
+	service service.ReorderService
+}
+
+func NewReorderHandler(service service.ReorderService) *ReorderHandler {
+	return &ReorderHandler{service: service}
+}
+
+func (h *ReorderHandler) RegisterRoutes(g *echo.Group) {
+	rg := g.Group("/reorder-settings")
+	rg.GET("", h.GetAll)
+	rg.PUT("", h.Save)
+	rg.DELETE("/:id", h.Delete)
+	g.GET("/reorder-suggestions", h.GetSuggestions)
+}
+
+// GetAll godoc
+// @Summary      Get reorder settings
+// @Description  Retrieves paginated min/max and reorder point settings, optionally filtered by warehouse and product
+// @Tags         reorder
+// @Accept       json
+// @Produce      json
+// @Param        page         query     int     false  "Page number (default: 1)"
+// @Param        pageSize     query     int     false  "Page size (default: 10)"
+// @Param        warehouseId  query     string  false  "Warehouse ID (UUID format)"
+// @Param        productId    query     string  false  "Product ID (UUID format)"
+// @Success      200          {object}  object
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/reorder-settings [get]
+func (h *ReorderHandler) GetAll(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+	productID, err := parseOptionalUUID(c.QueryParam("productId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid productId format",
+		})
+	}
+
+	settings, total, err := h.service.GetAll(page, pageSize, warehouseID, productID)
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return contract.PaginatedSuccess(c, settings, total, page, pageSize)
+}
+
+// Save godoc
+// @Summary      Set reorder levels
+// @Description  Creates or replaces the minimum stock, reorder point and maximum stock of a product in a warehouse
+// @Tags         reorder
+// @Accept       json
+// @Produce      json
+// @Param        setting  body      dto.ReorderSettingRequest  true  "Reorder levels"
+// @Success      200      {object}  model.ReorderSetting
+// @Failure      400      {object}  object{success=bool,error=string}  "Validation error"
+// @Failure      401      {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500      {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/reorder-settings [put]
+func (h *ReorderHandler) Save(c echo.Context) error {
+	var req dto.ReorderSettingRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	setting := req.ToReorderSetting()
+	if err := h.service.Save(setting); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, contract.APIResponse[model.ReorderSetting]{
+		Success: true,
+		Data:    *setting,
+	})
+}
+
+// Delete godoc
+// @Summary      Delete a reorder setting
+// @Description  Stops replenishment suggestions for a product in a warehouse
+// @Tags         reorder
+// @Accept       json
+// @Produce      json
+// @Param        id  path      string  true  "Reorder Setting ID (UUID format)"
+// @Success      204 {string} string "No Content"
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/reorder-settings/{id} [delete]
+func (h *ReorderHandler) Delete(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	if err := h.service.Delete(id); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetSuggestions godoc
+// @Summary      Get replenishment suggestions
+// @Description  Compares released, unexpired on-hand stock plus the quantity still to be received on approved purchase orders, less the demand forecast over the preferred supplier's lead time, with the reorder settings and suggests order quantities up to maximum stock plus that demand, rounded up to whole large units
+// @Tags         reorder
+// @Accept       json
+// @Produce      json
+// @Param        warehouseId  query     string  false  "Warehouse ID (UUID format)"
+// @Param        officeId     query     string  false  "Office ID (UUID format)"
+// @Success      200          {array}   service.ReorderSuggestion
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/reorder-suggestions [get]
+func (h *ReorderHandler) GetSuggestions(c echo.Context) error {
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+	officeID, err := parseOptionalUUID(c.QueryParam("officeId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid officeId format",
+		})
+	}
+
+	suggestions, err := h.service.GetSuggestions(warehouseID, officeID)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, suggestions)
+}
@@ -0,0 +1,393 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/core/auth"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type StockAdjustmentHandler struct {
+	service      service.StockAdjustmentService
+	approverRole string // Role required to approve or reject adjustments
+}
+
+func NewStockAdjustmentHandler(service service.StockAdjustmentService, approverRole string) *StockAdjustmentHandler {
+	return &StockAdjustmentHandler{service: service, approverRole: approverRole}
+}
+
+func (h *StockAdjustmentHandler) RegisterRoutes(g *echo.Group) {
+	ag := g.Group("/stock-adjustments")
+	ag.GET("", h.GetAll)
+	ag.POST("", h.Create)
+	ag.GET("/:id", h.GetByID)
+	ag.PUT("/:id", h.Update)
+	ag.POST("/:id/submit", h.Submit)
+	ag.POST("/:id/approve", h.Approve, auth.RoleMiddleware(h.approverRole))
+	ag.POST("/:id/reject", h.Reject, auth.RoleMiddleware(h.approverRole))
+	ag.POST("/:id/cancel", h.Cancel)
+	ag.POST("/:id/attachments", h.AddAttachment)
+	ag.DELETE("/:id/attachments/:attachmentId", h.DeleteAttachment)
+}
+
+// GetAll godoc
+// @Summary      Get stock adjustments
+// @Description  Retrieves paginated adjustment documents, newest first, optionally filtered by warehouse and status
+// @Tags         stock-adjustments
+// @Accept       json
+// @Produce      json
+// @Param        page         query     int     false  "Page number (default: 1)"
+// @Param        pageSize     query     int     false  "Page size (default: 10)"
+// @Param        warehouseId  query     string  false  "Warehouse ID (UUID format)"
+// @Param        status       query     string  false  "Adjustment status (draft, pending_approval, approved, rejected, cancelled)"
+// @Success      200          {object}  object
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-adjustments [get]
+func (h *StockAdjustmentHandler) GetAll(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+
+	adjustments, total, err := h.service.GetAll(page, pageSize, warehouseID, c.QueryParam("status"))
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return contract.PaginatedSuccess(c, adjustments, total, page, pageSize)
+}
+
+// Create godoc
+// @Summary      Create a stock adjustment
+// @Description  Creates a draft adjustment document. Every line needs an active reason code whose direction matches the sign of its quantity. No stock moves until the document is approved.
+// @Tags         stock-adjustments
+// @Accept       json
+// @Produce      json
+// @Param        adjustment  body      dto.StockAdjustmentRequest  true  "Adjustment data"
+// @Success      201         {object}  model.StockAdjustment
+// @Failure      400         {object}  object{success=bool,error=string}  "Validation error"
+// @Failure      401         {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500         {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-adjustments [post]
+func (h *StockAdjustmentHandler) Create(c echo.Context) error {
+	var req dto.StockAdjustmentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	adjustment := req.ToStockAdjustment()
+	adjustment.CreatedBy = currentUserID(c)
+	if err := h.service.Create(adjustment); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.StockAdjustment]{
+		Success: true,
+		Data:    *adjustment,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get stock adjustment by ID
+// @Description  Retrieve an adjustment document with its lines and attachments
+// @Tags         stock-adjustments
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Adjustment ID (UUID format)"
+// @Success      200  {object}  model.StockAdjustment
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      404  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-adjustments/{id} [get]
+func (h *StockAdjustmentHandler) GetByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	adjustment, err := h.service.GetByID(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
+			Success: false,
+			Error:   err.Error(),
+		})
+	}
+	if adjustment == nil {
+		return c.JSON(http.StatusNotFound, contract.APIResponse[any]{
+			Success: false,
+			Error:   "stock adjustment not found",
+		})
+	}
+	return contract.SingleSuccess(c, *adjustment)
+}
+
+// Update godoc
+// @Summary      Update a stock adjustment
+// @Description  Replaces the warehouse, date, notes, authorization, counterparty and lines of an adjustment that is still in draft
+// @Tags         stock-adjustments
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                      true  "Stock Adjustment ID (UUID format)"
+// @Param        adjustment  body      dto.StockAdjustmentRequest  true  "Adjustment data"
+// @Success      200         {object}  model.StockAdjustment
+// @Failure      400         {object}  object{success=bool,error=string}  "Validation error or adjustment not in draft"
+// @Failure      401         {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500         {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-adjustments/{id} [put]
+func (h *StockAdjustmentHandler) Update(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	var req dto.StockAdjustmentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	adjustment := req.ToStockAdjustment()
+	if err := h.service.Update(id, adjustment); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+	return contract.SingleSuccess(c, *adjustment)
+}
+
+// Submit godoc
+// @Summary      Submit a stock adjustment
+// @Description  Values every line at batch cost and totals the document. Documents above the approval threshold, or carrying a reason that always needs approval, wait for approval; the rest are approved and posted immediately. Lines of controlled products need the counterparty, and the authorizing user unless the document waits for approval.
+// @Tags         stock-adjustments
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Adjustment ID (UUID format)"
+// @Success      200  {object}  model.StockAdjustment
+// @Failure      400  {object}  object{success=bool,error=string}  "Adjustment not in draft or insufficient stock"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-adjustments/{id}/submit [post]
+func (h *StockAdjustmentHandler) Submit(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	adjustment, err := h.service.Submit(id, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *adjustment)
+}
+
+// Approve godoc
+// @Summary      Approve a stock adjustment
+// @Description  Posts every line as an adjustment stock entry carrying its reason code. Controlled product lines without an authorizing user are authorized by the approver. Only users with the adjustment approver role may approve, and the submitter cannot approve their own adjustment.
+// @Tags         stock-adjustments
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Adjustment ID (UUID format)"
+// @Success      200  {object}  model.StockAdjustment
+// @Failure      400  {object}  object{success=bool,error=string}  "Adjustment not pending approval, same approver or insufficient stock"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      403  {object}  object{error=string}               "User lacks the adjustment approver role"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-adjustments/{id}/approve [post]
+func (h *StockAdjustmentHandler) Approve(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	adjustment, err := h.service.Approve(id, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *adjustment)
+}
+
+// Reject godoc
+// @Summary      Reject a stock adjustment
+// @Description  Rejects an adjustment waiting for approval. No stock is moved. Only users with the adjustment approver role may reject.
+// @Tags         stock-adjustments
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                            true  "Stock Adjustment ID (UUID format)"
+// @Param        reject  body      dto.StockAdjustmentRejectRequest  true  "Rejection reason"
+// @Success      200     {object}  model.StockAdjustment
+// @Failure      400     {object}  object{success=bool,error=string}  "Validation error or adjustment not pending approval"
+// @Failure      401     {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      403     {object}  object{error=string}               "User lacks the adjustment approver role"
+// @Failure      500     {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-adjustments/{id}/reject [post]
+func (h *StockAdjustmentHandler) Reject(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	var req dto.StockAdjustmentRejectRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	adjustment, err := h.service.Reject(id, currentUserID(c), req.Reason)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *adjustment)
+}
+
+// Cancel godoc
+// @Summary      Cancel a stock adjustment
+// @Description  Cancels an adjustment that has not been posted. No stock is moved.
+// @Tags         stock-adjustments
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Adjustment ID (UUID format)"
+// @Success      200  {object}  model.StockAdjustment
+// @Failure      400  {object}  object{success=bool,error=string}  "Adjustment already posted, rejected or cancelled"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-adjustments/{id}/cancel [post]
+func (h *StockAdjustmentHandler) Cancel(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	adjustment, err := h.service.Cancel(id)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *adjustment)
+}
+
+// AddAttachment godoc
+// @Summary      Attach evidence to a stock adjustment
+// @Description  Links a file already stored in file storage (photo, incident report) to an adjustment. Posted adjustments still accept attachments.
+// @Tags         stock-adjustments
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                                true  "Stock Adjustment ID (UUID format)"
+// @Param        attachment  body      dto.StockAdjustmentAttachmentRequest  true  "Attachment data"
+// @Success      201         {object}  model.StockAdjustmentAttachment
+// @Failure      400         {object}  object{success=bool,error=string}  "Validation error or adjustment rejected or cancelled"
+// @Failure      401         {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500         {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-adjustments/{id}/attachments [post]
+func (h *StockAdjustmentHandler) AddAttachment(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	var req dto.StockAdjustmentAttachmentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	attachment := req.ToAttachment()
+	attachment.UploadedBy = currentUserID(c)
+	if err := h.service.AddAttachment(id, attachment); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.StockAdjustmentAttachment]{
+		Success: true,
+		Data:    *attachment,
+	})
+}
+
+// DeleteAttachment godoc
+// @Summary      Remove an attachment from a stock adjustment
+// @Description  Removes evidence while the adjustment is in draft or waiting for approval
+// @Tags         stock-adjustments
+// @Accept       json
+// @Produce      json
+// @Param        id            path      string  true  "Stock Adjustment ID (UUID format)"
+// @Param        attachmentId  path      string  true  "Attachment ID (UUID format)"
+// @Success      204 {string} string "No Content"
+// @Failure      400  {object}  object{success=bool,error=string}  "Attachment not found or adjustment already posted"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-adjustments/{id}/attachments/{attachmentId} [delete]
+func (h *StockAdjustmentHandler) DeleteAttachment(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+	attachmentID, err := uuid.Parse(c.Param("attachmentId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid attachmentId format",
+		})
+	}
+
+	if err := h.service.DeleteAttachment(id, attachmentID); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
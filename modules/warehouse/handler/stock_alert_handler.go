@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type StockAlertHandler struct {
+	service service.StockAlertService
+}
+
+func NewStockAlertHandler(service service.StockAlertService) *StockAlertHandler {
+	return &StockAlertHandler{service: service}
+}
+
+func (h *StockAlertHandler) RegisterRoutes(g *echo.Group) {
+	ag := g.Group("/stock-alerts")
+	ag.GET("", h.GetAll)
+	ag.POST("/:id/acknowledge", h.Acknowledge)
+}
+
+// GetAll godoc
+// @Summary      Get list of stock alerts
+// @Description  Retrieves paginated alerts raised by the daily stock scan, newest first
+// @Tags         stock-alerts
+// @Accept       json
+// @Produce      json
+// @Param        page          query     int     false  "Page number (default: 1)"
+// @Param        pageSize      query     int     false  "Page size (default: 10)"
+// @Param        type          query     string  false  "Alert type (near_expiry, expired)"
+// @Param        acknowledged  query     bool    false  "Filter by acknowledgement"
+// @Success      200           {object}  object
+// @Failure      400           {object}  object
+// @Failure      401           {object}  object
+// @Failure      500           {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-alerts [get]
+func (h *StockAlertHandler) GetAll(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	var acknowledged *bool
+	if a := c.QueryParam("acknowledged"); a != "" {
+		parsed, err := strconv.ParseBool(a)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+				Success: false,
+				Error:   "invalid acknowledged value",
+			})
+		}
+		acknowledged = &parsed
+	}
+
+	alerts, total, err := h.service.GetAll(page, pageSize, c.QueryParam("type"), acknowledged)
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return contract.PaginatedSuccess(c, alerts, total, page, pageSize)
+}
+
+// Acknowledge godoc
+// @Summary      Acknowledge a stock alert
+// @Description  Marks an alert as seen by the current user
+// @Tags         stock-alerts
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Alert ID (UUID format)"
+// @Success      200  {object}  model.StockAlert
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-alerts/{id}/acknowledge [post]
+func (h *StockAlertHandler) Acknowledge(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	alert, err := h.service.Acknowledge(id, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *alert)
+}
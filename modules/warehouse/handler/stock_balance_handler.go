@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type StockBalanceHandler struct {
+	service service.StockBalanceService
+}
+
+func NewStockBalanceHandler(service service.StockBalanceService) *StockBalanceHandler {
+	return &StockBalanceHandler{service: service}
+}
+
+func (h *StockBalanceHandler) RegisterRoutes(g *echo.Group) {
+	g.GET("/warehouses/:id/stock", h.GetByWarehouse)
+	g.GET("/products/:id/stock", h.GetByProduct)
+}
+
+// GetByWarehouse godoc
+// @Summary      Get on-hand stock of a warehouse
+// @Description  Retrieves paginated non-zero stock balances per product and batch for a warehouse. With asOf the balances are rebuilt from the stock ledger as of that moment, starting from the snapshot of the latest closed period.
+// @Tags         stock-balances
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string  true   "Warehouse ID (UUID format)"
+// @Param        asOf      query     string  false  "Point in time (YYYY-MM-DD or RFC 3339, default: current balances)"
+// @Param        page      query     int     false  "Page number (default: 1)"
+// @Param        pageSize  query     int     false  "Page size (default: 10)"
+// @Success      200       {object}  object
+// @Failure      400       {object}  object
+// @Failure      401       {object}  object
+// @Failure      500       {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/warehouses/{id}/stock [get]
+func (h *StockBalanceHandler) GetByWarehouse(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	asOf, err := parseOptionalDate(c.QueryParam("asOf"), true)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid asOf format",
+		})
+	}
+
+	page, pageSize := paginationParams(c)
+	balances, total, err := h.service.GetByWarehouse(id, asOf, page, pageSize)
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return contract.PaginatedSuccess(c, balances, total, page, pageSize)
+}
+
+// GetByProduct godoc
+// @Summary      Get on-hand stock of a product
+// @Description  Retrieves paginated non-zero stock balances per warehouse and batch for a product. With asOf the balances are rebuilt from the stock ledger as of that moment, starting from the snapshot of the latest closed period.
+// @Tags         stock-balances
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string  true   "Product ID (UUID format)"
+// @Param        asOf      query     string  false  "Point in time (YYYY-MM-DD or RFC 3339, default: current balances)"
+// @Param        page      query     int     false  "Page number (default: 1)"
+// @Param        pageSize  query     int     false  "Page size (default: 10)"
+// @Success      200       {object}  object
+// @Failure      400       {object}  object
+// @Failure      401       {object}  object
+// @Failure      500       {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/products/{id}/stock [get]
+func (h *StockBalanceHandler) GetByProduct(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	asOf, err := parseOptionalDate(c.QueryParam("asOf"), true)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid asOf format",
+		})
+	}
+
+	page, pageSize := paginationParams(c)
+	balances, total, err := h.service.GetByProduct(id, asOf, page, pageSize)
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return contract.PaginatedSuccess(c, balances, total, page, pageSize)
+}
@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type StockCountHandler struct {
+	service service.StockCountService
+}
+
+func NewStockCountHandler(service service.StockCountService) *StockCountHandler {
+	return &StockCountHandler{service: service}
+}
+
+func (h *StockCountHandler) RegisterRoutes(g *echo.Group) {
+	cg := g.Group("/stock-counts")
+	cg.GET("", h.GetAll)
+	cg.POST("", h.Create)
+	cg.GET("/:id", h.GetByID)
+	cg.POST("/:id/counts", h.RecordCounts)
+	cg.POST("/:id/submit", h.Submit)
+	cg.POST("/:id/approve", h.Approve)
+	cg.POST("/:id/cancel", h.Cancel)
+}
+
+// GetAll godoc
+// @Summary      Get list of stock counts
+// @Description  Retrieves paginated stock count sessions, newest first. Expected quantities of blind counts are hidden until counting is submitted.
+// @Tags         stock-counts
+// @Accept       json
+// @Produce      json
+// @Param        page         query     int     false  "Page number (default: 1)"
+// @Param        pageSize     query     int     false  "Page size (default: 10)"
+// @Param        warehouseId  query     string  false  "Warehouse ID (UUID format)"
+// @Param        status       query     string  false  "Count status (counting, submitted, approved, cancelled)"
+// @Success      200          {object}  object
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-counts [get]
+func (h *StockCountHandler) GetAll(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+
+	counts, total, err := h.service.GetAll(page, pageSize, warehouseID, c.QueryParam("status"))
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+	for i := range counts {
+		counts[i].MaskExpected()
+	}
+
+	return contract.PaginatedSuccess(c, counts, total, page, pageSize)
+}
+
+// Create godoc
+// @Summary      Open a stock count
+// @Description  Starts a stock opname for a warehouse and freezes the current balance of every product and batch as the expected quantity
+// @Tags         stock-counts
+// @Accept       json
+// @Produce      json
+// @Param        count  body      dto.StockCountRequest  true  "Stock count data"
+// @Success      201    {object}  model.StockCount
+// @Failure      400    {object}  object{success=bool,error=string}  "Validation error"
+// @Failure      401    {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500    {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-counts [post]
+func (h *StockCountHandler) Create(c echo.Context) error {
+	var req dto.StockCountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	count := req.ToStockCount()
+	count.CreatedBy = currentUserID(c)
+	if err := h.service.Create(count); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+	count.MaskExpected()
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.StockCount]{
+		Success: true,
+		Data:    *count,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get stock count by ID
+// @Description  Retrieve a stock count with its lines. Expected quantities and variances of blind counts are hidden until counting is submitted.
+// @Tags         stock-counts
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Count ID (UUID format)"
+// @Success      200  {object}  model.StockCount
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      404  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-counts/{id} [get]
+func (h *StockCountHandler) GetByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	count, err := h.service.GetByID(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
+			Success: false,
+			Error:   err.Error(),
+		})
+	}
+	if count == nil {
+		return c.JSON(http.StatusNotFound, contract.APIResponse[any]{
+			Success: false,
+			Error:   "stock count not found",
+		})
+	}
+	count.MaskExpected()
+	return contract.SingleSuccess(c, *count)
+}
+
+// RecordCounts godoc
+// @Summary      Record counted quantities
+// @Description  Stores counted quantities by line, or by product and batch. Stock found outside the snapshot is added with an expected quantity of zero.
+// @Tags         stock-counts
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                        true  "Stock Count ID (UUID format)"
+// @Param        counts  body      dto.StockCountEntriesRequest  true  "Counted quantities"
+// @Success      200     {object}  model.StockCount
+// @Failure      400     {object}  object{success=bool,error=string}  "Validation error or count not open"
+// @Failure      401     {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500     {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-counts/{id}/counts [post]
+func (h *StockCountHandler) RecordCounts(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	var req dto.StockCountEntriesRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	entries := make([]service.StockCountEntry, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		entries = append(entries, service.StockCountEntry{
+			LineID:      line.LineID,
+			ProductID:   line.ProductID,
+			BatchNumber: line.BatchNumber,
+			ExpiredAt:   line.ExpiredAt,
+			Quantity:    line.Quantity,
+			Unit:        line.Unit,
+			Notes:       line.Notes,
+		})
+	}
+
+	count, err := h.service.RecordCounts(id, entries, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+	count.MaskExpected()
+
+	return contract.SingleSuccess(c, *count)
+}
+
+// Submit godoc
+// @Summary      Submit a stock count
+// @Description  Finishes counting and reveals variances for supervisor review. Every line must have been counted.
+// @Tags         stock-counts
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Count ID (UUID format)"
+// @Success      200  {object}  model.StockCount
+// @Failure      400  {object}  object{success=bool,error=string}  "Uncounted lines or count not open"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-counts/{id}/submit [post]
+func (h *StockCountHandler) Submit(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	count, err := h.service.Submit(id, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *count)
+}
+
+// Approve godoc
+// @Summary      Approve a stock count
+// @Description  Posts every variance as an adjustment stock entry referencing the count session. Variances are recomputed against the balances at approval, and every counted batch ends up on exactly its counted quantity whatever moved since the snapshot. The approving user authorizes the variances; those of controlled products also need the counterparty.
+// @Tags         stock-counts
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                        true   "Stock Count ID (UUID format)"
+// @Param        approve  body      dto.StockCountApproveRequest  false  "Approval data"
+// @Success      200      {object}  model.StockCount
+// @Failure      400      {object}  object{success=bool,error=string}  "Validation error, count not submitted or insufficient stock"
+// @Failure      401      {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500      {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-counts/{id}/approve [post]
+func (h *StockCountHandler) Approve(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	var req dto.StockCountApproveRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	count, err := h.service.Approve(id, req.Counterparty, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *count)
+}
+
+// Cancel godoc
+// @Summary      Cancel a stock count
+// @Description  Cancels a count that has not been approved. No stock is adjusted.
+// @Tags         stock-counts
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Count ID (UUID format)"
+// @Success      200  {object}  model.StockCount
+// @Failure      400  {object}  object{success=bool,error=string}  "Count already approved or cancelled"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-counts/{id}/cancel [post]
+func (h *StockCountHandler) Cancel(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	count, err := h.service.Cancel(id)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *count)
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type StockEntryHandler struct {
+	service service.StockEntryService
+}
+
+func NewStockEntryHandler(service service.StockEntryService) *StockEntryHandler {
+	return &StockEntryHandler{service: service}
+}
+
+func (h *StockEntryHandler) RegisterRoutes(g *echo.Group) {
+	sg := g.Group("/stock-entries")
+	sg.GET("", h.GetAll)
+	sg.GET("/:id", h.GetByID)
+	sg.POST("/receipts", h.Receive)
+	sg.POST("/issues", h.Issue)
+	sg.POST("/write-offs", h.WriteOff)
+}
+
+// GetAll godoc
+// @Summary      Get stock ledger
+// @Description  Retrieves paginated stock movements, newest first, optionally filtered by warehouse, product, batch, status and reason code
+// @Tags         stock-entries
+// @Accept       json
+// @Produce      json
+// @Param        page         query     int     false  "Page number (default: 1)"
+// @Param        pageSize     query     int     false  "Page size (default: 10)"
+// @Param        warehouseId  query     string  false  "Warehouse ID (UUID format)"
+// @Param        productId    query     string  false  "Product ID (UUID format)"
+// @Param        batchNumber  query     string  false  "Batch number"
+// @Param        status       query     string  false  "Movement status (receipt, issue, adjustment, transfer_out, transfer_in, write_off, return_out, return_in, qc_change)"
+// @Param        reasonCode   query     string  false  "Adjustment reason code"
+// @Success      200          {object}  object
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-entries [get]
+func (h *StockEntryHandler) GetAll(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+	productID, err := parseOptionalUUID(c.QueryParam("productId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid productId format",
+		})
+	}
+
+	filter := repository.StockEntryFilter{
+		WarehouseID: warehouseID,
+		ProductID:   productID,
+		BatchNumber: c.QueryParam("batchNumber"),
+		Status:      c.QueryParam("status"),
+		ReasonCode:  c.QueryParam("reasonCode"),
+	}
+
+	entries, total, err := h.service.GetAll(page, pageSize, filter)
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return contract.PaginatedSuccess(c, entries, total, page, pageSize)
+}
+
+// GetByID godoc
+// @Summary      Get stock entry by ID
+// @Description  Retrieve a single stock movement by its ID
+// @Tags         stock-entries
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Entry ID (UUID format)"
+// @Success      200  {object}  model.StockEntry
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      404  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-entries/{id} [get]
+func (h *StockEntryHandler) GetByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	entry, err := h.service.GetByID(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
+			Success: false,
+			Error:   err.Error(),
+		})
+	}
+	if entry == nil {
+		return c.JSON(http.StatusNotFound, contract.APIResponse[any]{
+			Success: false,
+			Error:   "stock entry not found",
+		})
+	}
+	return contract.SingleSuccess(c, *entry)
+}
+
+// Receive godoc
+// @Summary      Record a stock receipt
+// @Description  Appends an incoming movement to the ledger. Previous stock is taken from the last entry of the same warehouse, product and batch. Receipts into warehouses that quarantine receipts are always held in quarantine until QC releases them.
+// @Tags         stock-entries
+// @Accept       json
+// @Produce      json
+// @Param        receipt  body      dto.StockReceiptRequest  true  "Receipt data"
+// @Success      201      {object}  model.StockEntry
+// @Failure      400      {object}  object{success=bool,error=string}  "Validation error"
+// @Failure      401      {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500      {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-entries/receipts [post]
+func (h *StockEntryHandler) Receive(c echo.Context) error {
+	var req dto.StockReceiptRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	entry := req.ToStockEntry()
+	entry.CreatedBy = currentUserID(c)
+	if err := h.service.Receive(entry); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.StockEntry]{
+		Success: true,
+		Data:    *entry,
+	})
+}
+
+// Issue godoc
+// @Summary      Record a stock issue
+// @Description  Appends an outgoing movement to the ledger. Without a batch number the quantity is allocated across batches using the warehouse issue policy (FEFO by default, FIFO optional); expired batches are skipped and cannot be issued until written off, quarantined and rejected batches are skipped and cannot be issued at all. Stock reserved for other documents cannot be issued. Concurrent issues of the same product are serialized, so stock never drops below zero unless the warehouse allows negative stock. Returns one entry per consumed batch.
+// @Tags         stock-entries
+// @Accept       json
+// @Produce      json
+// @Param        issue  body      dto.StockIssueRequest  true  "Issue data"
+// @Success      201    {array}   model.StockEntry
+// @Failure      400    {object}  object{success=bool,error=string}  "Validation error or insufficient stock"
+// @Failure      401    {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500    {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-entries/issues [post]
+func (h *StockEntryHandler) Issue(c echo.Context) error {
+	var req dto.StockIssueRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+	if req.Quantity <= 0 {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "quantity must be greater than 0",
+		})
+	}
+
+	entry := req.ToStockEntry()
+	entry.CreatedBy = currentUserID(c)
+	entries, err := h.service.Issue(entry)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[[]model.StockEntry]{
+		Success: true,
+		Data:    entries,
+	})
+}
+
+// WriteOff godoc
+// @Summary      Write off a batch
+// @Description  Removes expired or unusable stock from a batch. Expired batches can only leave stock through a write-off.
+// @Tags         stock-entries
+// @Accept       json
+// @Produce      json
+// @Param        writeOff  body      dto.StockWriteOffRequest  true  "Write-off data"
+// @Success      201       {object}  model.StockEntry
+// @Failure      400       {object}  object{success=bool,error=string}  "Validation error or insufficient stock"
+// @Failure      401       {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500       {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-entries/write-offs [post]
+func (h *StockEntryHandler) WriteOff(c echo.Context) error {
+	var req dto.StockWriteOffRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+	if req.Quantity < 0 {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "quantity cannot be negative",
+		})
+	}
+
+	entry := req.ToStockEntry()
+	entry.CreatedBy = currentUserID(c)
+	if err := h.service.WriteOff(entry); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.StockEntry]{
+		Success: true,
+		Data:    *entry,
+	})
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type StockPeriodHandler struct {
+	service service.StockPeriodService
+}
+
+func NewStockPeriodHandler(service service.StockPeriodService) *StockPeriodHandler {
+	return &StockPeriodHandler{service: service}
+}
+
+func (h *StockPeriodHandler) RegisterRoutes(g *echo.Group) {
+	pg := g.Group("/stock-periods")
+	pg.GET("", h.GetAll)
+	pg.POST("/close", h.Close)
+	pg.GET("/:id", h.GetByID)
+	pg.POST("/:id/reopen", h.Reopen)
+}
+
+// GetAll godoc
+// @Summary      Get list of stock periods
+// @Description  Retrieves paginated stock periods, latest month first
+// @Tags         stock-periods
+// @Accept       json
+// @Produce      json
+// @Param        page      query     int     false  "Page number (default: 1)"
+// @Param        pageSize  query     int     false  "Page size (default: 10)"
+// @Param        status    query     string  false  "Period status (open, closed)"
+// @Success      200       {object}  object
+// @Failure      400       {object}  object
+// @Failure      401       {object}  object
+// @Failure      500       {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-periods [get]
+func (h *StockPeriodHandler) GetAll(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	periods, total, err := h.service.GetAll(page, pageSize, c.QueryParam("status"))
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return contract.PaginatedSuccess(c, periods, total, page, pageSize)
+}
+
+// GetByID godoc
+// @Summary      Get stock period by ID
+// @Description  Retrieve a single stock period by its ID
+// @Tags         stock-periods
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Period ID (UUID format)"
+// @Success      200  {object}  model.StockPeriod
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      404  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-periods/{id} [get]
+func (h *StockPeriodHandler) GetByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	period, err := h.service.GetByID(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
+			Success: false,
+			Error:   err.Error(),
+		})
+	}
+	if period == nil {
+		return c.JSON(http.StatusNotFound, contract.APIResponse[any]{
+			Success: false,
+			Error:   "stock period not found",
+		})
+	}
+	return contract.SingleSuccess(c, *period)
+}
+
+// Close godoc
+// @Summary      Close a stock period
+// @Description  Closes a past month: snapshots the closing stock balances and the valuation report (overall and per office), and rejects any later stock movement dated within or before the month. Balance and valuation queries as of the month end are then served from the snapshots. Months close in calendar order.
+// @Tags         stock-periods
+// @Accept       json
+// @Produce      json
+// @Param        period  body      dto.StockPeriodCloseRequest  true  "Period to close"
+// @Success      200     {object}  model.StockPeriod
+// @Failure      400     {object}  object{success=bool,error=string}  "Invalid, unfinished or already closed period"
+// @Failure      401     {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500     {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-periods/close [post]
+func (h *StockPeriodHandler) Close(c echo.Context) error {
+	var req dto.StockPeriodCloseRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	period, err := h.service.Close(req.Period, req.Notes, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *period)
+}
+
+// Reopen godoc
+// @Summary      Reopen a stock period
+// @Description  Reopens the latest closed period and drops its snapshots so backdated corrections can be posted. The period has to be closed again afterwards.
+// @Tags         stock-periods
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Period ID (UUID format)"
+// @Success      200  {object}  model.StockPeriod
+// @Failure      400  {object}  object{success=bool,error=string}  "Period not closed or not the latest closed period"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-periods/{id}/reopen [post]
+func (h *StockPeriodHandler) Reopen(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	period, err := h.service.Reopen(id, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *period)
+}
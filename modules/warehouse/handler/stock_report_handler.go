@@ -0,0 +1,477 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/labstack/echo/v4"
+)
+
+type StockReportHandler struct {
+	service service.StockReportService
+}
+
+func NewStockReportHandler(service service.StockReportService) *StockReportHandler {
+	return &StockReportHandler{service: service}
+}
+
+func (h *StockReportHandler) RegisterRoutes(g *echo.Group) {
+	rg := g.Group("/stock-reports")
+	rg.GET("/expiry", h.GetExpiryReport)
+	rg.GET("/shrinkage", h.GetShrinkageReport)
+	rg.GET("/stock-card", h.GetStockCard)
+	rg.GET("/abc", h.GetABCReport)
+	rg.GET("/dead-stock", h.GetDeadStockReport)
+	rg.GET("/controlled-register", h.GetControlledRegister)
+}
+
+// GetExpiryReport godoc
+// @Summary      Get near-expiry and expired stock
+// @Description  Lists batches with remaining stock that expire within the given number of days, including already expired ones, grouped by office, branch and warehouse with quantity and value
+// @Tags         stock-reports
+// @Accept       json
+// @Produce      json
+// @Param        days      query     int     false  "Days ahead to look for expiring batches (default: 30)"
+// @Param        officeId  query     string  false  "Office ID (UUID format)"
+// @Success      200       {object}  service.ExpiryReport
+// @Failure      400       {object}  object
+// @Failure      401       {object}  object
+// @Failure      500       {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-reports/expiry [get]
+func (h *StockReportHandler) GetExpiryReport(c echo.Context) error {
+	days := 30
+	if d := c.QueryParam("days"); d != "" {
+		parsedDays, err := strconv.Atoi(d)
+		if err != nil || parsedDays < 0 {
+			return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+				Success: false,
+				Error:   "invalid days value",
+			})
+		}
+		days = parsedDays
+	}
+
+	officeID, err := parseOptionalUUID(c.QueryParam("officeId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid officeId format",
+		})
+	}
+
+	report, err := h.service.GetExpiryReport(days, officeID)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *report)
+}
+
+// GetShrinkageReport godoc
+// @Summary      Get shrinkage by reason code
+// @Description  Totals stock removed by adjustments carrying a reason code (damage, loss, sampling, ...), grouped by reason and warehouse with quantity and value
+// @Tags         stock-reports
+// @Accept       json
+// @Produce      json
+// @Param        from         query     string  false  "Start date (YYYY-MM-DD or RFC 3339), open when empty"
+// @Param        to           query     string  false  "End date (YYYY-MM-DD or RFC 3339, default: now)"
+// @Param        warehouseId  query     string  false  "Warehouse ID (UUID format)"
+// @Param        officeId     query     string  false  "Office ID (UUID format)"
+// @Success      200          {object}  service.ShrinkageReport
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-reports/shrinkage [get]
+func (h *StockReportHandler) GetShrinkageReport(c echo.Context) error {
+	from, err := parseOptionalDate(c.QueryParam("from"), false)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid from format",
+		})
+	}
+	to, err := parseOptionalDate(c.QueryParam("to"), true)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid to format",
+		})
+	}
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+	officeID, err := parseOptionalUUID(c.QueryParam("officeId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid officeId format",
+		})
+	}
+
+	report, err := h.service.GetShrinkageReport(from, to, warehouseID, officeID)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *report)
+}
+
+// GetStockCard godoc
+// @Summary      Get stock card
+// @Description  Returns the movement history (kartu stok) of a product in a warehouse over a period, read from the stock ledger. Each movement shows its in and out quantities, the balance before and after it, the document reference, batch and user. Opening balance, totals and closing balance cover the whole period; movements are paginated oldest first. With format=csv every movement of the period is exported as a CSV file.
+// @Tags         stock-reports
+// @Accept       json
+// @Produce      json,text/csv
+// @Param        productId    query     string  true   "Product ID (UUID format)"
+// @Param        warehouseId  query     string  true   "Warehouse ID (UUID format)"
+// @Param        batchNumber  query     string  false  "Batch number, all batches when empty"
+// @Param        from         query     string  false  "Start date (YYYY-MM-DD or RFC 3339), open when empty"
+// @Param        to           query     string  false  "End date (YYYY-MM-DD or RFC 3339, default: now)"
+// @Param        page         query     int     false  "Page number (default: 1)"
+// @Param        pageSize     query     int     false  "Page size (default: 10)"
+// @Param        format       query     string  false  "Response format (json, csv)"
+// @Success      200          {object}  service.StockCard
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-reports/stock-card [get]
+func (h *StockReportHandler) GetStockCard(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	productID, err := parseOptionalUUID(c.QueryParam("productId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid productId format",
+		})
+	}
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+	from, err := parseOptionalDate(c.QueryParam("from"), false)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid from format",
+		})
+	}
+	to, err := parseOptionalDate(c.QueryParam("to"), true)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid to format",
+		})
+	}
+
+	format := c.QueryParam("format")
+	if format != "" && format != "json" && format != "csv" {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid format value",
+		})
+	}
+	if format == "csv" {
+		// Exports carry the whole period
+		page, pageSize = 1, 0
+	}
+
+	filter := repository.StockCardFilter{
+		WarehouseID: warehouseID,
+		ProductID:   productID,
+		BatchNumber: c.QueryParam("batchNumber"),
+		From:        from,
+		To:          to,
+	}
+
+	card, err := h.service.GetStockCard(filter, page, pageSize)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	if format == "csv" {
+		return writeStockCardCSV(c, card)
+	}
+	return contract.SingleSuccess(c, *card)
+}
+
+// writeStockCardCSV streams a stock card as CSV, framed by opening and closing balance rows
+func writeStockCardCSV(c echo.Context, card *service.StockCard) error {
+	fileName := fmt.Sprintf("stock-card-%s-%s.csv", card.ProductCode, card.To.Format("20060102"))
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Response().WriteHeader(http.StatusOK)
+
+	openingDate := ""
+	if card.From != nil {
+		openingDate = card.From.Format(time.RFC3339)
+	}
+
+	w := csv.NewWriter(c.Response())
+	rows := [][]string{
+		{"date", "status", "reference_id", "order_id", "reason_code", "batch_number", "expired_at", "opening", "in", "out", "balance", "user", "notes"},
+		{openingDate, "opening_balance", "", "", "", card.BatchNumber, "", "", "", "", strconv.Itoa(card.OpeningBalance), "", ""},
+	}
+	for _, m := range card.Movements {
+		rows = append(rows, []string{
+			m.Date.Format(time.RFC3339),
+			m.Status,
+			m.ReferenceID.String(),
+			m.OrderID.String(),
+			m.ReasonCode,
+			m.BatchNumber,
+			m.ExpiredAt.Format("2006-01-02"),
+			strconv.Itoa(m.Opening),
+			strconv.Itoa(m.In),
+			strconv.Itoa(m.Out),
+			strconv.Itoa(m.Balance),
+			m.Username,
+			m.Notes,
+		})
+	}
+	rows = append(rows, []string{card.To.Format(time.RFC3339), "closing_balance", "", "", "", card.BatchNumber, "", strconv.Itoa(card.OpeningBalance), strconv.Itoa(card.TotalIn), strconv.Itoa(card.TotalOut), strconv.Itoa(card.ClosingBalance), "", ""})
+
+	return w.WriteAll(rows)
+}
+
+// GetABCReport godoc
+// @Summary      Get ABC analysis
+// @Description  Ranks products by the value of stock issued within the period and classifies them into A, B and C classes by cumulative share of the total consumption value. Products with no issue in the period are not listed.
+// @Tags         stock-reports
+// @Accept       json
+// @Produce      json
+// @Param        from         query     string  false  "Start date (YYYY-MM-DD or RFC 3339, default: one year before to)"
+// @Param        to           query     string  false  "End date (YYYY-MM-DD or RFC 3339, default: now)"
+// @Param        warehouseId  query     string  false  "Warehouse ID (UUID format)"
+// @Param        officeId     query     string  false  "Office ID (UUID format)"
+// @Param        classA       query     number  false  "Cumulative share (%) closing class A (default: 80)"
+// @Param        classB       query     number  false  "Cumulative share (%) closing class B (default: 95)"
+// @Success      200          {object}  service.ABCReport
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-reports/abc [get]
+func (h *StockReportHandler) GetABCReport(c echo.Context) error {
+	from, err := parseOptionalDate(c.QueryParam("from"), false)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid from format",
+		})
+	}
+	to, err := parseOptionalDate(c.QueryParam("to"), true)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid to format",
+		})
+	}
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+	officeID, err := parseOptionalUUID(c.QueryParam("officeId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid officeId format",
+		})
+	}
+
+	classA, classB := 80.0, 95.0
+	if a := c.QueryParam("classA"); a != "" {
+		if classA, err = strconv.ParseFloat(a, 64); err != nil {
+			return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+				Success: false,
+				Error:   "invalid classA value",
+			})
+		}
+	}
+	if b := c.QueryParam("classB"); b != "" {
+		if classB, err = strconv.ParseFloat(b, 64); err != nil {
+			return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+				Success: false,
+				Error:   "invalid classB value",
+			})
+		}
+	}
+
+	report, err := h.service.GetABCReport(from, to, warehouseID, officeID, classA, classB)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *report)
+}
+
+// GetDeadStockReport godoc
+// @Summary      Get dead and slow-moving stock
+// @Description  Lists stock on hand per product and warehouse, grouped by product category, that had no outgoing movement (issue or transfer out) in the last given days (dead), or that would last longer than slowCoverDays at the outgoing rate of those days (slow-moving)
+// @Tags         stock-reports
+// @Accept       json
+// @Produce      json
+// @Param        days           query     int     false  "Days without outgoing movement before stock counts as dead (default: 90)"
+// @Param        slowCoverDays  query     int     false  "Days of cover above which moving stock counts as slow, 0 to skip (default: 180)"
+// @Param        warehouseId    query     string  false  "Warehouse ID (UUID format)"
+// @Param        officeId       query     string  false  "Office ID (UUID format)"
+// @Success      200            {object}  service.DeadStockReport
+// @Failure      400            {object}  object
+// @Failure      401            {object}  object
+// @Failure      500            {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-reports/dead-stock [get]
+func (h *StockReportHandler) GetDeadStockReport(c echo.Context) error {
+	days := 90
+	if d := c.QueryParam("days"); d != "" {
+		parsedDays, err := parsePositiveInt(d)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+				Success: false,
+				Error:   "invalid days value",
+			})
+		}
+		days = parsedDays
+	}
+	slowCoverDays := 180
+	if d := c.QueryParam("slowCoverDays"); d != "" {
+		parsedDays, err := strconv.Atoi(d)
+		if err != nil || parsedDays < 0 {
+			return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+				Success: false,
+				Error:   "invalid slowCoverDays value",
+			})
+		}
+		slowCoverDays = parsedDays
+	}
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+	officeID, err := parseOptionalUUID(c.QueryParam("officeId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid officeId format",
+		})
+	}
+
+	report, err := h.service.GetDeadStockReport(days, slowCoverDays, warehouseID, officeID)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *report)
+}
+
+// GetControlledRegister godoc
+// @Summary      Get the controlled substance register
+// @Description  Builds the monthly register of controlled products (narcotic, psychotropic, precursor) per office: opening balance, in, out and closing balance of each product with every movement of the month, its counterparty and authorizing user. Transfers between warehouses of the same office are shown apart from in and out. With format=csv the register is exported as a CSV file.
+// @Tags         stock-reports
+// @Accept       json
+// @Produce      json,text/csv
+// @Param        period    query     string  false  "Register month (YYYY-MM, default: current month)"
+// @Param        officeId  query     string  false  "Office ID (UUID format)"
+// @Param        class     query     string  false  "Controlled class (narcotic, psychotropic, precursor)"
+// @Param        format    query     string  false  "Response format (json, csv)"
+// @Success      200       {object}  service.ControlledRegister
+// @Failure      400       {object}  object
+// @Failure      401       {object}  object
+// @Failure      500       {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-reports/controlled-register [get]
+func (h *StockReportHandler) GetControlledRegister(c echo.Context) error {
+	period := c.QueryParam("period")
+	if period == "" {
+		period = time.Now().Format("2006-01")
+	}
+	officeID, err := parseOptionalUUID(c.QueryParam("officeId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid officeId format",
+		})
+	}
+	format := c.QueryParam("format")
+	if format != "" && format != "json" && format != "csv" {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid format value",
+		})
+	}
+
+	register, err := h.service.GetControlledRegister(period, officeID, c.QueryParam("class"))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	if format == "csv" {
+		return writeControlledRegisterCSV(c, register)
+	}
+	return contract.SingleSuccess(c, *register)
+}
+
+// writeControlledRegisterCSV streams the register as CSV, each product framed
+// by its opening and closing balance rows
+func writeControlledRegisterCSV(c echo.Context, register *service.ControlledRegister) error {
+	fileName := fmt.Sprintf("controlled-register-%s.csv", register.Period)
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Response().WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(c.Response())
+	rows := [][]string{
+		{"office", "product_code", "product_name", "class", "unit", "date", "warehouse", "status", "batch_number", "reference_id", "counterparty", "in", "out", "internal_transfer", "balance", "authorized_by", "user", "notes"},
+	}
+	for _, office := range register.Offices {
+		for _, p := range office.Products {
+			// line prefixes the product columns to the cells of one row
+			line := func(cells ...string) []string {
+				return append([]string{office.OfficeName, p.ProductCode, p.ProductName, p.ControlledClass, p.Unit}, cells...)
+			}
+			rows = append(rows, line(register.From.Format(time.RFC3339), "", "opening_balance", "", "", "", "", "", "", strconv.Itoa(p.Opening), "", "", ""))
+			for _, m := range p.Movements {
+				rows = append(rows, line(
+					m.Date.Format(time.RFC3339),
+					m.WarehouseName,
+					m.Status,
+					m.BatchNumber,
+					m.ReferenceID.String(),
+					m.Counterparty,
+					strconv.Itoa(m.In),
+					strconv.Itoa(m.Out),
+					strconv.Itoa(m.InternalTransfer),
+					strconv.Itoa(m.Balance),
+					m.AuthorizedByName,
+					m.Username,
+					m.Notes,
+				))
+			}
+			rows = append(rows, line(register.To.Format(time.RFC3339), "", "closing_balance", "", "", "", strconv.Itoa(p.In), strconv.Itoa(p.Out), strconv.Itoa(p.InternalTransfer), strconv.Itoa(p.Closing), "", "", ""))
+		}
+	}
+
+	return w.WriteAll(rows)
+}
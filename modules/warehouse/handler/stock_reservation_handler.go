@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type StockReservationHandler struct {
+	service service.StockReservationService
+}
+
+func NewStockReservationHandler(service service.StockReservationService) *StockReservationHandler {
+	return &StockReservationHandler{service: service}
+}
+
+func (h *StockReservationHandler) RegisterRoutes(g *echo.Group) {
+	rg := g.Group("/stock-reservations")
+	rg.GET("", h.GetAll)
+	rg.POST("", h.Create)
+	rg.GET("/:id", h.GetByID)
+	rg.POST("/:id/release", h.Release)
+	rg.POST("/:id/fulfill", h.Fulfill)
+	g.GET("/stock-availability", h.GetAvailability)
+}
+
+// GetAll godoc
+// @Summary      Get stock reservations
+// @Description  Retrieves paginated stock reservations, newest first, optionally filtered by warehouse, product, reference document and status
+// @Tags         stock-reservations
+// @Accept       json
+// @Produce      json
+// @Param        page         query     int     false  "Page number (default: 1)"
+// @Param        pageSize     query     int     false  "Page size (default: 10)"
+// @Param        warehouseId  query     string  false  "Warehouse ID (UUID format)"
+// @Param        productId    query     string  false  "Product ID (UUID format)"
+// @Param        referenceId  query     string  false  "Reference document ID (UUID format)"
+// @Param        status       query     string  false  "Reservation status (active, released, fulfilled, expired)"
+// @Success      200          {object}  object
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-reservations [get]
+func (h *StockReservationHandler) GetAll(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+	productID, err := parseOptionalUUID(c.QueryParam("productId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid productId format",
+		})
+	}
+	referenceID, err := parseOptionalUUID(c.QueryParam("referenceId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid referenceId format",
+		})
+	}
+
+	filter := repository.StockReservationFilter{
+		WarehouseID: warehouseID,
+		ProductID:   productID,
+		ReferenceID: referenceID,
+		Status:      c.QueryParam("status"),
+	}
+
+	reservations, total, err := h.service.GetAll(page, pageSize, filter)
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return contract.PaginatedSuccess(c, reservations, total, page, pageSize)
+}
+
+// Create godoc
+// @Summary      Reserve stock
+// @Description  Holds stock for a confirmed document without issuing it. Fails when the warehouse has less available-to-promise stock than requested.
+// @Tags         stock-reservations
+// @Accept       json
+// @Produce      json
+// @Param        reservation  body      dto.StockReservationRequest  true  "Reservation data"
+// @Success      201          {object}  model.StockReservation
+// @Failure      400          {object}  object{success=bool,error=string}  "Validation error or insufficient available stock"
+// @Failure      401          {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500          {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-reservations [post]
+func (h *StockReservationHandler) Create(c echo.Context) error {
+	var req dto.StockReservationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	reservation := req.ToStockReservation()
+	reservation.CreatedBy = currentUserID(c)
+	if err := h.service.Create(reservation); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.StockReservation]{
+		Success: true,
+		Data:    *reservation,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get stock reservation by ID
+// @Description  Retrieve a single stock reservation by its ID
+// @Tags         stock-reservations
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Reservation ID (UUID format)"
+// @Success      200  {object}  model.StockReservation
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      404  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-reservations/{id} [get]
+func (h *StockReservationHandler) GetByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	reservation, err := h.service.GetByID(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
+			Success: false,
+			Error:   err.Error(),
+		})
+	}
+	if reservation == nil {
+		return c.JSON(http.StatusNotFound, contract.APIResponse[any]{
+			Success: false,
+			Error:   "stock reservation not found",
+		})
+	}
+	return contract.SingleSuccess(c, *reservation)
+}
+
+// Release godoc
+// @Summary      Release a stock reservation
+// @Description  Returns the reserved quantity to available stock without issuing it
+// @Tags         stock-reservations
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Reservation ID (UUID format)"
+// @Success      200  {object}  model.StockReservation
+// @Failure      400  {object}  object{success=bool,error=string}  "Reservation not active"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-reservations/{id}/release [post]
+func (h *StockReservationHandler) Release(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	reservation, err := h.service.Release(id, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+	return contract.SingleSuccess(c, *reservation)
+}
+
+// Fulfill godoc
+// @Summary      Fulfil a stock reservation
+// @Description  Issues the reserved quantity out of the warehouse and closes the reservation. Without a batch number the issue follows the warehouse FEFO/FIFO policy. Controlled products need the authorizing user and the counterparty.
+// @Tags         stock-reservations
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                              true   "Stock Reservation ID (UUID format)"
+// @Param        fulfill  body      dto.StockReservationFulfillRequest  false  "Fulfilment data"
+// @Success      200      {object}  model.StockReservation
+// @Failure      400      {object}  object{success=bool,error=string}  "Validation error, reservation not active or insufficient stock"
+// @Failure      401      {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500      {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-reservations/{id}/fulfill [post]
+func (h *StockReservationHandler) Fulfill(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	var req dto.StockReservationFulfillRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	reservation, err := h.service.Fulfill(id, req.AuthorizedBy, req.Counterparty, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+	return contract.SingleSuccess(c, *reservation)
+}
+
+// GetAvailability godoc
+// @Summary      Get available-to-promise stock
+// @Description  Returns on-hand stock minus active reservations per warehouse and product. Expired batches are left out of on-hand. Filter by office to see every warehouse of that office.
+// @Tags         stock-reservations
+// @Accept       json
+// @Produce      json
+// @Param        productId    query     string  false  "Product ID (UUID format)"
+// @Param        warehouseId  query     string  false  "Warehouse ID (UUID format)"
+// @Param        officeId     query     string  false  "Office ID (UUID format)"
+// @Success      200          {array}   repository.AvailabilityRow
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-availability [get]
+func (h *StockReservationHandler) GetAvailability(c echo.Context) error {
+	productID, err := parseOptionalUUID(c.QueryParam("productId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid productId format",
+		})
+	}
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+	officeID, err := parseOptionalUUID(c.QueryParam("officeId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid officeId format",
+		})
+	}
+
+	rows, err := h.service.GetAvailability(warehouseID, productID, officeID)
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+	return contract.SingleSuccess(c, rows)
+}
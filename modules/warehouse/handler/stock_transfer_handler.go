@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type StockTransferHandler struct {
+	service service.StockTransferService
+}
+
+func NewStockTransferHandler(service service.StockTransferService) *StockTransferHandler {
+	return &StockTransferHandler{service: service}
+}
+
+func (h *StockTransferHandler) RegisterRoutes(g *echo.Group) {
+	tg := g.Group("/stock-transfers")
+	tg.GET("", h.GetAll)
+	tg.POST("", h.Create)
+	tg.GET("/:id", h.GetByID)
+	tg.PUT("/:id", h.Update)
+	tg.POST("/:id/ship", h.Ship)
+	tg.POST("/:id/dispatch", h.Dispatch)
+	tg.POST("/:id/receive", h.Receive)
+	tg.POST("/:id/cancel", h.Cancel)
+}
+
+// GetAll godoc
+// @Summary      Get list of stock transfers
+// @Description  Retrieves paginated inter-warehouse transfers, newest first, optionally filtered by status
+// @Tags         stock-transfers
+// @Accept       json
+// @Produce      json
+// @Param        page      query     int     false  "Page number (default: 1)"
+// @Param        pageSize  query     int     false  "Page size (default: 10)"
+// @Param        status    query     string  false  "Transfer status (draft, shipped, in_transit, received, cancelled)"
+// @Success      200       {object}  object
+// @Failure      401       {object}  object
+// @Failure      500       {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-transfers [get]
+func (h *StockTransferHandler) GetAll(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	transfers, total, err := h.service.GetAll(page, pageSize, c.QueryParam("status"))
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return contract.PaginatedSuccess(c, transfers, total, page, pageSize)
+}
+
+// Create godoc
+// @Summary      Create a stock transfer
+// @Description  Creates a draft transfer between two warehouses and reserves its lines at the source. No stock moves until the transfer is shipped.
+// @Tags         stock-transfers
+// @Accept       json
+// @Produce      json
+// @Param        transfer  body      dto.StockTransferRequest  true  "Transfer data"
+// @Success      201       {object}  model.StockTransfer
+// @Failure      400       {object}  object{success=bool,error=string}  "Validation error or insufficient available stock"
+// @Failure      401       {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500       {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-transfers [post]
+func (h *StockTransferHandler) Create(c echo.Context) error {
+	var req dto.StockTransferRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	transfer := req.ToStockTransfer()
+	transfer.CreatedBy = currentUserID(c)
+	if err := h.service.Create(transfer); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.StockTransfer]{
+		Success: true,
+		Data:    *transfer,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get stock transfer by ID
+// @Description  Retrieve a transfer with its lines, received quantities and discrepancies
+// @Tags         stock-transfers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Transfer ID (UUID format)"
+// @Success      200  {object}  model.StockTransfer
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      404  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-transfers/{id} [get]
+func (h *StockTransferHandler) GetByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	transfer, err := h.service.GetByID(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
+			Success: false,
+			Error:   err.Error(),
+		})
+	}
+	if transfer == nil {
+		return c.JSON(http.StatusNotFound, contract.APIResponse[any]{
+			Success: false,
+			Error:   "stock transfer not found",
+		})
+	}
+	return contract.SingleSuccess(c, *transfer)
+}
+
+// Update godoc
+// @Summary      Update a draft stock transfer
+// @Description  Replaces the warehouses, notes and lines of a transfer that is still in draft. Reservations are released and taken again for the new lines.
+// @Tags         stock-transfers
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                    true  "Stock Transfer ID (UUID format)"
+// @Param        transfer  body      dto.StockTransferRequest  true  "Transfer data"
+// @Success      200       {object}  model.StockTransfer
+// @Failure      400       {object}  object{success=bool,error=string}  "Validation error, transfer not in draft or insufficient available stock"
+// @Failure      401       {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500       {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-transfers/{id} [put]
+func (h *StockTransferHandler) Update(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	var req dto.StockTransferRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	transfer := req.ToStockTransfer()
+	if err := h.service.Update(id, transfer); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *transfer)
+}
+
+// Ship godoc
+// @Summary      Ship a stock transfer
+// @Description  Fulfils the reservations of the transfer and issues every line out of the source warehouse as transfer_out stock entries. Lines without a batch number are allocated by the warehouse issue policy (FEFO or FIFO) and split into one line per issued batch.
+// @Tags         stock-transfers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Transfer ID (UUID format)"
+// @Success      200  {object}  model.StockTransfer
+// @Failure      400  {object}  object{success=bool,error=string}  "Transfer not in draft or insufficient stock"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-transfers/{id}/ship [post]
+func (h *StockTransferHandler) Ship(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	transfer, err := h.service.Ship(id, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *transfer)
+}
+
+// Dispatch godoc
+// @Summary      Mark a stock transfer in transit
+// @Description  Marks a shipped transfer as handed over to the carrier
+// @Tags         stock-transfers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Transfer ID (UUID format)"
+// @Success      200  {object}  model.StockTransfer
+// @Failure      400  {object}  object{success=bool,error=string}  "Transfer not shipped"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-transfers/{id}/dispatch [post]
+func (h *StockTransferHandler) Dispatch(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	transfer, err := h.service.Dispatch(id)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *transfer)
+}
+
+// Receive godoc
+// @Summary      Receive a stock transfer
+// @Description  Puts arrived quantities into the destination warehouse as transfer_in stock entries. Partial receipts keep the transfer in transit; close records the remainder as a discrepancy.
+// @Tags         stock-transfers
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                           true  "Stock Transfer ID (UUID format)"
+// @Param        receipt  body      dto.StockTransferReceiveRequest  true  "Received quantities"
+// @Success      200      {object}  model.StockTransfer
+// @Failure      400      {object}  object{success=bool,error=string}  "Validation error or transfer not shipped"
+// @Failure      401      {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500      {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-transfers/{id}/receive [post]
+func (h *StockTransferHandler) Receive(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	var req dto.StockTransferReceiveRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	receipt := service.TransferReceipt{Close: req.Close}
+	for _, line := range req.Lines {
+		receipt.Lines = append(receipt.Lines, service.TransferReceiptLine{
+			LineID:           line.LineID,
+			Quantity:         line.Quantity,
+			Unit:             line.Unit,
+			DiscrepancyNotes: line.DiscrepancyNotes,
+		})
+	}
+
+	transfer, err := h.service.Receive(id, receipt, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *transfer)
+}
+
+// Cancel godoc
+// @Summary      Cancel a stock transfer
+// @Description  Cancels a transfer that has not been shipped yet and releases its reserved stock
+// @Tags         stock-transfers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Stock Transfer ID (UUID format)"
+// @Success      200  {object}  model.StockTransfer
+// @Failure      400  {object}  object{success=bool,error=string}  "Transfer not in draft"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/stock-transfers/{id}/cancel [post]
+func (h *StockTransferHandler) Cancel(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	transfer, err := h.service.Cancel(id, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *transfer)
+}
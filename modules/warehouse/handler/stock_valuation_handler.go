@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/labstack/echo/v4"
+)
+
+type StockValuationHandler struct {
+	service service.StockValuationService
+}
+
+func NewStockValuationHandler(service service.StockValuationService) *StockValuationHandler {
+	return &StockValuationHandler{service: service}
+}
+
+func (h *StockValuationHandler) RegisterRoutes(g *echo.Group) {
+	g.GET("/stock-reports/valuation", h.GetValuationReport)
+}
+
+// GetValuationReport godoc
+// @Summary      Get stock valuation
+// @Description  Values stock on hand as of a date per warehouse and per category, using the FIFO or moving average method configured on each office, and reports the cost of goods issued within the period. A request spanning exactly one closed month (from its first day, as of its last day) is served from the period snapshot.
+// @Tags         stock-reports
+// @Accept       json
+// @Produce      json
+// @Param        asOf      query     string  false  "Valuation date (YYYY-MM-DD or RFC 3339, default: now)"
+// @Param        from      query     string  false  "Start of the cost of goods issued period (YYYY-MM-DD or RFC 3339, default: beginning of the ledger)"
+// @Param        officeId  query     string  false  "Office ID (UUID format)"
+// @Success      200       {object}  service.ValuationReport
+// @Failure      400       {object}  object
+// @Failure      401       {object}  object
+// @Failure      500       {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/stock-reports/valuation [get]
+func (h *StockValuationHandler) GetValuationReport(c echo.Context) error {
+	asOf, err := parseOptionalDate(c.QueryParam("asOf"), true)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid asOf format",
+		})
+	}
+	from, err := parseOptionalDate(c.QueryParam("from"), false)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid from format",
+		})
+	}
+	officeID, err := parseOptionalUUID(c.QueryParam("officeId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid officeId format",
+		})
+	}
+
+	report, err := h.service.GetValuationReport(asOf, from, officeID)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *report)
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type SupplierHandler struct {
+	service service.SupplierService
+}
+
+func NewSupplierHandler(service service.SupplierService) *SupplierHandler {
+	return &SupplierHandler{service: service}
+}
+
+func (h *SupplierHandler) RegisterRoutes(g *echo.Group) {
+	sg := g.Group("/suppliers")
+	sg.GET("", h.GetAll)
+	sg.POST("", h.Create)
+	sg.GET("/:id", h.GetByID)
+	sg.PUT("/:id", h.Update)
+	sg.DELETE("/:id", h.Delete)
+}
+
+// GetAll godoc
+// @Summary      Get list of suppliers
+// @Description  Retrieves paginated suppliers with their contacts, optionally filtered by search term and status
+// @Tags         suppliers
+// @Accept       json
+// @Produce      json
+// @Param        page        query     int     false  "Page number (default: 1)"
+// @Param        pageSize    query     int     false  "Page size (default: 10)"
+// @Param        searchTerm  query     string  false  "Search by code, name or city"
+// @Param        status      query     string  false  "Supplier status (active, inactive)"
+// @Success      200         {object}  object
+// @Failure      401         {object}  object
+// @Failure      500         {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/suppliers [get]
+func (h *SupplierHandler) GetAll(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	suppliers, total, err := h.service.GetAll(page, pageSize, c.QueryParam("searchTerm"), c.QueryParam("status"))
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return contract.PaginatedSuccess(c, suppliers, total, page, pageSize)
+}
+
+// Create godoc
+// @Summary      Create a supplier
+// @Description  Creates a supplier with its contacts and payment terms
+// @Tags         suppliers
+// @Accept       json
+// @Produce      json
+// @Param        supplier  body      dto.SupplierRequest  true  "Supplier data"
+// @Success      201       {object}  model.Supplier
+// @Failure      400       {object}  object{success=bool,error=string}  "Validation error"
+// @Failure      401       {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500       {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/suppliers [post]
+func (h *SupplierHandler) Create(c echo.Context) error {
+	var req dto.SupplierRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	supplier := req.ToSupplier()
+	if err := h.service.Create(supplier); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.Supplier]{
+		Success: true,
+		Data:    *supplier,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get supplier by ID
+// @Description  Retrieve a supplier with its contacts
+// @Tags         suppliers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Supplier ID (UUID format)"
+// @Success      200  {object}  model.Supplier
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      404  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/suppliers/{id} [get]
+func (h *SupplierHandler) GetByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	supplier, err := h.service.GetByID(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
+			Success: false,
+			Error:   err.Error(),
+		})
+	}
+	if supplier == nil {
+		return c.JSON(http.StatusNotFound, contract.APIResponse[any]{
+			Success: false,
+			Error:   "supplier not found",
+		})
+	}
+	return contract.SingleSuccess(c, *supplier)
+}
+
+// Update godoc
+// @Summary      Update a supplier
+// @Description  Replaces the details and contacts of a supplier
+// @Tags         suppliers
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string               true  "Supplier ID (UUID format)"
+// @Param        supplier  body      dto.SupplierRequest  true  "Supplier data"
+// @Success      200       {object}  model.Supplier
+// @Failure      400       {object}  object{success=bool,error=string}  "Validation error"
+// @Failure      401       {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500       {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/suppliers/{id} [put]
+func (h *SupplierHandler) Update(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	var req dto.SupplierRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	supplier := req.ToSupplier()
+	if err := h.service.Update(id, supplier); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *supplier)
+}
+
+// Delete godoc
+// @Summary      Delete a supplier
+// @Description  Deletes a supplier together with its contacts and product catalog entries
+// @Tags         suppliers
+// @Accept       json
+// @Produce      json
+// @Param        id  path      string  true  "Supplier ID (UUID format)"
+// @Success      204 {string} string "No Content"
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/suppliers/{id} [delete]
+func (h *SupplierHandler) Delete(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	if err := h.service.Delete(id); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/dto"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type SupplierReturnHandler struct {
+	service service.SupplierReturnService
+}
+
+func NewSupplierReturnHandler(service service.SupplierReturnService) *SupplierReturnHandler {
+	return &SupplierReturnHandler{service: service}
+}
+
+func (h *SupplierReturnHandler) RegisterRoutes(g *echo.Group) {
+	rg := g.Group("/supplier-returns")
+	rg.GET("", h.GetAll)
+	rg.POST("", h.Create)
+	rg.GET("/:id", h.GetByID)
+	rg.POST("/:id/post", h.Post)
+	rg.POST("/:id/cancel", h.Cancel)
+}
+
+// GetAll godoc
+// @Summary      Get supplier returns
+// @Description  Retrieves paginated supplier returns, newest first, optionally filtered by warehouse and status
+// @Tags         returns
+// @Accept       json
+// @Produce      json
+// @Param        page         query     int     false  "Page number (default: 1)"
+// @Param        pageSize     query     int     false  "Page size (default: 10)"
+// @Param        warehouseId  query     string  false  "Warehouse ID (UUID format)"
+// @Param        status       query     string  false  "Return status (draft, posted, cancelled)"
+// @Success      200          {object}  object
+// @Failure      400          {object}  object
+// @Failure      401          {object}  object
+// @Failure      500          {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/supplier-returns [get]
+func (h *SupplierReturnHandler) GetAll(c echo.Context) error {
+	page, pageSize := paginationParams(c)
+
+	warehouseID, err := parseOptionalUUID(c.QueryParam("warehouseId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid warehouseId format",
+		})
+	}
+
+	returns, total, err := h.service.GetAll(page, pageSize, warehouseID, c.QueryParam("status"))
+	if err != nil {
+		return contract.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return contract.PaginatedSuccess(c, returns, total, page, pageSize)
+}
+
+// Create godoc
+// @Summary      Create a supplier return
+// @Description  Drafts a return against a goods receipt. Product, batch and cost of each line come from the referenced receipt line and no more than is left of the receipt can be returned.
+// @Tags         returns
+// @Accept       json
+// @Produce      json
+// @Param        return  body      dto.SupplierReturnRequest  true  "Return data"
+// @Success      201     {object}  model.SupplierReturn
+// @Failure      400     {object}  object{success=bool,error=string}  "Validation error"
+// @Failure      401     {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500     {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/supplier-returns [post]
+func (h *SupplierReturnHandler) Create(c echo.Context) error {
+	var req dto.SupplierReturnRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid request body",
+		})
+	}
+
+	ret := req.ToSupplierReturn()
+	ret.CreatedBy = currentUserID(c)
+	if err := h.service.Create(ret); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, contract.APIResponse[model.SupplierReturn]{
+		Success: true,
+		Data:    *ret,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get supplier return by ID
+// @Description  Retrieve a supplier return with its lines
+// @Tags         returns
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Supplier Return ID (UUID format)"
+// @Success      200  {object}  model.SupplierReturn
+// @Failure      400  {object}  object
+// @Failure      401  {object}  object
+// @Failure      404  {object}  object
+// @Failure      500  {object}  object
+// @Security     BearerAuth
+// @Router       /v1/api/supplier-returns/{id} [get]
+func (h *SupplierReturnHandler) GetByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	ret, err := h.service.GetByID(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
+			Success: false,
+			Error:   err.Error(),
+		})
+	}
+	if ret == nil {
+		return c.JSON(http.StatusNotFound, contract.APIResponse[any]{
+			Success: false,
+			Error:   "supplier return not found",
+		})
+	}
+	return contract.SingleSuccess(c, *ret)
+}
+
+// Post godoc
+// @Summary      Post a supplier return
+// @Description  Issues every line out of the receiving warehouse as return_out stock entries. The entries carry the purchase order in order_id and the goods receipt in reference_id. Expired, quarantined and rejected batches may be returned.
+// @Tags         returns
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Supplier Return ID (UUID format)"
+// @Success      200  {object}  model.SupplierReturn
+// @Failure      400  {object}  object{success=bool,error=string}  "Return not in draft, quantity over the receipt or insufficient stock"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/supplier-returns/{id}/post [post]
+func (h *SupplierReturnHandler) Post(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	ret, err := h.service.Post(id, currentUserID(c))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *ret)
+}
+
+// Cancel godoc
+// @Summary      Cancel a supplier return
+// @Description  Cancels a return that has not been posted. No stock is moved.
+// @Tags         returns
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Supplier Return ID (UUID format)"
+// @Success      200  {object}  model.SupplierReturn
+// @Failure      400  {object}  object{success=bool,error=string}  "Return already posted or cancelled"
+// @Failure      401  {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500  {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/supplier-returns/{id}/cancel [post]
+func (h *SupplierReturnHandler) Cancel(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid id format",
+		})
+	}
+
+	ret, err := h.service.Cancel(id)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *ret)
+}
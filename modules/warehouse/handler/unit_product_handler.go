@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
 	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
@@ -22,6 +23,7 @@ func (h *UnitProductHandler) RegisterRoutes(g *echo.Group) {
 	upg := g.Group("/unit-products")
 	upg.GET("", h.GetAll)
 	upg.POST("", h.Create)
+	upg.GET("/convert", h.Convert)
 	upg.GET("/:id", h.GetByID)
 	upg.PUT("/:id", h.Update)
 	upg.DELETE("/:id", h.Delete)
@@ -99,10 +101,7 @@ func (h *UnitProductHandler) Create(c echo.Context) error {
 	}
 
 	if err := h.service.Create(&unitProduct); err != nil {
-		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
-			Success: false,
-			Error:   err.Error(),
-		})
+		return serviceErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusCreated, contract.APIResponse[model.UnitProduct]{
@@ -111,6 +110,38 @@ func (h *UnitProductHandler) Create(c echo.Context) error {
 	})
 }
 
+// Convert godoc
+// @Summary      Convert a quantity between units
+// @Description  Converts a quantity between two units of the same dimension (mass, volume, length or count) using their standard factors. Units of different dimensions, or without a standard factor such as box, are rejected.
+// @Tags         unit-products
+// @Accept       json
+// @Produce      json
+// @Param        quantity  query     number  true  "Quantity to convert"
+// @Param        from      query     string  true  "Unit code to convert from, e.g. lb"
+// @Param        to        query     string  true  "Unit code to convert to, e.g. kg"
+// @Success      200       {object}  service.UnitConversion
+// @Failure      400       {object}  object{success=bool,error=string}  "Unknown or incompatible units"
+// @Failure      401       {object}  object{success=bool,error=string}  "Unauthorized"
+// @Failure      500       {object}  object{success=bool,error=string}  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/api/unit-products/convert [get]
+func (h *UnitProductHandler) Convert(c echo.Context) error {
+	quantity, err := strconv.ParseFloat(c.QueryParam("quantity"), 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
+			Success: false,
+			Error:   "invalid quantity value",
+		})
+	}
+
+	conversion, err := h.service.Convert(quantity, c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return contract.SingleSuccess(c, *conversion)
+}
+
 // GetByID godoc
 // @Summary      Get unit product by ID
 // @Description  Retrieve a specific unit product by its ID
@@ -186,10 +217,7 @@ func (h *UnitProductHandler) Update(c echo.Context) error {
 	}
 
 	if err := h.service.Update(id, &unitProduct); err != nil {
-		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
-			Success: false,
-			Error:   err.Error(),
-		})
+		return serviceErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, contract.APIResponse[model.UnitProduct]{
@@ -1,7 +1,14 @@
 package handler
 
 import (
+	"net/http"
 	"strconv"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/shared/auth"
+	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
 )
 
 // parsePositiveInt parses a string to a positive integer
@@ -15,3 +22,66 @@ func parsePositiveInt(s string) (int, error) {
 	}
 	return val, nil
 }
+
+// currentUserID returns the authenticated user ID set by the auth middleware
+func currentUserID(c echo.Context) uint {
+	userID, _ := c.Get(string(auth.ContextKeyUserID)).(uint)
+	return userID
+}
+
+// paginationParams reads page and pageSize query params, falling back to 1 and 10
+func paginationParams(c echo.Context) (int, int) {
+	page := 1
+	pageSize := 10
+	if p := c.QueryParam("page"); p != "" {
+		if parsedPage, err := parsePositiveInt(p); err == nil {
+			page = parsedPage
+		}
+	}
+	if ps := c.QueryParam("pageSize"); ps != "" {
+		if parsedPageSize, err := parsePositiveInt(ps); err == nil {
+			pageSize = parsedPageSize
+		}
+	}
+	return page, pageSize
+}
+
+// parseOptionalUUID parses a UUID query value, treating an empty string as uuid.Nil
+func parseOptionalUUID(s string) (uuid.UUID, error) {
+	if s == "" {
+		return uuid.Nil, nil
+	}
+	return uuid.Parse(s)
+}
+
+// parseOptionalDate parses a date (2006-01-02) or RFC 3339 timestamp query value,
+// treating an empty string as the zero time. With endOfDay a plain date is
+// moved to its last instant so the whole day is included.
+func parseOptionalDate(s string, endOfDay bool) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return t, nil
+}
+
+// serviceErrorResponse maps validation errors to 400 and anything else to 500
+func serviceErrorResponse(c echo.Context, err error) error {
+	status := http.StatusInternalServerError
+	if isValidationError(err) {
+		status = http.StatusBadRequest
+	}
+	return c.JSON(status, contract.APIResponse[any]{
+		Success: false,
+		Error:   err.Error(),
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
 	"github.com/antoniusDoni/monorepo/modules/warehouse/service"
 	"github.com/antoniusDoni/monorepo/shared/contract"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
@@ -95,10 +96,7 @@ func (h *WarehouseHandler) Create(c echo.Context) error {
 	}
 
 	if err := h.service.Create(&warehouse); err != nil {
-		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
-			Success: false,
-			Error:   err.Error(),
-		})
+		return serviceErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusCreated, contract.APIResponse[model.Warehouse]{
@@ -113,7 +111,7 @@ func (h *WarehouseHandler) Create(c echo.Context) error {
 // @Tags         warehouses
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "Warehouse ID"
+// @Param        id   path      string  true  "Warehouse ID (UUID format)"
 // @Success      200  {object}  model.Warehouse
 // @Failure      400  {object}  object
 // @Failure      401  {object}  object
@@ -122,15 +120,15 @@ func (h *WarehouseHandler) Create(c echo.Context) error {
 // @Security     BearerAuth
 // @Router       /v1/api/warehouses/{id} [get]
 func (h *WarehouseHandler) GetByID(c echo.Context) error {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil || id <= 0 {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
 		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
 			Success: false,
-			Error:   "invalid id",
+			Error:   "invalid id format",
 		})
 	}
 
-	warehouse, err := h.service.GetByID(uint(id))
+	warehouse, err := h.service.GetByID(id)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
 			Success: false,
@@ -155,7 +153,7 @@ func (h *WarehouseHandler) GetByID(c echo.Context) error {
 // @Tags         warehouses
 // @Accept       json
 // @Produce      json
-// @Param        id         path      int              true  "Warehouse ID"
+// @Param        id         path      string           true  "Warehouse ID (UUID format)"
 // @Param        warehouse  body      model.Warehouse  true  "Updated warehouse data"
 // @Success      200        {object}  model.Warehouse
 // @Failure      400        {object}  object
@@ -164,11 +162,11 @@ func (h *WarehouseHandler) GetByID(c echo.Context) error {
 // @Security     BearerAuth
 // @Router       /v1/api/warehouses/{id} [put]
 func (h *WarehouseHandler) Update(c echo.Context) error {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil || id <= 0 {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
 		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
 			Success: false,
-			Error:   "invalid id",
+			Error:   "invalid id format",
 		})
 	}
 
@@ -180,11 +178,8 @@ func (h *WarehouseHandler) Update(c echo.Context) error {
 		})
 	}
 
-	if err := h.service.Update(uint(id), &warehouse); err != nil {
-		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
-			Success: false,
-			Error:   err.Error(),
-		})
+	if err := h.service.Update(id, &warehouse); err != nil {
+		return serviceErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, contract.APIResponse[model.Warehouse]{
@@ -199,7 +194,7 @@ func (h *WarehouseHandler) Update(c echo.Context) error {
 // @Tags         warehouses
 // @Accept       json
 // @Produce      json
-// @Param        id  path      int  true  "Warehouse ID"
+// @Param        id  path      string  true  "Warehouse ID (UUID format)"
 // @Success      204 {object}  object
 // @Failure      400 {object}  object
 // @Failure      401 {object}  object
@@ -207,15 +202,15 @@ func (h *WarehouseHandler) Update(c echo.Context) error {
 // @Security     BearerAuth
 // @Router       /v1/api/warehouses/{id} [delete]
 func (h *WarehouseHandler) Delete(c echo.Context) error {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil || id <= 0 {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
 		return c.JSON(http.StatusBadRequest, contract.APIResponse[any]{
 			Success: false,
-			Error:   "invalid id",
+			Error:   "invalid id format",
 		})
 	}
 
-	if err := h.service.Delete(uint(id)); err != nil {
+	if err := h.service.Delete(id); err != nil {
 		return c.JSON(http.StatusInternalServerError, contract.APIResponse[any]{
 			Success: false,
 			Error:   err.Error(),
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Directions an adjustment reason may move stock in
+const (
+	AdjustmentDirectionIncrease = "increase"
+	AdjustmentDirectionDecrease = "decrease"
+	AdjustmentDirectionBoth     = "both"
+)
+
+// Adjustment reason statuses
+const (
+	AdjustmentReasonStatusActive   = "active"
+	AdjustmentReasonStatusInactive = "inactive"
+)
+
+// AdjustmentReason is a configurable reason code (damage, loss, sampling, ...)
+// that every stock adjustment line must carry
+type AdjustmentReason struct {
+	ID               uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Code             string    `gorm:"unique;not null" json:"code"`
+	Name             string    `gorm:"not null" json:"name"`
+	Description      string    `json:"description"`
+	Direction        string    `gorm:"not null;default:both" json:"direction"`
+	RequiresApproval bool      `json:"requires_approval"` // Always route adjustments with this reason to approval, whatever their value
+	Status           string    `gorm:"not null;default:active" json:"status"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// Allows reports whether the reason may be used for a signed quantity
+func (r *AdjustmentReason) Allows(quantity int) bool {
+	switch r.Direction {
+	case AdjustmentDirectionIncrease:
+		return quantity > 0
+	case AdjustmentDirectionDecrease:
+		return quantity < 0
+	}
+	return true
+}
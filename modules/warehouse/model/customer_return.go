@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Dispositions of returned customer stock
+const (
+	ReturnDispositionSellable   = "sellable"
+	ReturnDispositionQuarantine = "quarantine"
+)
+
+// CustomerReturn takes stock back from a customer into a chosen warehouse.
+// OriginID is the document the stock was issued with; the return_in stock
+// entries carry it in ReferenceID so the batch history stays traceable.
+type CustomerReturn struct {
+	ID           uuid.UUID            `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Number       string               `gorm:"unique;not null" json:"number"`
+	OriginID     uuid.UUID            `gorm:"type:uuid;not null;index" json:"origin_id"`
+	WarehouseID  uuid.UUID            `gorm:"type:uuid;not null;index" json:"warehouse_id"`
+	CustomerName string               `json:"customer_name"`
+	Status       string               `gorm:"not null" json:"status"`
+	Date         time.Time            `json:"date"`
+	Reason       string               `json:"reason"`
+	Notes        string               `json:"notes"`
+	PostedAt     *time.Time           `json:"posted_at,omitempty"`
+	CreatedBy    uint                 `json:"created_by"`
+	PostedBy     uint                 `json:"posted_by"`
+	Lines        []CustomerReturnLine `gorm:"foreignKey:ReturnID;constraint:OnDelete:CASCADE" json:"lines"`
+	CreatedAt    time.Time            `json:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+}
+
+// CustomerReturnLine is the returned quantity of one issued batch, in small units
+type CustomerReturnLine struct {
+	ID                 uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ReturnID           uuid.UUID `gorm:"type:uuid;not null;index" json:"return_id"`
+	ProductID          uuid.UUID `gorm:"type:uuid;not null" json:"product_id"`
+	BatchNumber        string    `json:"batch_number"`
+	ExpiredAt          time.Time `json:"expired_at"`
+	Quantity           int       `json:"quantity"`
+	Unit               string    `gorm:"-" json:"-"`          // Unit the quantity was given in, converted to small units before it is stored
+	ConversionResidual float64   `json:"conversion_residual"` // Quantity given less quantity stored, in small units, when a standard unit conversion was rounded
+	Price              float64   `json:"price"`               // Unit cost of the original issue
+	Disposition        string    `json:"disposition"`         // sellable or quarantine
+	Notes              string    `json:"notes"`
+	StockEntryID       uuid.UUID `gorm:"type:uuid" json:"stock_entry_id"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GoodsReceipt records one delivery against a purchase order. Its stock
+// entries carry the order in OrderID and the receipt in ReferenceID.
+type GoodsReceipt struct {
+	ID          uuid.UUID          `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Number      string             `gorm:"unique;not null" json:"number"`
+	OrderID     uuid.UUID          `gorm:"type:uuid;not null;index" json:"order_id"`
+	WarehouseID uuid.UUID          `gorm:"type:uuid;not null" json:"warehouse_id"`
+	Date        time.Time          `json:"date"`
+	Notes       string             `json:"notes"`
+	ReceivedBy  uint               `json:"received_by"`
+	Lines       []GoodsReceiptLine `gorm:"foreignKey:ReceiptID;constraint:OnDelete:CASCADE" json:"lines"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// GoodsReceiptLine is one received batch of an order line, in small units
+type GoodsReceiptLine struct {
+	ID                 uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ReceiptID          uuid.UUID `gorm:"type:uuid;not null;index" json:"receipt_id"`
+	OrderLineID        uuid.UUID `gorm:"type:uuid;not null" json:"order_line_id"`
+	ProductID          uuid.UUID `gorm:"type:uuid;not null" json:"product_id"`
+	BatchNumber        string    `json:"batch_number"`
+	ExpiredAt          time.Time `json:"expired_at"`
+	Quantity           int       `json:"quantity"`
+	Unit               string    `gorm:"-" json:"-"`          // Unit the quantity was given in, converted to small units before it is stored
+	ConversionResidual float64   `json:"conversion_residual"` // Quantity given less quantity stored, in small units, when a standard unit conversion was rounded
+	Price              float64   `json:"price"`               // Unit cost per small unit
+	ReturnedQuantity   int       `json:"returned_quantity"`   // Sent back to the supplier through supplier returns
+	LandedCost         float64   `json:"landed_cost"`         // Per small unit, from posted landed cost documents
+	QCStatus           string    `json:"qc_status"`           // QC status the batch was received in
+	StockEntryID       uuid.UUID `gorm:"type:uuid" json:"stock_entry_id"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
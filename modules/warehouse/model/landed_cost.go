@@ -0,0 +1,87 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Landed cost statuses
+const (
+	LandedCostStatusDraft     = "draft"
+	LandedCostStatusPosted    = "posted"
+	LandedCostStatusReversed  = "reversed"
+	LandedCostStatusCancelled = "cancelled"
+)
+
+// Landed cost allocation methods decide how the charges are split over the receipt lines
+const (
+	LandedCostByValue    = "value"    // in proportion to quantity × purchase price
+	LandedCostByQuantity = "quantity" // in proportion to quantity received
+	LandedCostByWeight   = "weight"   // in proportion to the weight given per line
+)
+
+// Landed cost charge types
+const (
+	LandedCostChargeFreight   = "freight"
+	LandedCostChargeInsurance = "insurance"
+	LandedCostChargeDuty      = "duty"
+	LandedCostChargeOther     = "other"
+)
+
+// LandedCost allocates charges such as freight, insurance and import duties
+// over the lines of a goods receipt. Once posted, the allocated amount of each
+// line raises the unit cost of its receipt stock entry in valuation from the
+// landed cost date on. Reversing a landed cost removes it again from the
+// moment it was reversed, so earlier reports stay reproducible.
+type LandedCost struct {
+	ID             uuid.UUID          `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Number         string             `gorm:"unique;not null" json:"number"`
+	ReceiptID      uuid.UUID          `gorm:"type:uuid;not null;index" json:"receipt_id"`
+	OrderID        uuid.UUID          `gorm:"type:uuid;not null" json:"order_id"`
+	WarehouseID    uuid.UUID          `gorm:"type:uuid;not null;index" json:"warehouse_id"`
+	Method         string             `gorm:"not null" json:"method"`
+	Status         string             `gorm:"not null" json:"status"`
+	Date           time.Time          `json:"date"`
+	Reference      string             `json:"reference"` // Invoice number of the forwarder, insurer or customs
+	Notes          string             `json:"notes"`
+	TotalAmount    float64            `json:"total_amount"`
+	PostedAt       *time.Time         `json:"posted_at,omitempty"`
+	ReversedAt     *time.Time         `json:"reversed_at,omitempty"`
+	ReversalReason string             `json:"reversal_reason"`
+	CreatedBy      uint               `json:"created_by"`
+	PostedBy       uint               `json:"posted_by"`
+	ReversedBy     uint               `json:"reversed_by"`
+	Charges        []LandedCostCharge `gorm:"foreignKey:LandedCostID;constraint:OnDelete:CASCADE" json:"charges"`
+	Lines          []LandedCostLine   `gorm:"foreignKey:LandedCostID;constraint:OnDelete:CASCADE" json:"lines"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+// LandedCostCharge is one extra charge to allocate
+type LandedCostCharge struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	LandedCostID uuid.UUID `gorm:"type:uuid;not null;index" json:"landed_cost_id"`
+	Type         string    `gorm:"not null" json:"type"`
+	Description  string    `json:"description"`
+	Amount       float64   `json:"amount"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// LandedCostLine is the share of the charges allocated to one receipt line
+type LandedCostLine struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	LandedCostID  uuid.UUID `gorm:"type:uuid;not null;index" json:"landed_cost_id"`
+	ReceiptLineID uuid.UUID `gorm:"type:uuid;not null" json:"receipt_line_id"`
+	StockEntryID  uuid.UUID `gorm:"type:uuid;index" json:"stock_entry_id"` // Receipt movement whose cost is raised
+	ProductID     uuid.UUID `gorm:"type:uuid;not null" json:"product_id"`
+	BatchNumber   string    `json:"batch_number"`
+	Quantity      int       `json:"quantity"`  // Quantity still held in small units, received less returned to the supplier
+	Price         float64   `json:"price"`     // Purchase price per small unit of the receipt line
+	Weight        float64   `json:"weight"`    // Total weight of the line, used by the weight method
+	Amount        float64   `json:"amount"`    // Allocated share of the charges
+	UnitCost      float64   `json:"unit_cost"` // Amount per small unit added to the purchase price
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
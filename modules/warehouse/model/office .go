@@ -6,6 +6,12 @@ import (
 	"github.com/google/uuid"
 )
 
+// Valuation methods decide how the cost of issued and remaining stock is computed
+const (
+	ValuationMethodAverage = "average" // weighted moving average cost
+	ValuationMethodFIFO    = "fifo"    // first in, first out cost layers
+)
+
 type Office struct {
 	ID      uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
 	Code    string    `gorm:"unique;not null" json:"code"`
@@ -15,6 +21,8 @@ type Office struct {
 	Phone   string    `json:"phone"`
 	Status  string    `json:"status"` // e.g., active, inactive
 
+	ValuationMethod string `gorm:"not null;default:average" json:"valuation_method"` // average or fifo
+
 	Branches []Branch `gorm:"foreignKey:OfficeID" json:"branches,omitempty"`
 
 	CreatedAt time.Time `json:"created_at"`
@@ -6,18 +6,29 @@ import (
 	"github.com/google/uuid"
 )
 
+// Controlled substance classes reported to the regulator
+const (
+	ControlledClassNarcotic     = "narcotic"
+	ControlledClassPsychotropic = "psychotropic"
+	ControlledClassPrecursor    = "precursor"
+)
+
 type Product struct {
-	ID                  uuid.UUID       `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"` // Unique identifier
-	Code                string          `json:"code"`                                                      // Product code or SKU
-	Name                string          `json:"name"`                                                      // Product name
-	LargeUnit           string          `json:"large_unit"`                                                // e.g., box, pack
-	ContentPerLargeUnit int             `json:"content_per_large_unit"`                                    // e.g., 12 pieces per box
-	SmallUnit           string          `json:"small_unit"`                                                // e.g., piece, tablet
-	PurchasePrice       float64         `json:"purchase_price"`                                            // Cost price
-	SellingPrice        float64         `json:"selling_price"`                                             // Sale price
-	CategoryID          uuid.UUID       `gorm:"type:uuid" json:"category_id"`                              // Foreign key
-	Category            CategoryProduct `gorm:"foreignKey:CategoryID" json:"category"`
-	Indication          string          `json:"indication"` // Description or usage
-	CreatedAt           time.Time       `json:"created_at"` // Timestamp when created
-	UpdatedAt           time.Time       `json:"updated_at"` // Timestamp when updated
+	ID                  uuid.UUID        `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"` // Unique identifier
+	Code                string           `json:"code"`                                                      // Product code or SKU
+	Name                string           `json:"name"`                                                      // Product name
+	LargeUnit           string           `json:"large_unit"`                                                // e.g., box, pack
+	ContentPerLargeUnit int              `json:"content_per_large_unit"`                                    // e.g., 12 pieces per box
+	SmallUnit           string           `json:"small_unit"`                                                // e.g., piece, tablet
+	PurchasePrice       float64          `json:"purchase_price"`                                            // Cost price
+	SellingPrice        float64          `json:"selling_price"`                                             // Sale price
+	CategoryID          uuid.UUID        `gorm:"type:uuid" json:"category_id"`                              // Foreign key
+	Category            CategoryProduct  `gorm:"foreignKey:CategoryID" json:"category"`
+	Indication          string           `json:"indication"`                                                       // Description or usage
+	Controlled          bool             `json:"controlled"`                                                       // Movements need an authorizing user and a counterparty
+	ControlledClass     string           `json:"controlled_class"`                                                 // narcotic, psychotropic or precursor when controlled
+	Units               []ProductUnit    `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"units"`    // Packaging hierarchy, base unit first
+	Barcodes            []ProductBarcode `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"barcodes"` // Barcodes printed on the packaging units
+	CreatedAt           time.Time        `json:"created_at"`                                                       // Timestamp when created
+	UpdatedAt           time.Time        `json:"updated_at"`                                                       // Timestamp when updated
 }
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductBarcode is a GTIN printed on one packaging unit of a product. A product
+// can carry several, for example one on the strip and another on the box.
+type ProductBarcode struct {
+	ID        uuid.UUID    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ProductID uuid.UUID    `gorm:"type:uuid;not null;index" json:"product_id"`
+	Code      string       `gorm:"size:14;not null;uniqueIndex" json:"code"` // EAN-13, EAN-8 or UPC-A
+	UnitID    uuid.UUID    `gorm:"type:uuid;not null" json:"unit_id"`        // Packaging unit the barcode is printed on
+	Unit      *UnitProduct `gorm:"foreignKey:UnitID" json:"unit,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductSupplier links a product to a supplier that sells it
+type ProductSupplier struct {
+	ID                uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ProductID         uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_product_supplier" json:"product_id"`
+	SupplierID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_product_supplier" json:"supplier_id"`
+	Supplier          *Supplier  `gorm:"foreignKey:SupplierID;constraint:OnDelete:CASCADE" json:"supplier,omitempty"`
+	SupplierSKU       string     `json:"supplier_sku"`
+	LeadTimeDays      int        `json:"lead_time_days"`
+	MinOrderQuantity  int        `json:"min_order_quantity"`  // In small units
+	LastPurchasePrice float64    `json:"last_purchase_price"` // Per small unit, updated on goods receipt
+	LastPurchasedAt   *time.Time `json:"last_purchased_at,omitempty"`
+	IsPreferred       bool       `json:"is_preferred"` // Vendor picked for reorder suggestions
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
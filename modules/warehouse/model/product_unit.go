@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductUnit is one level of a product's packaging hierarchy, for example
+// pcs → strip → box → carton. Level 0 is the base unit stock is kept in; every
+// other level holds Content units of the level below it.
+type ProductUnit struct {
+	ID        uuid.UUID    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ProductID uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_product_unit" json:"product_id"`
+	UnitID    uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_product_unit" json:"unit_id"`
+	Unit      *UnitProduct `gorm:"foreignKey:UnitID" json:"unit,omitempty"`
+	Level     int          `gorm:"not null" json:"level"`
+	Content   int          `gorm:"not null" json:"content"` // Units of the level below in one of this unit, 1 for the base unit
+	Factor    int          `gorm:"not null" json:"factor"`  // Base units in one of this unit
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
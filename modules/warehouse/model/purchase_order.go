@@ -0,0 +1,71 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Purchase order statuses
+const (
+	PurchaseOrderStatusDraft             = "draft"
+	PurchaseOrderStatusApproved          = "approved"
+	PurchaseOrderStatusPartiallyReceived = "partially_received"
+	PurchaseOrderStatusReceived          = "received"
+	PurchaseOrderStatusClosed            = "closed"
+)
+
+// PurchaseOrder is an order placed with a supplier for delivery into one warehouse
+type PurchaseOrder struct {
+	ID           uuid.UUID           `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Number       string              `gorm:"unique;not null" json:"number"`
+	WarehouseID  uuid.UUID           `gorm:"type:uuid;not null;index" json:"warehouse_id"` // Receiving warehouse
+	SupplierID   *uuid.UUID          `gorm:"type:uuid;index" json:"supplier_id"`
+	SupplierName string              `json:"supplier_name"` // Copied from the supplier when one is set
+	Status       string              `gorm:"not null;index" json:"status"`
+	OrderDate    time.Time           `json:"order_date"`
+	ExpectedDate *time.Time          `json:"expected_date,omitempty"`
+	Notes        string              `json:"notes"`
+	ApprovedAt   *time.Time          `json:"approved_at,omitempty"`
+	ClosedAt     *time.Time          `json:"closed_at,omitempty"`
+	CreatedBy    uint                `json:"created_by"`
+	ApprovedBy   uint                `json:"approved_by"`
+	ClosedBy     uint                `json:"closed_by"`
+	Lines        []PurchaseOrderLine `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"lines"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+}
+
+// PurchaseOrderLine is an ordered product. Quantity and Price are in the ordered
+// unit; ReceivedQuantity is in small units like the stock ledger.
+type PurchaseOrderLine struct {
+	ID               uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	OrderID          uuid.UUID `gorm:"type:uuid;not null;index" json:"order_id"`
+	ProductID        uuid.UUID `gorm:"type:uuid;not null" json:"product_id"`
+	Unit             string    `json:"unit"`         // Ordered unit, any unit of the product
+	UnitContent      int       `json:"unit_content"` // Small units per ordered unit
+	Quantity         int       `json:"quantity"`
+	Price            float64   `json:"price"` // Price per ordered unit
+	ReceivedQuantity int       `json:"received_quantity"`
+	Notes            string    `json:"notes"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// OrderedQuantity is the ordered quantity in small units
+func (l *PurchaseOrderLine) OrderedQuantity() int {
+	return l.Quantity * l.UnitContent
+}
+
+// UnitPrice is the ordered price per small unit
+func (l *PurchaseOrderLine) UnitPrice() float64 {
+	if l.UnitContent == 0 {
+		return l.Price
+	}
+	return l.Price / float64(l.UnitContent)
+}
+
+// Outstanding is the quantity in small units still to be received
+func (l *PurchaseOrderLine) Outstanding() int {
+	return l.OrderedQuantity() - l.ReceivedQuantity
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Recall statuses
+const (
+	RecallStatusOpen   = "open"
+	RecallStatusClosed = "closed"
+)
+
+// Recall task actions and statuses
+const (
+	RecallActionReturn  = "return"  // send the stock back to the supplier
+	RecallActionDispose = "dispose" // write the stock off for destruction
+
+	RecallTaskStatusOpen = "open"
+	RecallTaskStatusDone = "done"
+)
+
+// Recall withdraws one batch of a product from use. Opening it rejects the
+// batch in every warehouse holding it, so it can only leave through a return
+// or disposal, and creates a task for each of those warehouses. Stock of the
+// batch arriving while the recall is open comes in rejected as well, with a
+// new task for its warehouse when that has none open.
+type Recall struct {
+	ID          uuid.UUID    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Number      string       `gorm:"unique;not null" json:"number"`
+	ProductID   uuid.UUID    `gorm:"type:uuid;not null;index:idx_recall_batch;uniqueIndex:idx_recall_open_batch,where:status = 'open'" json:"product_id"`
+	BatchNumber string       `gorm:"not null;index:idx_recall_batch;uniqueIndex:idx_recall_open_batch,where:status = 'open'" json:"batch_number"` // A batch has at most one open recall
+	SupplierID  *uuid.UUID   `gorm:"type:uuid" json:"supplier_id"`                                                                                // Supplier that issued the recall
+	Reference   string       `json:"reference"`                                                                                                   // Recall notice number of the supplier or regulator
+	Reason      string       `gorm:"not null" json:"reason"`
+	Action      string       `gorm:"not null" json:"action"` // Default action of the tasks: return or dispose
+	Status      string       `gorm:"not null" json:"status"`
+	Notes       string       `json:"notes"`
+	OpenedAt    time.Time    `json:"opened_at"`
+	OpenedBy    uint         `json:"opened_by"`
+	ClosedAt    *time.Time   `json:"closed_at,omitempty"`
+	ClosedBy    uint         `json:"closed_by"`
+	Tasks       []RecallTask `gorm:"foreignKey:RecallID;constraint:OnDelete:CASCADE" json:"tasks"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// RecallTask is the return or disposal of the recalled batch in one warehouse
+type RecallTask struct {
+	ID           uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	RecallID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"recall_id"`
+	WarehouseID  uuid.UUID  `gorm:"type:uuid;not null" json:"warehouse_id"`
+	Action       string     `gorm:"not null" json:"action"`
+	Status       string     `gorm:"not null" json:"status"`
+	Quantity     int        `json:"quantity"`                        // On hand when the task was created, in small units
+	DoneQuantity int        `json:"done_quantity"`                   // Returned or disposed when the task was completed
+	StockEntryID *uuid.UUID `gorm:"type:uuid" json:"stock_entry_id"` // Movement that removed the stock, nil when it had already left
+	AuthorizedBy uint       `json:"authorized_by"`                   // Authorizing user of the movement, required for controlled products
+	Counterparty string     `json:"counterparty"`                    // Supplier or disposal party, required for controlled products
+	Notes        string     `json:"notes"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	CompletedBy  uint       `json:"completed_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
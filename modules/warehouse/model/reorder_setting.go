@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReorderSetting holds the replenishment levels of a product in a warehouse, in small units
+type ReorderSetting struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	WarehouseID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_reorder_setting_position" json:"warehouse_id"`
+	ProductID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_reorder_setting_position" json:"product_id"`
+	Product      *Product  `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	MinStock     int       `json:"min_stock"`     // Safety stock
+	ReorderPoint int       `json:"reorder_point"` // Order when on-hand stock falls to this level
+	MaxStock     int       `json:"max_stock"`     // Order up to this level
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stock adjustment statuses
+const (
+	StockAdjustmentStatusDraft           = "draft"
+	StockAdjustmentStatusPendingApproval = "pending_approval"
+	StockAdjustmentStatusApproved        = "approved"
+	StockAdjustmentStatusRejected        = "rejected"
+	StockAdjustmentStatusCancelled       = "cancelled"
+)
+
+// StockAdjustment is a document of stock corrections in one warehouse. Its lines
+// post adjustment stock entries only once the document is approved.
+type StockAdjustment struct {
+	ID               uuid.UUID                   `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Number           string                      `gorm:"unique;not null" json:"number"`
+	WarehouseID      uuid.UUID                   `gorm:"type:uuid;not null;index" json:"warehouse_id"`
+	Status           string                      `gorm:"not null" json:"status"`
+	Date             time.Time                   `json:"date"`
+	Notes            string                      `json:"notes"`
+	AuthorizedBy     uint                        `json:"authorized_by"`     // Authorizing user of controlled product lines, the approver when left empty
+	Counterparty     string                      `json:"counterparty"`      // Where controlled product lines came from or went to
+	TotalValue       float64                     `json:"total_value"`       // Sum of line values, increases and decreases alike
+	RequiresApproval bool                        `json:"requires_approval"` // Set on submit from the value threshold and reason codes
+	SubmittedAt      *time.Time                  `json:"submitted_at,omitempty"`
+	ApprovedAt       *time.Time                  `json:"approved_at,omitempty"`
+	RejectedAt       *time.Time                  `json:"rejected_at,omitempty"`
+	RejectionNotes   string                      `json:"rejection_notes"`
+	CreatedBy        uint                        `json:"created_by"`
+	SubmittedBy      uint                        `json:"submitted_by"`
+	ApprovedBy       uint                        `json:"approved_by"`
+	RejectedBy       uint                        `json:"rejected_by"`
+	Lines            []StockAdjustmentLine       `gorm:"foreignKey:AdjustmentID;constraint:OnDelete:CASCADE" json:"lines"`
+	Attachments      []StockAdjustmentAttachment `gorm:"foreignKey:AdjustmentID;constraint:OnDelete:CASCADE" json:"attachments"`
+	CreatedAt        time.Time                   `json:"created_at"`
+	UpdatedAt        time.Time                   `json:"updated_at"`
+}
+
+// StockAdjustmentLine is one signed correction of a product/batch
+type StockAdjustmentLine struct {
+	ID                 uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	AdjustmentID       uuid.UUID `gorm:"type:uuid;not null;index" json:"adjustment_id"`
+	ProductID          uuid.UUID `gorm:"type:uuid;not null" json:"product_id"`
+	BatchNumber        string    `json:"batch_number"`
+	ExpiredAt          time.Time `json:"expired_at"` // Expiry for batches created by an increase
+	ReasonID           uuid.UUID `gorm:"type:uuid;not null" json:"reason_id"`
+	ReasonCode         string    `json:"reason_code"`
+	Quantity           int       `json:"quantity"`            // Signed, negative reduces stock
+	Unit               string    `gorm:"-" json:"-"`          // Unit the quantity was given in, converted to small units before it is stored
+	ConversionResidual float64   `json:"conversion_residual"` // Quantity given less quantity stored, in small units, when a standard unit conversion was rounded
+	Price              float64   `json:"price"`               // Unit cost; decreases take the batch cost on submit
+	Value              float64   `json:"value"`               // Absolute quantity times price
+	Notes              string    `json:"notes"`
+	StockEntryID       uuid.UUID `gorm:"type:uuid" json:"stock_entry_id"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// StockAdjustmentAttachment references supporting evidence (photos, reports)
+// stored outside the database
+type StockAdjustmentAttachment struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	AdjustmentID uuid.UUID `gorm:"type:uuid;not null;index" json:"adjustment_id"`
+	FileName     string    `gorm:"not null" json:"file_name"`
+	URL          string    `gorm:"not null" json:"url"`
+	ContentType  string    `json:"content_type"`
+	UploadedBy   uint      `json:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
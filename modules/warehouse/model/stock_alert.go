@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stock alert types
+const (
+	StockAlertTypeNearExpiry = "near_expiry"
+	StockAlertTypeExpired    = "expired"
+)
+
+// StockAlert is raised by the daily stock scan for a warehouse/product/batch.
+// At most one alert of each type is raised per batch per day.
+type StockAlert struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Type           string     `gorm:"not null;uniqueIndex:idx_stock_alert_daily" json:"type"`
+	AlertDate      time.Time  `gorm:"type:date;not null;uniqueIndex:idx_stock_alert_daily" json:"alert_date"`
+	WarehouseID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_stock_alert_daily" json:"warehouse_id"`
+	ProductID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_stock_alert_daily" json:"product_id"`
+	BatchNumber    string     `gorm:"uniqueIndex:idx_stock_alert_daily" json:"batch_number"`
+	ExpiredAt      time.Time  `json:"expired_at"`
+	Quantity       int        `json:"quantity"`
+	Message        string     `json:"message"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy uint       `json:"acknowledged_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StockBalance is the on-hand projection of the stock ledger for one
+// warehouse/product/batch. It is maintained in the same transaction as every
+// StockEntry and can be rebuilt from the ledger at any time.
+type StockBalance struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	WarehouseID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_stock_balance_position" json:"warehouse_id"`
+	Warehouse   *Warehouse `gorm:"foreignKey:WarehouseID" json:"warehouse,omitempty"`
+	ProductID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_stock_balance_position" json:"product_id"`
+	Product     *Product   `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	BatchNumber string     `gorm:"not null;default:'';uniqueIndex:idx_stock_balance_position" json:"batch_number"`
+	ExpiredAt   time.Time  `json:"expired_at"`
+	ReceivedAt  time.Time  `json:"received_at"`                                // Date of the first movement into the batch, used for FIFO
+	Quantity    int        `json:"quantity"`                                   // On-hand quantity in small units
+	QCStatus    string     `gorm:"not null;default:released" json:"qc_status"` // Copied from the last ledger row applied
+	LastEntryID uuid.UUID  `gorm:"type:uuid" json:"last_entry_id"`             // Last ledger row applied
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
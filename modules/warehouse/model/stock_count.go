@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stock count statuses
+const (
+	StockCountStatusCounting  = "counting"
+	StockCountStatusSubmitted = "submitted"
+	StockCountStatusApproved  = "approved"
+	StockCountStatusCancelled = "cancelled"
+)
+
+// StockCount is a physical count (stock opname) of one warehouse. Expected
+// quantities are frozen from the stock balances when the session is opened.
+type StockCount struct {
+	ID          uuid.UUID        `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Number      string           `gorm:"unique;not null" json:"number"`
+	WarehouseID uuid.UUID        `gorm:"type:uuid;not null;index" json:"warehouse_id"`
+	Status      string           `gorm:"not null" json:"status"`
+	Blind       bool             `json:"blind"` // Hide expected quantities from counters until the count is submitted
+	Notes       string           `json:"notes"`
+	SnapshotAt  time.Time        `json:"snapshot_at"`
+	SubmittedAt *time.Time       `json:"submitted_at,omitempty"`
+	ApprovedAt  *time.Time       `json:"approved_at,omitempty"`
+	CreatedBy   uint             `json:"created_by"`
+	SubmittedBy uint             `json:"submitted_by"`
+	ApprovedBy  uint             `json:"approved_by"`
+	Lines       []StockCountLine `gorm:"foreignKey:CountID;constraint:OnDelete:CASCADE" json:"lines"`
+	// ExpectedHidden is set on responses where expected quantities were masked for a blind count
+	ExpectedHidden bool      `gorm:"-" json:"expected_hidden"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// StockCountLine is the expected and counted quantity of one product/batch
+type StockCountLine struct {
+	ID                 uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CountID            uuid.UUID `gorm:"type:uuid;not null;index" json:"count_id"`
+	ProductID          uuid.UUID `gorm:"type:uuid;not null" json:"product_id"`
+	BatchNumber        string    `json:"batch_number"`
+	ExpiredAt          time.Time `json:"expired_at"`
+	ExpectedQuantity   int       `json:"expected_quantity"`
+	CountedQuantity    *int      `json:"counted_quantity"`    // Nil until counted
+	ConversionResidual float64   `json:"conversion_residual"` // Quantity counted less quantity stored, in small units, when a standard unit conversion was rounded
+	VarianceQuantity   int       `json:"variance_quantity"`
+	CountedBy          uint      `json:"counted_by"`
+	Notes              string    `json:"notes"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// MaskExpected hides expected quantities and variances while a blind count is still being counted
+func (c *StockCount) MaskExpected() {
+	if !c.Blind || c.Status != StockCountStatusCounting {
+		return
+	}
+	for i := range c.Lines {
+		c.Lines[i].ExpectedQuantity = 0
+		c.Lines[i].VarianceQuantity = 0
+	}
+	c.ExpectedHidden = true
+}
@@ -6,22 +6,59 @@ import (
 	"github.com/google/uuid"
 )
 
+// Stock entry statuses describe the kind of movement recorded in the ledger
+const (
+	StockEntryStatusReceipt    = "receipt"    // goods coming into a warehouse
+	StockEntryStatusIssue      = "issue"      // goods leaving a warehouse
+	StockEntryStatusAdjustment = "adjustment" // manual correction, positive or negative
+
+	StockEntryStatusTransferOut = "transfer_out" // shipped to another warehouse
+	StockEntryStatusTransferIn  = "transfer_in"  // received from another warehouse
+	StockEntryStatusWriteOff    = "write_off"    // expired or unusable stock removed from the books
+
+	StockEntryStatusReturnOut = "return_out" // returned to the supplier
+	StockEntryStatusReturnIn  = "return_in"  // returned by a customer
+
+	StockEntryStatusQCChange = "qc_change" // QC decision on a batch, quantity is zero
+)
+
+// QC statuses of a batch in a warehouse. Quarantined and rejected stock is on
+// hand but cannot be allocated, reserved or issued. Quarantined stock waits for
+// a QC decision; rejected stock can only leave through a supplier return,
+// write-off or correcting adjustment.
+const (
+	QCStatusReleased   = "released"
+	QCStatusQuarantine = "quarantine"
+	QCStatusRejected   = "rejected"
+)
+
+// StockEntry is an append-only stock movement. Quantity is signed (positive
+// for incoming, negative for outgoing) and Stock is the running balance of the
+// warehouse/product/batch after the movement was applied.
 type StockEntry struct {
-	ID            uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-	WarehouseID   uuid.UUID `json:"warehouse_id"`
-	ProductID     uuid.UUID `json:"product_id"`
-	BatchNumber   string    `json:"batch_number"`
-	ExpiredAt     time.Time `json:"expired_at"`
-	Date          time.Time `json:"date"`
-	Margin        float64   `json:"margin"`
-	Tax           float64   `json:"tax"`
-	Price         float64   `json:"price"`
-	Stock         int       `json:"stock"`
-	PreviousStock int       `json:"previous_stock"`
-	Status        string    `json:"status"`
-	OrderID       uuid.UUID `json:"order_id"`
-	Notes         string    `json:"notes"`
-	ReferenceID   uuid.UUID `json:"reference_id"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID                 uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	WarehouseID        uuid.UUID `gorm:"type:uuid;index:idx_stock_entry_position" json:"warehouse_id"`
+	ProductID          uuid.UUID `gorm:"type:uuid;index:idx_stock_entry_position" json:"product_id"`
+	BatchNumber        string    `gorm:"index:idx_stock_entry_position" json:"batch_number"`
+	ExpiredAt          time.Time `json:"expired_at"`
+	Date               time.Time `json:"date"`
+	Margin             float64   `json:"margin"`
+	Tax                float64   `json:"tax"`
+	Price              float64   `json:"price"`
+	Quantity           int       `json:"quantity"`            // Signed movement quantity in small units
+	Unit               string    `gorm:"-" json:"-"`          // Unit the quantity was given in, converted to small units before it is stored
+	ConversionResidual float64   `json:"conversion_residual"` // Quantity given less quantity stored, in small units, when a standard unit conversion was rounded
+	Stock              int       `json:"stock"`
+	PreviousStock      int       `json:"previous_stock"`
+	Status             string    `json:"status"`
+	QCStatus           string    `gorm:"not null;default:released" json:"qc_status"` // QC status of the batch after the movement
+	OrderID            uuid.UUID `json:"order_id"`
+	Notes              string    `json:"notes"`
+	ReasonCode         string    `gorm:"index" json:"reason_code"` // Adjustment reason, kept for shrinkage reporting
+	ReferenceID        uuid.UUID `json:"reference_id"`
+	CreatedBy          uint      `json:"created_by"`              // User who recorded the movement
+	AuthorizedBy       uint      `json:"authorized_by,omitempty"` // User who authorized a movement of a controlled product
+	Counterparty       string    `json:"counterparty,omitempty"`  // Supplier, patient, prescriber or warehouse on the other side of a controlled movement
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
 }
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stock period statuses
+const (
+	StockPeriodStatusOpen   = "open"
+	StockPeriodStatusClosed = "closed"
+)
+
+// StockPeriod is a calendar month of the stock ledger. Closing a period
+// snapshots its closing balances and valuation and rejects any further
+// movement dated on or before its end.
+type StockPeriod struct {
+	ID         uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Period     string     `gorm:"unique;not null" json:"period"` // YYYY-MM
+	StartAt    time.Time  `json:"start_at"`
+	EndAt      time.Time  `gorm:"index" json:"end_at"` // Last instant of the month
+	Status     string     `gorm:"not null" json:"status"`
+	Notes      string     `json:"notes"`
+	ClosedAt   *time.Time `json:"closed_at,omitempty"`
+	ClosedBy   uint       `json:"closed_by"`
+	ReopenedAt *time.Time `json:"reopened_at,omitempty"`
+	ReopenedBy uint       `json:"reopened_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// StockBalanceSnapshot is the closing balance of one warehouse/product/batch in a closed period
+type StockBalanceSnapshot struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	PeriodID    uuid.UUID `gorm:"type:uuid;not null;index" json:"period_id"`
+	WarehouseID uuid.UUID `gorm:"type:uuid;not null" json:"warehouse_id"`
+	ProductID   uuid.UUID `gorm:"type:uuid;not null" json:"product_id"`
+	BatchNumber string    `json:"batch_number"`
+	ExpiredAt   time.Time `json:"expired_at"`
+	Quantity    int       `json:"quantity"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// StockValuationSnapshot is the valuation report of a closed period, for all
+// offices (OfficeID is uuid.Nil) or for one office, stored as JSON
+type StockValuationSnapshot struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	PeriodID  uuid.UUID `gorm:"type:uuid;not null;index" json:"period_id"`
+	OfficeID  uuid.UUID `gorm:"type:uuid" json:"office_id"`
+	Report    string    `gorm:"type:jsonb;not null" json:"report"`
+	CreatedAt time.Time `json:"created_at"`
+}
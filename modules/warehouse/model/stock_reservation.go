@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stock reservation statuses
+const (
+	StockReservationStatusActive    = "active"
+	StockReservationStatusReleased  = "released"
+	StockReservationStatusFulfilled = "fulfilled"
+	StockReservationStatusExpired   = "expired"
+)
+
+// ReservationReferenceStockTransfer marks reservations held by a draft stock transfer
+const ReservationReferenceStockTransfer = "stock_transfer"
+
+// StockReservation holds stock for a confirmed document until it is issued,
+// released or the reservation expires
+type StockReservation struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	WarehouseID        uuid.UUID  `gorm:"type:uuid;not null;index:idx_stock_reservation_position" json:"warehouse_id"`
+	ProductID          uuid.UUID  `gorm:"type:uuid;not null;index:idx_stock_reservation_position" json:"product_id"`
+	BatchNumber        string     `json:"batch_number"`        // Empty to issue by the warehouse policy on fulfilment
+	Quantity           int        `json:"quantity"`            // In small units
+	Unit               string     `gorm:"-" json:"-"`          // Unit the quantity was given in, converted to small units before it is stored
+	ConversionResidual float64    `json:"conversion_residual"` // Quantity given less quantity stored, in small units, when a standard unit conversion was rounded
+	Status             string     `gorm:"not null;index" json:"status"`
+	ReferenceType      string     `json:"reference_type"` // e.g. sales_order, stock_transfer
+	ReferenceID        uuid.UUID  `gorm:"type:uuid;index" json:"reference_id"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"` // Nil holds the stock until released
+	ReleasedAt         *time.Time `json:"released_at,omitempty"`
+	Notes              string     `json:"notes"`
+	CreatedBy          uint       `json:"created_by"`
+	ReleasedBy         uint       `json:"released_by"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
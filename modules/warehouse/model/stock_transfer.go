@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stock transfer statuses
+const (
+	StockTransferStatusDraft     = "draft"
+	StockTransferStatusShipped   = "shipped"
+	StockTransferStatusInTransit = "in_transit"
+	StockTransferStatusReceived  = "received"
+	StockTransferStatusCancelled = "cancelled"
+)
+
+// StockTransfer moves stock between two warehouses. Shipping issues the lines
+// at the source warehouse and receiving puts them into the destination.
+type StockTransfer struct {
+	ID                     uuid.UUID           `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Number                 string              `gorm:"unique;not null" json:"number"`
+	SourceWarehouseID      uuid.UUID           `gorm:"type:uuid;not null" json:"source_warehouse_id"`
+	DestinationWarehouseID uuid.UUID           `gorm:"type:uuid;not null" json:"destination_warehouse_id"`
+	Status                 string              `gorm:"not null" json:"status"`
+	Notes                  string              `json:"notes"`
+	ShippedAt              *time.Time          `json:"shipped_at,omitempty"`
+	ReceivedAt             *time.Time          `json:"received_at,omitempty"`
+	CreatedBy              uint                `json:"created_by"`
+	ShippedBy              uint                `json:"shipped_by"`
+	ReceivedBy             uint                `json:"received_by"`
+	Lines                  []StockTransferLine `gorm:"foreignKey:TransferID;constraint:OnDelete:CASCADE" json:"lines"`
+	CreatedAt              time.Time           `json:"created_at"`
+	UpdatedAt              time.Time           `json:"updated_at"`
+}
+
+// StockTransferLine is one product/batch on a transfer. Discrepancy is the
+// shipped quantity that never arrived once the receipt was closed.
+type StockTransferLine struct {
+	ID                  uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TransferID          uuid.UUID `gorm:"type:uuid;not null;index" json:"transfer_id"`
+	ProductID           uuid.UUID `gorm:"type:uuid;not null" json:"product_id"`
+	BatchNumber         string    `json:"batch_number"`
+	ExpiredAt           time.Time `json:"expired_at"` // Captured from the outgoing movement when shipped
+	Price               float64   `json:"price"`      // Captured from the outgoing movement when shipped
+	Quantity            int       `json:"quantity"`
+	Unit                string    `gorm:"-" json:"-"`          // Unit the quantity was given in, converted to small units before it is stored
+	ConversionResidual  float64   `json:"conversion_residual"` // Quantity given less quantity stored, in small units, when a standard unit conversion was rounded
+	ReceivedQuantity    int       `json:"received_quantity"`
+	DiscrepancyQuantity int       `json:"discrepancy_quantity"`
+	DiscrepancyNotes    string    `json:"discrepancy_notes"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// Outstanding returns the shipped quantity not yet received or written off
+func (l *StockTransferLine) Outstanding() int {
+	return l.Quantity - l.ReceivedQuantity - l.DiscrepancyQuantity
+}
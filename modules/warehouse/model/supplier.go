@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Supplier statuses
+const (
+	SupplierStatusActive   = "active"
+	SupplierStatusInactive = "inactive"
+)
+
+type Supplier struct {
+	ID              uuid.UUID         `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Code            string            `gorm:"unique;not null" json:"code"`
+	Name            string            `gorm:"not null" json:"name"`
+	Address         string            `json:"address"`
+	City            string            `json:"city"`
+	Phone           string            `json:"phone"`
+	Email           string            `json:"email"`
+	TaxNumber       string            `json:"tax_number"`
+	PaymentTermDays int               `json:"payment_term_days"` // Days until invoices are due, 0 for cash
+	Status          string            `gorm:"not null;default:active" json:"status"`
+	Contacts        []SupplierContact `gorm:"foreignKey:SupplierID;constraint:OnDelete:CASCADE" json:"contacts"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// SupplierContact is a person to reach at a supplier
+type SupplierContact struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	SupplierID uuid.UUID `gorm:"type:uuid;not null;index" json:"supplier_id"`
+	Name       string    `gorm:"not null" json:"name"`
+	Position   string    `json:"position"`
+	Phone      string    `json:"phone"`
+	Email      string    `json:"email"`
+	IsPrimary  bool      `json:"is_primary"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
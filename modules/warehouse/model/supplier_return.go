@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Return document statuses, shared by supplier and customer returns
+const (
+	ReturnStatusDraft     = "draft"
+	ReturnStatusPosted    = "posted"
+	ReturnStatusCancelled = "cancelled"
+)
+
+// SupplierReturn sends received batches back to the supplier. Its return_out
+// stock entries carry the purchase order in OrderID and the goods receipt they
+// came in with in ReferenceID, so the batch history leads back to its origin.
+type SupplierReturn struct {
+	ID          uuid.UUID            `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Number      string               `gorm:"unique;not null" json:"number"`
+	ReceiptID   uuid.UUID            `gorm:"type:uuid;not null;index" json:"receipt_id"`
+	OrderID     uuid.UUID            `gorm:"type:uuid;not null" json:"order_id"`
+	SupplierID  *uuid.UUID           `gorm:"type:uuid;index" json:"supplier_id"`
+	WarehouseID uuid.UUID            `gorm:"type:uuid;not null;index" json:"warehouse_id"`
+	Status      string               `gorm:"not null" json:"status"`
+	Date        time.Time            `json:"date"`
+	Reason      string               `json:"reason"`
+	Notes       string               `json:"notes"`
+	PostedAt    *time.Time           `json:"posted_at,omitempty"`
+	CreatedBy   uint                 `json:"created_by"`
+	PostedBy    uint                 `json:"posted_by"`
+	Lines       []SupplierReturnLine `gorm:"foreignKey:ReturnID;constraint:OnDelete:CASCADE" json:"lines"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+// SupplierReturnLine is the quantity of one received batch sent back, in small units
+type SupplierReturnLine struct {
+	ID                 uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ReturnID           uuid.UUID `gorm:"type:uuid;not null;index" json:"return_id"`
+	ReceiptLineID      uuid.UUID `gorm:"type:uuid;not null" json:"receipt_line_id"`
+	ProductID          uuid.UUID `gorm:"type:uuid;not null" json:"product_id"`
+	BatchNumber        string    `json:"batch_number"`
+	Quantity           int       `json:"quantity"`
+	Unit               string    `gorm:"-" json:"-"`          // Unit the quantity was given in, converted to small units before it is stored
+	ConversionResidual float64   `json:"conversion_residual"` // Quantity given less quantity stored, in small units, when a standard unit conversion was rounded
+	Price              float64   `json:"price"`               // Unit cost of the receipt line
+	Notes              string    `json:"notes"`
+	StockEntryID       uuid.UUID `gorm:"type:uuid" json:"stock_entry_id"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
@@ -6,10 +6,21 @@ import (
 	"github.com/google/uuid"
 )
 
+// Unit dimensions. Units of the same dimension convert through their factor to
+// the base unit of the dimension: kg, m³, m and pcs.
+const (
+	UnitDimensionMass   = "mass"
+	UnitDimensionVolume = "volume"
+	UnitDimensionLength = "length"
+	UnitDimensionCount  = "count"
+)
+
 type UnitProduct struct {
 	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"` // Unique identifier
 	Code      string    `json:"code"`                                                      // Product code or SKU
 	Name      string    `json:"name"`                                                      // Product name
+	Dimension string    `gorm:"size:20" json:"dimension"`                                  // mass, volume, length or count
+	Factor    float64   `json:"factor"`                                                    // Base units of the dimension in one of this unit, 0 when the size differs per product
 	CreatedAt time.Time `json:"created_at"`                                                // Timestamp when created
 	UpdatedAt time.Time `json:"updated_at"`                                                // Timestamp when updated
 }
@@ -6,15 +6,24 @@ import (
 	"github.com/google/uuid"
 )
 
+// Issue policies decide which batch is consumed first when stock is issued without a batch
+const (
+	IssuePolicyFEFO = "fefo" // first expired, first out
+	IssuePolicyFIFO = "fifo" // first received, first out
+)
+
 type Warehouse struct {
-	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-	Code      string     `gorm:"unique;not null" json:"code"`
-	Name      string     `gorm:"not null" json:"name"`
-	Address   string     `json:"address"`
-	Phone     string     `json:"phone"`
-	Status    string     `json:"status"`
-	BranchID  *uuid.UUID `gorm:"type:uuid" json:"branch_id"` // nullable for now
-	OfficeID  *uuid.UUID `gorm:"type:uuid" json:"office_id"` // nullable for now
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID                 uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Code               string     `gorm:"unique;not null" json:"code"`
+	Name               string     `gorm:"not null" json:"name"`
+	Address            string     `json:"address"`
+	Phone              string     `json:"phone"`
+	Status             string     `json:"status"`
+	IssuePolicy        string     `gorm:"not null;default:fefo" json:"issue_policy"`          // fefo or fifo
+	AllowNegativeStock bool       `gorm:"not null;default:false" json:"allow_negative_stock"` // Let issues drive balances below zero
+	QuarantineReceipts bool       `gorm:"not null;default:false" json:"quarantine_receipts"`  // Receive every receipt into quarantine until QC releases it
+	BranchID           *uuid.UUID `gorm:"type:uuid" json:"branch_id"`                         // nullable for now
+	OfficeID           *uuid.UUID `gorm:"type:uuid" json:"office_id"`                         // nullable for now
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
 }
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AdjustmentReasonRepository interface {
+	GetAll(status string) ([]model.AdjustmentReason, error)
+	GetByID(id uuid.UUID) (*model.AdjustmentReason, error)
+	GetByCode(code string) (*model.AdjustmentReason, error)
+	Create(reason *model.AdjustmentReason) error
+	Update(reason *model.AdjustmentReason) error
+}
+
+type adjustmentReasonRepository struct {
+	*repository.Repository
+}
+
+func NewAdjustmentReasonRepository(db *gorm.DB) AdjustmentReasonRepository {
+	return &adjustmentReasonRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// GetAll returns every reason code, unpaginated since the list is short and used to fill pickers
+func (r *adjustmentReasonRepository) GetAll(status string) ([]model.AdjustmentReason, error) {
+	var reasons []model.AdjustmentReason
+	query := r.DB().Model(&model.AdjustmentReason{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("code ASC").Find(&reasons).Error
+	return reasons, err
+}
+
+func (r *adjustmentReasonRepository) GetByID(id uuid.UUID) (*model.AdjustmentReason, error) {
+	var reason model.AdjustmentReason
+	err := r.DB().First(&reason, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reason, nil
+}
+
+func (r *adjustmentReasonRepository) GetByCode(code string) (*model.AdjustmentReason, error) {
+	var reason model.AdjustmentReason
+	err := r.DB().First(&reason, "code = ?", code).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reason, nil
+}
+
+func (r *adjustmentReasonRepository) Create(reason *model.AdjustmentReason) error {
+	return r.DB().Create(reason).Error
+}
+
+func (r *adjustmentReasonRepository) Update(reason *model.AdjustmentReason) error {
+	return r.DB().Save(reason).Error
+}
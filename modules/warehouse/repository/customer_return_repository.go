@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CustomerReturnRepository interface {
+	GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.CustomerReturn, int64, error)
+	GetByID(id uuid.UUID) (*model.CustomerReturn, error)
+	Create(ret *model.CustomerReturn) error
+	Update(ret *model.CustomerReturn) error
+	// Lock holds a row lock on the return until the transaction ends
+	Lock(id uuid.UUID) error
+	WithTx(tx *gorm.DB) CustomerReturnRepository
+}
+
+type customerReturnRepository struct {
+	*repository.Repository
+}
+
+func NewCustomerReturnRepository(db *gorm.DB) CustomerReturnRepository {
+	return &customerReturnRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *customerReturnRepository) WithTx(tx *gorm.DB) CustomerReturnRepository {
+	return NewCustomerReturnRepository(tx)
+}
+
+// Lock waits for a concurrent post of it to finish and keeps others waiting until the
+// transaction ends. Load the return after locking to see their changes.
+func (r *customerReturnRepository) Lock(id uuid.UUID) error {
+	return r.DB().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", id).Find(&model.CustomerReturn{}).Error
+}
+
+func (r *customerReturnRepository) GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.CustomerReturn, int64, error) {
+	var returns []model.CustomerReturn
+	var total int64
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	baseQuery := r.DB().Model(&model.CustomerReturn{})
+	if warehouseID != uuid.Nil {
+		baseQuery = baseQuery.Where("warehouse_id = ?", warehouseID)
+	}
+	if status != "" {
+		baseQuery = baseQuery.Where("status = ?", status)
+	}
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := baseQuery.Preload("Lines").Order("created_at DESC").Limit(pageSize).Offset(offset).Find(&returns).Error; err != nil {
+		return nil, 0, err
+	}
+	return returns, total, nil
+}
+
+func (r *customerReturnRepository) GetByID(id uuid.UUID) (*model.CustomerReturn, error) {
+	var ret model.CustomerReturn
+	err := r.DB().Preload("Lines").First(&ret, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (r *customerReturnRepository) Create(ret *model.CustomerReturn) error {
+	return r.DB().Create(ret).Error
+}
+
+// Update saves the return header together with its lines
+func (r *customerReturnRepository) Update(ret *model.CustomerReturn) error {
+	return r.DB().Session(&gorm.Session{FullSaveAssociations: true}).Save(ret).Error
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GoodsReceiptRepository interface {
+	GetByOrder(orderID uuid.UUID) ([]model.GoodsReceipt, error)
+	GetByID(id uuid.UUID) (*model.GoodsReceipt, error)
+	Create(receipt *model.GoodsReceipt) error
+	// AddReturnedQuantity increases the quantity of a receipt line sent back to the supplier
+	AddReturnedQuantity(lineID uuid.UUID, quantity int) error
+	// AddLandedCost changes the landed cost per small unit of a receipt line, negative to take it back
+	AddLandedCost(lineID uuid.UUID, unitCost float64) error
+	// Lock holds a row lock on the receipt until the transaction ends
+	Lock(id uuid.UUID) error
+	WithTx(tx *gorm.DB) GoodsReceiptRepository
+}
+
+type goodsReceiptRepository struct {
+	*repository.Repository
+}
+
+func NewGoodsReceiptRepository(db *gorm.DB) GoodsReceiptRepository {
+	return &goodsReceiptRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *goodsReceiptRepository) WithTx(tx *gorm.DB) GoodsReceiptRepository {
+	return NewGoodsReceiptRepository(tx)
+}
+
+// Lock waits for concurrent returns against it to finish and keeps others waiting until the
+// transaction ends. Load the receipt after locking to see their changes.
+func (r *goodsReceiptRepository) Lock(id uuid.UUID) error {
+	return r.DB().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", id).Find(&model.GoodsReceipt{}).Error
+}
+
+// GetByOrder returns every receipt of a purchase order, oldest first
+func (r *goodsReceiptRepository) GetByOrder(orderID uuid.UUID) ([]model.GoodsReceipt, error) {
+	var receipts []model.GoodsReceipt
+	err := r.DB().Preload("Lines").Where("order_id = ?", orderID).Order("date ASC, created_at ASC").Find(&receipts).Error
+	return receipts, err
+}
+
+func (r *goodsReceiptRepository) GetByID(id uuid.UUID) (*model.GoodsReceipt, error) {
+	var receipt model.GoodsReceipt
+	err := r.DB().Preload("Lines").First(&receipt, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &receipt, nil
+}
+
+func (r *goodsReceiptRepository) Create(receipt *model.GoodsReceipt) error {
+	return r.DB().Create(receipt).Error
+}
+
+func (r *goodsReceiptRepository) AddReturnedQuantity(lineID uuid.UUID, quantity int) error {
+	return r.DB().Model(&model.GoodsReceiptLine{}).
+		Where("id = ?", lineID).
+		Update("returned_quantity", gorm.Expr("returned_quantity + ?", quantity)).Error
+}
+
+func (r *goodsReceiptRepository) AddLandedCost(lineID uuid.UUID, unitCost float64) error {
+	return r.DB().Model(&model.GoodsReceiptLine{}).
+		Where("id = ?", lineID).
+		Update("landed_cost", gorm.Expr("landed_cost + ?", unitCost)).Error
+}
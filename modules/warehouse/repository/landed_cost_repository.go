@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LandedCostRepository interface {
+	GetAll(page, pageSize int, receiptID uuid.UUID, status string) ([]model.LandedCost, int64, error)
+	GetByID(id uuid.UUID) (*model.LandedCost, error)
+	// Lock holds a row lock on the landed cost until the transaction ends
+	Lock(id uuid.UUID) error
+	Create(cost *model.LandedCost) error
+	Update(cost *model.LandedCost) error
+	WithTx(tx *gorm.DB) LandedCostRepository
+}
+
+type landedCostRepository struct {
+	*repository.Repository
+}
+
+func NewLandedCostRepository(db *gorm.DB) LandedCostRepository {
+	return &landedCostRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *landedCostRepository) WithTx(tx *gorm.DB) LandedCostRepository {
+	return NewLandedCostRepository(tx)
+}
+
+// Lock waits for concurrent posts, reversals or cancellations of the landed cost to finish and
+// keeps others waiting until the transaction ends. Load the landed cost after locking to see their changes.
+func (r *landedCostRepository) Lock(id uuid.UUID) error {
+	return r.DB().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", id).Find(&model.LandedCost{}).Error
+}
+
+func (r *landedCostRepository) GetAll(page, pageSize int, receiptID uuid.UUID, status string) ([]model.LandedCost, int64, error) {
+	var costs []model.LandedCost
+	var total int64
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	baseQuery := r.DB().Model(&model.LandedCost{})
+	if receiptID != uuid.Nil {
+		baseQuery = baseQuery.Where("receipt_id = ?", receiptID)
+	}
+	if status != "" {
+		baseQuery = baseQuery.Where("status = ?", status)
+	}
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := baseQuery.Preload("Charges").Preload("Lines").Order("created_at DESC").Limit(pageSize).Offset(offset).Find(&costs).Error; err != nil {
+		return nil, 0, err
+	}
+	return costs, total, nil
+}
+
+func (r *landedCostRepository) GetByID(id uuid.UUID) (*model.LandedCost, error) {
+	var cost model.LandedCost
+	err := r.DB().Preload("Charges").Preload("Lines").First(&cost, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cost, nil
+}
+
+func (r *landedCostRepository) Create(cost *model.LandedCost) error {
+	return r.DB().Create(cost).Error
+}
+
+// Update saves the landed cost header together with its charges and lines
+func (r *landedCostRepository) Update(cost *model.LandedCost) error {
+	return r.DB().Session(&gorm.Session{FullSaveAssociations: true}).Save(cost).Error
+}
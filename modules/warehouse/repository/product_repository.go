@@ -17,6 +17,10 @@ type ProductRepository interface {
 	Create(product *model.Product) error
 	Update(product *model.Product) error
 	Delete(id uuid.UUID) error
+	// HasStock reports whether any stock movement or balance refers to the product
+	HasStock(id uuid.UUID) (bool, error)
+	// GetBarcode finds the barcode matching any of the given codes, with its unit
+	GetBarcode(codes ...string) (*model.ProductBarcode, error)
 }
 
 type productRepository struct {
@@ -39,7 +43,7 @@ func (r *productRepository) GetAll(page, pageSize int, searchTerm string) ([]mod
 	}
 	offset := (page - 1) * pageSize
 
-	baseQuery := r.DB().Model(&model.Product{}).Preload("Category")
+	baseQuery := r.DB().Model(&model.Product{}).Preload("Category").Preload("Units", orderByLevel).Preload("Units.Unit").Preload("Barcodes.Unit")
 	if searchTerm != "" {
 		searchTerm = utils.SanitizeSearchTerm(searchTerm)
 		like := "%" + searchTerm + "%"
@@ -57,7 +61,7 @@ func (r *productRepository) GetAll(page, pageSize int, searchTerm string) ([]mod
 
 func (r *productRepository) GetByID(id uuid.UUID) (*model.Product, error) {
 	var product model.Product
-	err := r.DB().Preload("Category").First(&product, "id = ?", id).Error
+	err := r.DB().Preload("Category").Preload("Units", orderByLevel).Preload("Units.Unit").Preload("Barcodes.Unit").First(&product, "id = ?", id).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil // not found, return nil object and nil error
 	}
@@ -71,10 +75,76 @@ func (r *productRepository) Create(product *model.Product) error {
 	return r.DB().Create(product).Error
 }
 
+// Update saves the product and replaces its packaging hierarchy and barcodes as
+// a whole. A nil Units or Barcodes keeps what the product already has.
 func (r *productRepository) Update(product *model.Product) error {
-	return r.DB().Save(product).Error
+	return r.DB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Units", "Barcodes").Save(product).Error; err != nil {
+			return err
+		}
+		if product.Units != nil {
+			if err := tx.Where("product_id = ?", product.ID).Delete(&model.ProductUnit{}).Error; err != nil {
+				return err
+			}
+		}
+		if product.Barcodes != nil {
+			if err := tx.Where("product_id = ?", product.ID).Delete(&model.ProductBarcode{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(product.Units) > 0 {
+			for i := range product.Units {
+				product.Units[i].ID = uuid.Nil
+				product.Units[i].ProductID = product.ID
+			}
+			if err := tx.Omit("Unit").Create(&product.Units).Error; err != nil {
+				return err
+			}
+		}
+		if len(product.Barcodes) > 0 {
+			for i := range product.Barcodes {
+				product.Barcodes[i].ID = uuid.Nil
+				product.Barcodes[i].ProductID = product.ID
+			}
+			if err := tx.Omit("Unit").Create(&product.Barcodes).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 func (r *productRepository) Delete(id uuid.UUID) error {
 	return r.DB().Delete(&model.Product{}, "id = ?", id).Error
 }
+
+func (r *productRepository) HasStock(id uuid.UUID) (bool, error) {
+	for _, table := range []interface{}{&model.StockEntry{}, &model.StockBalance{}} {
+		var count int64
+		if err := r.DB().Model(table).Where("product_id = ?", id).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *productRepository) GetBarcode(codes ...string) (*model.ProductBarcode, error) {
+	var barcode model.ProductBarcode
+	err := r.DB().Preload("Unit").First(&barcode, "code IN ?", codes).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &barcode, nil
+}
+
+// orderByLevel sorts a preloaded packaging hierarchy base unit first
+func orderByLevel(db *gorm.DB) *gorm.DB {
+	return db.Order("level ASC")
+}
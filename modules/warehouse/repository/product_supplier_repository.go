@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ProductSupplierRepository interface {
+	GetByProduct(productID uuid.UUID) ([]model.ProductSupplier, error)
+	Get(productID, supplierID uuid.UUID) (*model.ProductSupplier, error)
+	// GetPreferred returns the vendor to order a product from: the preferred
+	// active supplier, otherwise the active supplier with the shortest lead time
+	GetPreferred(productID uuid.UUID) (*model.ProductSupplier, error)
+	Save(link *model.ProductSupplier) error
+	ClearPreferred(productID uuid.UUID) error
+	Delete(productID, supplierID uuid.UUID) error
+	WithTx(tx *gorm.DB) ProductSupplierRepository
+}
+
+type productSupplierRepository struct {
+	*repository.Repository
+}
+
+func NewProductSupplierRepository(db *gorm.DB) ProductSupplierRepository {
+	return &productSupplierRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *productSupplierRepository) WithTx(tx *gorm.DB) ProductSupplierRepository {
+	return NewProductSupplierRepository(tx)
+}
+
+func (r *productSupplierRepository) GetByProduct(productID uuid.UUID) ([]model.ProductSupplier, error) {
+	var links []model.ProductSupplier
+	err := r.DB().Preload("Supplier").
+		Where("product_id = ?", productID).
+		Order("is_preferred DESC, lead_time_days ASC").
+		Find(&links).Error
+	return links, err
+}
+
+func (r *productSupplierRepository) Get(productID, supplierID uuid.UUID) (*model.ProductSupplier, error) {
+	var link model.ProductSupplier
+	err := r.DB().Where("product_id = ? AND supplier_id = ?", productID, supplierID).First(&link).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *productSupplierRepository) GetPreferred(productID uuid.UUID) (*model.ProductSupplier, error) {
+	var link model.ProductSupplier
+	err := r.DB().Preload("Supplier").
+		Joins("JOIN suppliers ON suppliers.id = product_suppliers.supplier_id").
+		Where("product_suppliers.product_id = ? AND suppliers.status = ?", productID, model.SupplierStatusActive).
+		Order("product_suppliers.is_preferred DESC, product_suppliers.lead_time_days ASC").
+		First(&link).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *productSupplierRepository) Save(link *model.ProductSupplier) error {
+	return r.DB().Omit("Supplier").Save(link).Error
+}
+
+// ClearPreferred unmarks every preferred supplier of a product
+func (r *productSupplierRepository) ClearPreferred(productID uuid.UUID) error {
+	return r.DB().Model(&model.ProductSupplier{}).
+		Where("product_id = ? AND is_preferred", productID).
+		Update("is_preferred", false).Error
+}
+
+func (r *productSupplierRepository) Delete(productID, supplierID uuid.UUID) error {
+	return r.DB().Where("product_id = ? AND supplier_id = ?", productID, supplierID).Delete(&model.ProductSupplier{}).Error
+}
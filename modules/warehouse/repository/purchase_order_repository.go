@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PurchaseOrderRepository interface {
+	GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.PurchaseOrder, int64, error)
+	GetByID(id uuid.UUID) (*model.PurchaseOrder, error)
+	Create(order *model.PurchaseOrder) error
+	Update(order *model.PurchaseOrder) error
+	ReplaceLines(order *model.PurchaseOrder) error
+	// Lock holds a row lock on the order until the transaction ends
+	Lock(id uuid.UUID) error
+	WithTx(tx *gorm.DB) PurchaseOrderRepository
+}
+
+type purchaseOrderRepository struct {
+	*repository.Repository
+}
+
+func NewPurchaseOrderRepository(db *gorm.DB) PurchaseOrderRepository {
+	return &purchaseOrderRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *purchaseOrderRepository) WithTx(tx *gorm.DB) PurchaseOrderRepository {
+	return NewPurchaseOrderRepository(tx)
+}
+
+// Lock waits for concurrent receipts against it to finish and keeps others waiting until the
+// transaction ends. Load the order after locking to see their changes.
+func (r *purchaseOrderRepository) Lock(id uuid.UUID) error {
+	return r.DB().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", id).Find(&model.PurchaseOrder{}).Error
+}
+
+func (r *purchaseOrderRepository) GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.PurchaseOrder, int64, error) {
+	var orders []model.PurchaseOrder
+	var total int64
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	baseQuery := r.DB().Model(&model.PurchaseOrder{})
+	if warehouseID != uuid.Nil {
+		baseQuery = baseQuery.Where("warehouse_id = ?", warehouseID)
+	}
+	if status != "" {
+		baseQuery = baseQuery.Where("status = ?", status)
+	}
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := baseQuery.Preload("Lines").Order("created_at DESC").Limit(pageSize).Offset(offset).Find(&orders).Error; err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+func (r *purchaseOrderRepository) GetByID(id uuid.UUID) (*model.PurchaseOrder, error) {
+	var order model.PurchaseOrder
+	err := r.DB().Preload("Lines").First(&order, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *purchaseOrderRepository) Create(order *model.PurchaseOrder) error {
+	return r.DB().Create(order).Error
+}
+
+// Update saves the order header together with its existing lines
+func (r *purchaseOrderRepository) Update(order *model.PurchaseOrder) error {
+	return r.DB().Session(&gorm.Session{FullSaveAssociations: true}).Save(order).Error
+}
+
+// ReplaceLines deletes the stored lines of an order and inserts the given ones
+func (r *purchaseOrderRepository) ReplaceLines(order *model.PurchaseOrder) error {
+	if err := r.DB().Where("order_id = ?", order.ID).Delete(&model.PurchaseOrderLine{}).Error; err != nil {
+		return err
+	}
+	for i := range order.Lines {
+		order.Lines[i].ID = uuid.Nil
+		order.Lines[i].OrderID = order.ID
+	}
+	if len(order.Lines) == 0 {
+		return nil
+	}
+	return r.DB().Create(&order.Lines).Error
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RecallRepository interface {
+	GetAll(page, pageSize int, productID uuid.UUID, status string) ([]model.Recall, int64, error)
+	GetByID(id uuid.UUID) (*model.Recall, error)
+	// GetOpen returns the open recall of a batch, or nil if the batch is not recalled
+	GetOpen(productID uuid.UUID, batchNumber string) (*model.Recall, error)
+	// Lock holds a row lock on the recall until the transaction ends
+	Lock(id uuid.UUID) error
+	Create(recall *model.Recall) error
+	Update(recall *model.Recall) error
+	// CreateTask adds a task to an existing recall
+	CreateTask(task *model.RecallTask) error
+	WithTx(tx *gorm.DB) RecallRepository
+}
+
+type recallRepository struct {
+	*repository.Repository
+}
+
+func NewRecallRepository(db *gorm.DB) RecallRepository {
+	return &recallRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *recallRepository) WithTx(tx *gorm.DB) RecallRepository {
+	return NewRecallRepository(tx)
+}
+
+func (r *recallRepository) GetAll(page, pageSize int, productID uuid.UUID, status string) ([]model.Recall, int64, error) {
+	var recalls []model.Recall
+	var total int64
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	baseQuery := r.DB().Model(&model.Recall{})
+	if productID != uuid.Nil {
+		baseQuery = baseQuery.Where("product_id = ?", productID)
+	}
+	if status != "" {
+		baseQuery = baseQuery.Where("status = ?", status)
+	}
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := baseQuery.Preload("Tasks").Order("created_at DESC").Limit(pageSize).Offset(offset).Find(&recalls).Error; err != nil {
+		return nil, 0, err
+	}
+	return recalls, total, nil
+}
+
+func (r *recallRepository) GetByID(id uuid.UUID) (*model.Recall, error) {
+	return r.first(r.DB().Where("id = ?", id))
+}
+
+func (r *recallRepository) GetOpen(productID uuid.UUID, batchNumber string) (*model.Recall, error) {
+	return r.first(r.DB().Where("product_id = ? AND batch_number = ? AND status = ?", productID, batchNumber, model.RecallStatusOpen))
+}
+
+func (r *recallRepository) Lock(id uuid.UUID) error {
+	return r.DB().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", id).Find(&model.Recall{}).Error
+}
+
+func (r *recallRepository) first(query *gorm.DB) (*model.Recall, error) {
+	var recall model.Recall
+	err := query.Preload("Tasks").First(&recall).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &recall, nil
+}
+
+func (r *recallRepository) Create(recall *model.Recall) error {
+	return r.DB().Create(recall).Error
+}
+
+// Update saves the recall header together with its tasks
+func (r *recallRepository) Update(recall *model.Recall) error {
+	return r.DB().Session(&gorm.Session{FullSaveAssociations: true}).Save(recall).Error
+}
+
+func (r *recallRepository) CreateTask(task *model.RecallTask) error {
+	return r.DB().Create(task).Error
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReorderCandidateRow is a reorder setting with the on-hand stock of its
+// warehouse summed from the ledger
+type ReorderCandidateRow struct {
+	WarehouseID         uuid.UUID
+	WarehouseName       string
+	ProductID           uuid.UUID
+	ProductCode         string
+	ProductName         string
+	LargeUnit           string
+	SmallUnit           string
+	ContentPerLargeUnit int
+	MinStock            int
+	ReorderPoint        int
+	MaxStock            int
+	OnHand              int
+	OnOrder             int
+}
+
+type ReorderSettingRepository interface {
+	GetAll(page, pageSize int, warehouseID, productID uuid.UUID) ([]model.ReorderSetting, int64, error)
+	GetByID(id uuid.UUID) (*model.ReorderSetting, error)
+	Get(warehouseID, productID uuid.UUID) (*model.ReorderSetting, error)
+	Save(setting *model.ReorderSetting) error
+	Delete(id uuid.UUID) error
+	// GetCandidates returns every setting with its usable on-hand stock and the quantity still to be
+	// received on approved purchase orders, optionally limited to a warehouse or office.
+	// Whether a product needs reordering depends on forecast demand and is left to the caller.
+	GetCandidates(warehouseID, officeID uuid.UUID) ([]ReorderCandidateRow, error)
+}
+
+type reorderSettingRepository struct {
+	*repository.Repository
+}
+
+func NewReorderSettingRepository(db *gorm.DB) ReorderSettingRepository {
+	return &reorderSettingRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+func (r *reorderSettingRepository) GetAll(page, pageSize int, warehouseID, productID uuid.UUID) ([]model.ReorderSetting, int64, error) {
+	var settings []model.ReorderSetting
+	var total int64
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	baseQuery := r.DB().Model(&model.ReorderSetting{})
+	if warehouseID != uuid.Nil {
+		baseQuery = baseQuery.Where("warehouse_id = ?", warehouseID)
+	}
+	if productID != uuid.Nil {
+		baseQuery = baseQuery.Where("product_id = ?", productID)
+	}
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := baseQuery.Preload("Product").Order("created_at DESC").Limit(pageSize).Offset(offset).Find(&settings).Error; err != nil {
+		return nil, 0, err
+	}
+	return settings, total, nil
+}
+
+func (r *reorderSettingRepository) GetByID(id uuid.UUID) (*model.ReorderSetting, error) {
+	var setting model.ReorderSetting
+	err := r.DB().First(&setting, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+
+// Get returns the setting of a product in a warehouse, or nil if none is configured
+func (r *reorderSettingRepository) Get(warehouseID, productID uuid.UUID) (*model.ReorderSetting, error) {
+	var setting model.ReorderSetting
+	err := r.DB().Where("warehouse_id = ? AND product_id = ?", warehouseID, productID).First(&setting).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *reorderSettingRepository) Save(setting *model.ReorderSetting) error {
+	return r.DB().Save(setting).Error
+}
+
+func (r *reorderSettingRepository) Delete(id uuid.UUID) error {
+	return r.DB().Delete(&model.ReorderSetting{}, "id = ?", id).Error
+}
+
+func (r *reorderSettingRepository) GetCandidates(warehouseID, officeID uuid.UUID) ([]ReorderCandidateRow, error) {
+	var rows []ReorderCandidateRow
+
+	// Quarantined, rejected and expired stock cannot be sold, so it does not count
+	onHand := r.DB().Model(&model.StockBalance{}).
+		Select("warehouse_id, product_id, SUM(quantity) AS on_hand").
+		Where("qc_status = ? AND (expired_at <= ? OR expired_at > ?)", model.QCStatusReleased, time.Time{}, time.Now()).
+		Group("warehouse_id, product_id")
+	onOrder := r.DB().Table("purchase_order_lines AS pol").
+		Select("po.warehouse_id, pol.product_id, SUM(GREATEST(pol.quantity * pol.unit_content - pol.received_quantity, 0)) AS on_order").
+		Joins("JOIN purchase_orders AS po ON po.id = pol.order_id").
+		Where("po.status IN ?", []string{model.PurchaseOrderStatusApproved, model.PurchaseOrderStatusPartiallyReceived}).
+		Group("po.warehouse_id, pol.product_id")
+
+	query := r.DB().Table("reorder_settings AS rs").
+		Select(`warehouses.id AS warehouse_id, warehouses.name AS warehouse_name,
+			products.id AS product_id, products.code AS product_code, products.name AS product_name,
+			products.large_unit, products.small_unit, products.content_per_large_unit,
+			rs.min_stock, rs.reorder_point, rs.max_stock, COALESCE(oh.on_hand, 0) AS on_hand, COALESCE(oo.on_order, 0) AS on_order`).
+		Joins("JOIN warehouses ON warehouses.id = rs.warehouse_id").
+		Joins("LEFT JOIN branches ON branches.id = warehouses.branch_id").
+		Joins("JOIN products ON products.id = rs.product_id").
+		Joins("LEFT JOIN (?) AS oh ON oh.warehouse_id = rs.warehouse_id AND oh.product_id = rs.product_id", onHand).
+		Joins("LEFT JOIN (?) AS oo ON oo.warehouse_id = rs.warehouse_id AND oo.product_id = rs.product_id", onOrder)
+	if warehouseID != uuid.Nil {
+		query = query.Where("rs.warehouse_id = ?", warehouseID)
+	}
+	if officeID != uuid.Nil {
+		query = query.Where("COALESCE(warehouses.office_id, branches.office_id) = ?", officeID)
+	}
+
+	err := query.Order("warehouses.name ASC, warehouses.id ASC, products.name ASC").Scan(&rows).Error
+	return rows, err
+}
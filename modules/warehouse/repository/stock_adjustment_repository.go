@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockAdjustmentRepository interface {
+	GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.StockAdjustment, int64, error)
+	GetByID(id uuid.UUID) (*model.StockAdjustment, error)
+	// Lock holds a row lock on the adjustment until the transaction ends
+	Lock(id uuid.UUID) error
+	Create(adjustment *model.StockAdjustment) error
+	Update(adjustment *model.StockAdjustment) error
+	ReplaceLines(adjustment *model.StockAdjustment) error
+	AddAttachment(attachment *model.StockAdjustmentAttachment) error
+	DeleteAttachment(adjustmentID, attachmentID uuid.UUID) (bool, error)
+	WithTx(tx *gorm.DB) StockAdjustmentRepository
+}
+
+type stockAdjustmentRepository struct {
+	*repository.Repository
+}
+
+func NewStockAdjustmentRepository(db *gorm.DB) StockAdjustmentRepository {
+	return &stockAdjustmentRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *stockAdjustmentRepository) WithTx(tx *gorm.DB) StockAdjustmentRepository {
+	return NewStockAdjustmentRepository(tx)
+}
+
+// Lock waits for concurrent status changes of the adjustment to finish and keeps others waiting
+// until the transaction ends. Load the adjustment after locking to see their changes.
+func (r *stockAdjustmentRepository) Lock(id uuid.UUID) error {
+	return r.DB().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", id).Find(&model.StockAdjustment{}).Error
+}
+
+func (r *stockAdjustmentRepository) GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.StockAdjustment, int64, error) {
+	var adjustments []model.StockAdjustment
+	var total int64
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	baseQuery := r.DB().Model(&model.StockAdjustment{})
+	if warehouseID != uuid.Nil {
+		baseQuery = baseQuery.Where("warehouse_id = ?", warehouseID)
+	}
+	if status != "" {
+		baseQuery = baseQuery.Where("status = ?", status)
+	}
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := baseQuery.Preload("Lines").Order("created_at DESC").Limit(pageSize).Offset(offset).Find(&adjustments).Error; err != nil {
+		return nil, 0, err
+	}
+	return adjustments, total, nil
+}
+
+func (r *stockAdjustmentRepository) GetByID(id uuid.UUID) (*model.StockAdjustment, error) {
+	var adjustment model.StockAdjustment
+	err := r.DB().
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Attachments", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		First(&adjustment, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &adjustment, nil
+}
+
+func (r *stockAdjustmentRepository) Create(adjustment *model.StockAdjustment) error {
+	return r.DB().Create(adjustment).Error
+}
+
+// Update saves the adjustment header together with its existing lines
+func (r *stockAdjustmentRepository) Update(adjustment *model.StockAdjustment) error {
+	return r.DB().Session(&gorm.Session{FullSaveAssociations: true}).Omit("Attachments").Save(adjustment).Error
+}
+
+// ReplaceLines deletes the stored lines of an adjustment and inserts the given ones
+func (r *stockAdjustmentRepository) ReplaceLines(adjustment *model.StockAdjustment) error {
+	if err := r.DB().Where("adjustment_id = ?", adjustment.ID).Delete(&model.StockAdjustmentLine{}).Error; err != nil {
+		return err
+	}
+	for i := range adjustment.Lines {
+		adjustment.Lines[i].ID = uuid.Nil
+		adjustment.Lines[i].AdjustmentID = adjustment.ID
+	}
+	if len(adjustment.Lines) == 0 {
+		return nil
+	}
+	return r.DB().Create(&adjustment.Lines).Error
+}
+
+func (r *stockAdjustmentRepository) AddAttachment(attachment *model.StockAdjustmentAttachment) error {
+	return r.DB().Create(attachment).Error
+}
+
+// DeleteAttachment removes an attachment of the adjustment, reporting whether it existed
+func (r *stockAdjustmentRepository) DeleteAttachment(adjustmentID, attachmentID uuid.UUID) (bool, error) {
+	result := r.DB().Where("id = ? AND adjustment_id = ?", attachmentID, adjustmentID).Delete(&model.StockAdjustmentAttachment{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockAlertRepository interface {
+	GetAll(page, pageSize int, alertType string, acknowledged *bool) ([]model.StockAlert, int64, error)
+	GetByID(id uuid.UUID) (*model.StockAlert, error)
+	// CreateIfAbsent inserts the alert unless the same alert was already raised that day
+	CreateIfAbsent(alert *model.StockAlert) (bool, error)
+	Update(alert *model.StockAlert) error
+}
+
+type stockAlertRepository struct {
+	*repository.Repository
+}
+
+func NewStockAlertRepository(db *gorm.DB) StockAlertRepository {
+	return &stockAlertRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+func (r *stockAlertRepository) GetAll(page, pageSize int, alertType string, acknowledged *bool) ([]model.StockAlert, int64, error) {
+	var alerts []model.StockAlert
+	var total int64
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	baseQuery := r.DB().Model(&model.StockAlert{})
+	if alertType != "" {
+		baseQuery = baseQuery.Where("type = ?", alertType)
+	}
+	if acknowledged != nil {
+		if *acknowledged {
+			baseQuery = baseQuery.Where("acknowledged_at IS NOT NULL")
+		} else {
+			baseQuery = baseQuery.Where("acknowledged_at IS NULL")
+		}
+	}
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := baseQuery.Order("alert_date DESC, expired_at ASC").Limit(pageSize).Offset(offset).Find(&alerts).Error; err != nil {
+		return nil, 0, err
+	}
+	return alerts, total, nil
+}
+
+func (r *stockAlertRepository) GetByID(id uuid.UUID) (*model.StockAlert, error) {
+	var alert model.StockAlert
+	err := r.DB().First(&alert, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+func (r *stockAlertRepository) CreateIfAbsent(alert *model.StockAlert) (bool, error) {
+	result := r.DB().Clauses(clause.OnConflict{DoNothing: true}).Create(alert)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *stockAlertRepository) Update(alert *model.StockAlert) error {
+	return r.DB().Save(alert).Error
+}
@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockBalanceRepository interface {
+	GetByWarehouse(warehouseID uuid.UUID, page, pageSize int) ([]model.StockBalance, int64, error)
+	GetByProduct(productID uuid.UUID, page, pageSize int) ([]model.StockBalance, int64, error)
+	// GetByQCStatus returns batches on hand in a QC status, or in any status other
+	// than released when status is empty. Either ID may be uuid.Nil to leave it unfiltered.
+	GetByQCStatus(warehouseID, productID uuid.UUID, status string, page, pageSize int) ([]model.StockBalance, int64, error)
+	// GetAsOf rebuilds non-zero balances as of a past moment from the ledger, starting
+	// from the snapshot of base when given. Either ID may be uuid.Nil to leave it unfiltered.
+	GetAsOf(warehouseID, productID uuid.UUID, asOf time.Time, base *model.StockPeriod, page, pageSize int) ([]model.StockBalance, int64, error)
+	// ListAsOf is GetAsOf for every warehouse and product, unpaginated
+	ListAsOf(asOf time.Time, base *model.StockPeriod) ([]model.StockBalance, error)
+	Get(warehouseID, productID uuid.UUID, batchNumber string) (*model.StockBalance, error)
+	// LockProduct locks every batch row of a product in a warehouse until the
+	// transaction ends, so concurrent stock movements of that product run one at a time
+	LockProduct(warehouseID, productID uuid.UUID) ([]model.StockBalance, error)
+	ListByWarehouse(warehouseID uuid.UUID) ([]model.StockBalance, error)
+	// ListByBatch returns the non-zero balances of a product batch in every warehouse
+	ListByBatch(productID uuid.UUID, batchNumber string) ([]model.StockBalance, error)
+	Apply(entry *model.StockEntry) error
+	Rebuild(warehouseID uuid.UUID) (int64, error)
+	WithTx(tx *gorm.DB) StockBalanceRepository
+}
+
+type stockBalanceRepository struct {
+	*repository.Repository
+}
+
+func NewStockBalanceRepository(db *gorm.DB) StockBalanceRepository {
+	return &stockBalanceRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *stockBalanceRepository) WithTx(tx *gorm.DB) StockBalanceRepository {
+	return NewStockBalanceRepository(tx)
+}
+
+func (r *stockBalanceRepository) GetByWarehouse(warehouseID uuid.UUID, page, pageSize int) ([]model.StockBalance, int64, error) {
+	query := r.DB().Model(&model.StockBalance{}).
+		Where("warehouse_id = ? AND quantity <> 0", warehouseID).
+		Preload("Product")
+	return r.paginate(query, page, pageSize)
+}
+
+func (r *stockBalanceRepository) GetByProduct(productID uuid.UUID, page, pageSize int) ([]model.StockBalance, int64, error) {
+	query := r.DB().Model(&model.StockBalance{}).
+		Where("product_id = ? AND quantity <> 0", productID).
+		Preload("Warehouse")
+	return r.paginate(query, page, pageSize)
+}
+
+func (r *stockBalanceRepository) GetByQCStatus(warehouseID, productID uuid.UUID, status string, page, pageSize int) ([]model.StockBalance, int64, error) {
+	query := r.DB().Model(&model.StockBalance{}).
+		Where("quantity <> 0").
+		Preload("Warehouse").
+		Preload("Product")
+	if status != "" {
+		query = query.Where("qc_status = ?", status)
+	} else {
+		query = query.Where("qc_status <> ?", model.QCStatusReleased)
+	}
+	if warehouseID != uuid.Nil {
+		query = query.Where("warehouse_id = ?", warehouseID)
+	}
+	if productID != uuid.Nil {
+		query = query.Where("product_id = ?", productID)
+	}
+	return r.paginate(query, page, pageSize)
+}
+
+func (r *stockBalanceRepository) GetAsOf(warehouseID, productID uuid.UUID, asOf time.Time, base *model.StockPeriod, page, pageSize int) ([]model.StockBalance, int64, error) {
+	query := r.DB().Model(&model.StockBalance{}).Table("(?) AS stock_balances", r.asOfQuery(warehouseID, productID, asOf, base))
+	if warehouseID != uuid.Nil {
+		query = query.Preload("Product")
+	} else {
+		query = query.Preload("Warehouse")
+	}
+	return r.paginate(query, page, pageSize)
+}
+
+func (r *stockBalanceRepository) ListAsOf(asOf time.Time, base *model.StockPeriod) ([]model.StockBalance, error) {
+	var balances []model.StockBalance
+	err := r.DB().Table("(?) AS stock_balances", r.asOfQuery(uuid.Nil, uuid.Nil, asOf, base)).
+		Order("warehouse_id ASC, product_id ASC, batch_number ASC").
+		Find(&balances).Error
+	return balances, err
+}
+
+// asOfQuery sums the snapshot of base with the ledger movements dated after
+// base ended and on or before asOf, per warehouse/product/batch
+func (r *stockBalanceRepository) asOfQuery(warehouseID, productID uuid.UUID, asOf time.Time, base *model.StockPeriod) *gorm.DB {
+	const columns = "warehouse_id, product_id, batch_number, expired_at, quantity"
+
+	var from time.Time
+	snapshot := r.DB().Model(&model.StockBalanceSnapshot{}).Select(columns).Where("1 = 0")
+	if base != nil {
+		from = base.EndAt
+		snapshot = r.DB().Model(&model.StockBalanceSnapshot{}).Select(columns).Where("period_id = ?", base.ID)
+	}
+	ledger := r.DB().Model(&model.StockEntry{}).Select(columns).Where("date > ? AND date <= ?", from, asOf)
+
+	if warehouseID != uuid.Nil {
+		snapshot = snapshot.Where("warehouse_id = ?", warehouseID)
+		ledger = ledger.Where("warehouse_id = ?", warehouseID)
+	}
+	if productID != uuid.Nil {
+		snapshot = snapshot.Where("product_id = ?", productID)
+		ledger = ledger.Where("product_id = ?", productID)
+	}
+
+	return r.DB().Table("(?) AS movements", r.DB().Raw("? UNION ALL ?", snapshot, ledger)).
+		Select("warehouse_id, product_id, batch_number, MAX(expired_at) AS expired_at, SUM(quantity) AS quantity").
+		Group("warehouse_id, product_id, batch_number").
+		Having("SUM(quantity) <> 0")
+}
+
+func (r *stockBalanceRepository) paginate(query *gorm.DB, page, pageSize int) ([]model.StockBalance, int64, error) {
+	var balances []model.StockBalance
+	var total int64
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("expired_at ASC, batch_number ASC").Limit(pageSize).Offset(offset).Find(&balances).Error; err != nil {
+		return nil, 0, err
+	}
+	return balances, total, nil
+}
+
+// Get returns the balance row of a warehouse/product/batch, or nil if stock was never recorded there
+func (r *stockBalanceRepository) Get(warehouseID, productID uuid.UUID, batchNumber string) (*model.StockBalance, error) {
+	var balance model.StockBalance
+	err := r.DB().
+		Where("warehouse_id = ? AND product_id = ? AND batch_number = ?", warehouseID, productID, batchNumber).
+		First(&balance).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// LockProduct returns every batch row of a product in the warehouse, locked FOR UPDATE.
+// Rows are locked in batch order so two transactions never wait on each other crosswise.
+func (r *stockBalanceRepository) LockProduct(warehouseID, productID uuid.UUID) ([]model.StockBalance, error) {
+	var balances []model.StockBalance
+	err := r.DB().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("warehouse_id = ? AND product_id = ?", warehouseID, productID).
+		Order("batch_number ASC").
+		Find(&balances).Error
+	return balances, err
+}
+
+// ListByWarehouse returns every non-zero balance of a warehouse, unpaginated
+func (r *stockBalanceRepository) ListByWarehouse(warehouseID uuid.UUID) ([]model.StockBalance, error) {
+	var balances []model.StockBalance
+	err := r.DB().
+		Where("warehouse_id = ? AND quantity <> 0", warehouseID).
+		Order("product_id ASC, batch_number ASC").
+		Find(&balances).Error
+	return balances, err
+}
+
+func (r *stockBalanceRepository) ListByBatch(productID uuid.UUID, batchNumber string) ([]model.StockBalance, error) {
+	var balances []model.StockBalance
+	err := r.DB().
+		Preload("Warehouse").
+		Where("product_id = ? AND batch_number = ? AND quantity <> 0", productID, batchNumber).
+		Order("warehouse_id ASC").
+		Find(&balances).Error
+	return balances, err
+}
+
+// Apply adds a ledger movement to its balance row, creating the row on first use
+func (r *stockBalanceRepository) Apply(entry *model.StockEntry) error {
+	balance, err := r.Get(entry.WarehouseID, entry.ProductID, entry.BatchNumber)
+	if err != nil {
+		return err
+	}
+	if balance == nil {
+		return r.DB().Create(&model.StockBalance{
+			WarehouseID: entry.WarehouseID,
+			ProductID:   entry.ProductID,
+			BatchNumber: entry.BatchNumber,
+			ExpiredAt:   entry.ExpiredAt,
+			ReceivedAt:  entry.Date,
+			Quantity:    entry.Quantity,
+			QCStatus:    entry.QCStatus,
+			LastEntryID: entry.ID,
+		}).Error
+	}
+
+	return r.DB().Model(balance).Updates(map[string]interface{}{
+		"quantity":      gorm.Expr("quantity + ?", entry.Quantity),
+		"expired_at":    entry.ExpiredAt,
+		"qc_status":     entry.QCStatus,
+		"last_entry_id": entry.ID,
+	}).Error
+}
+
+// Rebuild recomputes balances from the ledger. A nil warehouseID rebuilds every warehouse.
+func (r *stockBalanceRepository) Rebuild(warehouseID uuid.UUID) (int64, error) {
+	deleteQuery := r.DB().Where("1 = 1")
+	if warehouseID != uuid.Nil {
+		deleteQuery = r.DB().Where("warehouse_id = ?", warehouseID)
+	}
+	if err := deleteQuery.Delete(&model.StockBalance{}).Error; err != nil {
+		return 0, err
+	}
+
+	ledger := r.DB().Model(&model.StockEntry{}).
+		Select("warehouse_id, product_id, batch_number, MAX(expired_at) AS expired_at, MIN(date) AS received_at, SUM(quantity) AS quantity").
+		Group("warehouse_id, product_id, batch_number")
+	if warehouseID != uuid.Nil {
+		ledger = ledger.Where("warehouse_id = ?", warehouseID)
+	}
+
+	var balances []model.StockBalance
+	if err := ledger.Scan(&balances).Error; err != nil {
+		return 0, err
+	}
+	if len(balances) == 0 {
+		return 0, nil
+	}
+
+	for i := range balances {
+		var last model.StockEntry
+		err := r.DB().
+			Where("warehouse_id = ? AND product_id = ? AND batch_number = ?", balances[i].WarehouseID, balances[i].ProductID, balances[i].BatchNumber).
+			Order("created_at DESC").
+			First(&last).Error
+		if err != nil {
+			return 0, err
+		}
+		balances[i].QCStatus = last.QCStatus
+		balances[i].LastEntryID = last.ID
+	}
+
+	if err := r.DB().CreateInBatches(&balances, 500).Error; err != nil {
+		return 0, err
+	}
+	return int64(len(balances)), nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockCountRepository interface {
+	GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.StockCount, int64, error)
+	GetByID(id uuid.UUID) (*model.StockCount, error)
+	// Lock holds a row lock on the count until the transaction ends
+	Lock(id uuid.UUID) error
+	Create(count *model.StockCount) error
+	Update(count *model.StockCount) error
+	WithTx(tx *gorm.DB) StockCountRepository
+}
+
+type stockCountRepository struct {
+	*repository.Repository
+}
+
+func NewStockCountRepository(db *gorm.DB) StockCountRepository {
+	return &stockCountRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *stockCountRepository) WithTx(tx *gorm.DB) StockCountRepository {
+	return NewStockCountRepository(tx)
+}
+
+// Lock waits for concurrent changes to the count to finish and keeps others waiting until the
+// transaction ends. Load the count after locking to see their changes.
+func (r *stockCountRepository) Lock(id uuid.UUID) error {
+	return r.DB().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", id).Find(&model.StockCount{}).Error
+}
+
+func (r *stockCountRepository) GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.StockCount, int64, error) {
+	var counts []model.StockCount
+	var total int64
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	baseQuery := r.DB().Model(&model.StockCount{})
+	if warehouseID != uuid.Nil {
+		baseQuery = baseQuery.Where("warehouse_id = ?", warehouseID)
+	}
+	if status != "" {
+		baseQuery = baseQuery.Where("status = ?", status)
+	}
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := baseQuery.Preload("Lines").Order("created_at DESC").Limit(pageSize).Offset(offset).Find(&counts).Error; err != nil {
+		return nil, 0, err
+	}
+	return counts, total, nil
+}
+
+func (r *stockCountRepository) GetByID(id uuid.UUID) (*model.StockCount, error) {
+	var count model.StockCount
+	err := r.DB().
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		First(&count, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &count, nil
+}
+
+func (r *stockCountRepository) Create(count *model.StockCount) error {
+	return r.DB().Create(count).Error
+}
+
+// Update saves the count header together with its lines, inserting lines added during counting
+func (r *stockCountRepository) Update(count *model.StockCount) error {
+	return r.DB().Session(&gorm.Session{FullSaveAssociations: true}).Save(count).Error
+}
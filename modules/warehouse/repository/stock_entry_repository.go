@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StockEntryFilter narrows the stock ledger listing
+type StockEntryFilter struct {
+	WarehouseID uuid.UUID
+	ProductID   uuid.UUID
+	BatchNumber string
+	Status      string
+	ReasonCode  string
+}
+
+type StockEntryRepository interface {
+	GetAll(page, pageSize int, filter StockEntryFilter) ([]model.StockEntry, int64, error)
+	GetByID(id uuid.UUID) (*model.StockEntry, error)
+	GetLatest(warehouseID, productID uuid.UUID, batchNumber string) (*model.StockEntry, error)
+	// ListByReference returns every movement posted for a document, oldest first
+	ListByReference(referenceID uuid.UUID) ([]model.StockEntry, error)
+	// LockByReference holds row locks on the movements posted for a document until the transaction ends
+	LockByReference(referenceID uuid.UUID) error
+	// ListOutgoing returns the issues and transfers out of a product dated from..to
+	// (to exclusive), oldest first. A nil warehouseID covers every warehouse.
+	ListOutgoing(productID, warehouseID uuid.UUID, from, to time.Time) ([]model.StockEntry, error)
+	// ListByBatch returns every movement of a product batch in any warehouse, oldest first
+	ListByBatch(productID uuid.UUID, batchNumber string) ([]model.StockEntry, error)
+	Create(entry *model.StockEntry) error
+	WithTx(tx *gorm.DB) StockEntryRepository
+}
+
+type stockEntryRepository struct {
+	*repository.Repository
+}
+
+func NewStockEntryRepository(db *gorm.DB) StockEntryRepository {
+	return &stockEntryRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *stockEntryRepository) WithTx(tx *gorm.DB) StockEntryRepository {
+	return NewStockEntryRepository(tx)
+}
+
+func (r *stockEntryRepository) GetAll(page, pageSize int, filter StockEntryFilter) ([]model.StockEntry, int64, error) {
+	var entries []model.StockEntry
+	var total int64
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	baseQuery := r.DB().Model(&model.StockEntry{})
+	if filter.WarehouseID != uuid.Nil {
+		baseQuery = baseQuery.Where("warehouse_id = ?", filter.WarehouseID)
+	}
+	if filter.ProductID != uuid.Nil {
+		baseQuery = baseQuery.Where("product_id = ?", filter.ProductID)
+	}
+	if filter.BatchNumber != "" {
+		baseQuery = baseQuery.Where("batch_number = ?", filter.BatchNumber)
+	}
+	if filter.Status != "" {
+		baseQuery = baseQuery.Where("status = ?", filter.Status)
+	}
+	if filter.ReasonCode != "" {
+		baseQuery = baseQuery.Where("reason_code = ?", filter.ReasonCode)
+	}
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := baseQuery.Order("created_at DESC").Limit(pageSize).Offset(offset).Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+func (r *stockEntryRepository) GetByID(id uuid.UUID) (*model.StockEntry, error) {
+	var entry model.StockEntry
+	err := r.DB().First(&entry, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetLatest returns the most recent movement for a warehouse/product/batch, or nil if there is none
+func (r *stockEntryRepository) GetLatest(warehouseID, productID uuid.UUID, batchNumber string) (*model.StockEntry, error) {
+	var entry model.StockEntry
+	err := r.DB().
+		Where("warehouse_id = ? AND product_id = ? AND batch_number = ?", warehouseID, productID, batchNumber).
+		Order("created_at DESC").
+		First(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *stockEntryRepository) LockByReference(referenceID uuid.UUID) error {
+	var entries []model.StockEntry
+	return r.DB().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("reference_id = ?", referenceID).
+		Order("id ASC").
+		Find(&entries).Error
+}
+
+func (r *stockEntryRepository) ListByReference(referenceID uuid.UUID) ([]model.StockEntry, error) {
+	var entries []model.StockEntry
+	err := r.DB().Where("reference_id = ?", referenceID).Order("created_at ASC").Find(&entries).Error
+	return entries, err
+}
+
+func (r *stockEntryRepository) ListOutgoing(productID, warehouseID uuid.UUID, from, to time.Time) ([]model.StockEntry, error) {
+	var entries []model.StockEntry
+	query := r.DB().
+		Select("id, warehouse_id, product_id, date, quantity, status").
+		Where("product_id = ? AND status IN ? AND date >= ? AND date < ?", productID,
+			[]string{model.StockEntryStatusIssue, model.StockEntryStatusTransferOut}, from, to)
+	if warehouseID != uuid.Nil {
+		query = query.Where("warehouse_id = ?", warehouseID)
+	}
+	err := query.Order("date ASC").Find(&entries).Error
+	return entries, err
+}
+
+func (r *stockEntryRepository) ListByBatch(productID uuid.UUID, batchNumber string) ([]model.StockEntry, error) {
+	var entries []model.StockEntry
+	err := r.DB().
+		Where("product_id = ? AND batch_number = ?", productID, batchNumber).
+		Order("date ASC, created_at ASC").
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *stockEntryRepository) Create(entry *model.StockEntry) error {
+	return r.DB().Create(entry).Error
+}
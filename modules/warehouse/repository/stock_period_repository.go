@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StockPeriodRepository interface {
+	GetAll(page, pageSize int, status string) ([]model.StockPeriod, int64, error)
+	GetByID(id uuid.UUID) (*model.StockPeriod, error)
+	GetByPeriod(period string) (*model.StockPeriod, error)
+	// GetLatestClosed returns the closed period with the latest end, or nil if none is closed
+	GetLatestClosed() (*model.StockPeriod, error)
+	// GetLatestClosedAsOf returns the latest closed period that ended on or before asOf
+	GetLatestClosedAsOf(asOf time.Time) (*model.StockPeriod, error)
+	Create(period *model.StockPeriod) error
+	Update(period *model.StockPeriod) error
+	CreateBalanceSnapshots(snapshots []model.StockBalanceSnapshot) error
+	CreateValuationSnapshot(snapshot *model.StockValuationSnapshot) error
+	GetValuationSnapshot(periodID, officeID uuid.UUID) (*model.StockValuationSnapshot, error)
+	// DeleteSnapshots removes the balance and valuation snapshots of a period
+	DeleteSnapshots(periodID uuid.UUID) error
+	// LockForPosting holds a shared lock on the period calendar until the transaction
+	// ends. Movements take it before checking for a closed period.
+	LockForPosting() error
+	// LockForClosing holds the period calendar exclusively until the transaction ends,
+	// waiting for movements being posted and keeping new ones out
+	LockForClosing() error
+	WithTx(tx *gorm.DB) StockPeriodRepository
+}
+
+// stockPeriodLockKey identifies the advisory lock that serializes period closing with stock postings
+const stockPeriodLockKey int64 = 0x5354504552494f44 // "STPERIOD"
+
+type stockPeriodRepository struct {
+	*repository.Repository
+}
+
+func NewStockPeriodRepository(db *gorm.DB) StockPeriodRepository {
+	return &stockPeriodRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *stockPeriodRepository) WithTx(tx *gorm.DB) StockPeriodRepository {
+	return NewStockPeriodRepository(tx)
+}
+
+func (r *stockPeriodRepository) GetAll(page, pageSize int, status string) ([]model.StockPeriod, int64, error) {
+	var periods []model.StockPeriod
+	var total int64
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	baseQuery := r.DB().Model(&model.StockPeriod{})
+	if status != "" {
+		baseQuery = baseQuery.Where("status = ?", status)
+	}
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := baseQuery.Order("period DESC").Limit(pageSize).Offset(offset).Find(&periods).Error; err != nil {
+		return nil, 0, err
+	}
+	return periods, total, nil
+}
+
+func (r *stockPeriodRepository) GetByID(id uuid.UUID) (*model.StockPeriod, error) {
+	return r.first(r.DB().Where("id = ?", id))
+}
+
+func (r *stockPeriodRepository) GetByPeriod(period string) (*model.StockPeriod, error) {
+	return r.first(r.DB().Where("period = ?", period))
+}
+
+func (r *stockPeriodRepository) GetLatestClosed() (*model.StockPeriod, error) {
+	return r.first(r.DB().Where("status = ?", model.StockPeriodStatusClosed).Order("end_at DESC"))
+}
+
+func (r *stockPeriodRepository) GetLatestClosedAsOf(asOf time.Time) (*model.StockPeriod, error) {
+	return r.first(r.DB().Where("status = ? AND end_at <= ?", model.StockPeriodStatusClosed, asOf).Order("end_at DESC"))
+}
+
+func (r *stockPeriodRepository) first(query *gorm.DB) (*model.StockPeriod, error) {
+	var period model.StockPeriod
+	err := query.First(&period).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &period, nil
+}
+
+func (r *stockPeriodRepository) Create(period *model.StockPeriod) error {
+	return r.DB().Create(period).Error
+}
+
+func (r *stockPeriodRepository) Update(period *model.StockPeriod) error {
+	return r.DB().Save(period).Error
+}
+
+func (r *stockPeriodRepository) CreateBalanceSnapshots(snapshots []model.StockBalanceSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	return r.DB().CreateInBatches(&snapshots, 500).Error
+}
+
+func (r *stockPeriodRepository) CreateValuationSnapshot(snapshot *model.StockValuationSnapshot) error {
+	return r.DB().Create(snapshot).Error
+}
+
+func (r *stockPeriodRepository) GetValuationSnapshot(periodID, officeID uuid.UUID) (*model.StockValuationSnapshot, error) {
+	var snapshot model.StockValuationSnapshot
+	err := r.DB().Where("period_id = ? AND office_id = ?", periodID, officeID).First(&snapshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+func (r *stockPeriodRepository) DeleteSnapshots(periodID uuid.UUID) error {
+	if err := r.DB().Where("period_id = ?", periodID).Delete(&model.StockBalanceSnapshot{}).Error; err != nil {
+		return err
+	}
+	return r.DB().Where("period_id = ?", periodID).Delete(&model.StockValuationSnapshot{}).Error
+}
+
+func (r *stockPeriodRepository) LockForPosting() error {
+	return r.DB().Exec("SELECT pg_advisory_xact_lock_shared(?)", stockPeriodLockKey).Error
+}
+
+func (r *stockPeriodRepository) LockForClosing() error {
+	return r.DB().Exec("SELECT pg_advisory_xact_lock(?)", stockPeriodLockKey).Error
+}
@@ -0,0 +1,424 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ExpiringBatchRow is one batch with remaining stock and a known expiry date,
+// flattened together with its warehouse, branch and office
+type ExpiringBatchRow struct {
+	OfficeID      *uuid.UUID
+	OfficeName    string
+	BranchID      *uuid.UUID
+	BranchName    string
+	WarehouseID   uuid.UUID
+	WarehouseName string
+	ProductID     uuid.UUID
+	ProductCode   string
+	ProductName   string
+	BatchNumber   string
+	ExpiredAt     time.Time
+	Quantity      int
+	Price         float64
+}
+
+// ShrinkageRow is the stock removed under one adjustment reason in one warehouse
+type ShrinkageRow struct {
+	ReasonCode    string
+	ReasonName    string
+	WarehouseID   uuid.UUID
+	WarehouseName string
+	Movements     int
+	Quantity      int
+	Value         float64
+}
+
+// StockCardFilter selects the ledger rows of a stock card. An empty batch
+// number covers every batch and a zero From starts at the first movement.
+type StockCardFilter struct {
+	WarehouseID uuid.UUID
+	ProductID   uuid.UUID
+	BatchNumber string
+	From        time.Time
+	To          time.Time
+}
+
+// StockCardSummary is the balance before the period and the movement totals within it
+type StockCardSummary struct {
+	Opening  int
+	TotalIn  int
+	TotalOut int
+}
+
+// StockCardRow is one ledger movement with the sum of all period movements up to and including it
+type StockCardRow struct {
+	EntryID     uuid.UUID
+	Date        time.Time
+	Status      string
+	ReferenceID uuid.UUID
+	OrderID     uuid.UUID
+	ReasonCode  string
+	Notes       string
+	BatchNumber string
+	ExpiredAt   time.Time
+	Quantity    int
+	Cumulative  int
+	CreatedBy   uint
+	Username    string
+}
+
+// ConsumptionRow is the quantity and cost of one product issued within a period
+type ConsumptionRow struct {
+	ProductID    uuid.UUID
+	ProductCode  string
+	ProductName  string
+	CategoryID   uuid.UUID
+	CategoryName string
+	Quantity     int
+	Value        float64
+}
+
+// StockMovementRow is the on-hand stock of a product in a warehouse together
+// with when it last left the warehouse and how much left since a given date
+type StockMovementRow struct {
+	CategoryID       uuid.UUID
+	CategoryName     string
+	ProductID        uuid.UUID
+	ProductCode      string
+	ProductName      string
+	WarehouseID      uuid.UUID
+	WarehouseName    string
+	Quantity         int
+	Value            float64
+	ReceivedAt       time.Time
+	LastOutgoingAt   *time.Time
+	OutgoingQuantity int
+}
+
+// ControlledRegisterFilter selects the controlled products of a register
+// period. Empty office and class cover every office and class.
+type ControlledRegisterFilter struct {
+	From     time.Time
+	To       time.Time
+	OfficeID uuid.UUID
+	Class    string
+}
+
+// ControlledRegisterRow is the balance of one controlled product in one office
+// before the period and its movement totals within it. Transfers between
+// warehouses of the same office are kept out of the in and out totals.
+type ControlledRegisterRow struct {
+	OfficeID         *uuid.UUID
+	OfficeName       string
+	ProductID        uuid.UUID
+	ProductCode      string
+	ProductName      string
+	ControlledClass  string
+	Unit             string
+	Opening          int
+	QuantityIn       int
+	QuantityOut      int
+	InternalTransfer int // Net of transfers within the office, non-zero while goods are in transit
+}
+
+// ControlledMovementRow is one movement of a controlled product within a register period
+type ControlledMovementRow struct {
+	OfficeID         *uuid.UUID
+	ProductID        uuid.UUID
+	EntryID          uuid.UUID
+	Date             time.Time
+	WarehouseName    string
+	Status           string
+	BatchNumber      string
+	ReferenceID      uuid.UUID
+	Quantity         int
+	Internal         bool
+	Counterparty     string
+	AuthorizedBy     uint
+	AuthorizedByName string
+	CreatedBy        uint
+	Username         string
+	Notes            string
+}
+
+type StockReportRepository interface {
+	// GetExpiringBatches returns batches with stock that expire on or before the given time
+	GetExpiringBatches(before time.Time, officeID uuid.UUID) ([]ExpiringBatchRow, error)
+	// GetShrinkage sums outgoing movements that carry a reason code, dated from..to.
+	// A zero from leaves the lower bound open.
+	GetShrinkage(from, to time.Time, warehouseID, officeID uuid.UUID) ([]ShrinkageRow, error)
+	GetStockCardSummary(filter StockCardFilter) (StockCardSummary, error)
+	GetStockCard(filter StockCardFilter, page, pageSize int) ([]StockCardRow, int64, error)
+	// ListStockCard returns every row of the period, unpaginated, for exports
+	ListStockCard(filter StockCardFilter) ([]StockCardRow, error)
+	// GetConsumption sums issues per product dated from..to, highest value first
+	GetConsumption(from, to time.Time, warehouseID, officeID uuid.UUID) ([]ConsumptionRow, error)
+	// GetStockMovement returns every warehouse/product with stock on hand, ordered by category,
+	// counting outgoing movements (issues and transfers out) dated on or after since
+	GetStockMovement(since time.Time, warehouseID, officeID uuid.UUID) ([]StockMovementRow, error)
+	// GetControlledRegister returns every controlled product per office with stock
+	// before the period or movements within it, ordered by office and product
+	GetControlledRegister(filter ControlledRegisterFilter) ([]ControlledRegisterRow, error)
+	// ListControlledMovements returns the movements of the register period in the same
+	// order as GetControlledRegister, oldest first within each product
+	ListControlledMovements(filter ControlledRegisterFilter) ([]ControlledMovementRow, error)
+}
+
+type stockReportRepository struct {
+	*repository.Repository
+}
+
+func NewStockReportRepository(db *gorm.DB) StockReportRepository {
+	return &stockReportRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+func (r *stockReportRepository) GetExpiringBatches(before time.Time, officeID uuid.UUID) ([]ExpiringBatchRow, error) {
+	var rows []ExpiringBatchRow
+
+	query := r.DB().Table("stock_balances AS sb").
+		Select(`offices.id AS office_id, offices.name AS office_name,
+			branches.id AS branch_id, branches.name AS branch_name,
+			warehouses.id AS warehouse_id, warehouses.name AS warehouse_name,
+			products.id AS product_id, products.code AS product_code, products.name AS product_name,
+			sb.batch_number, sb.expired_at, sb.quantity, COALESCE(se.price, products.purchase_price) AS price`).
+		Joins("JOIN warehouses ON warehouses.id = sb.warehouse_id").
+		Joins("LEFT JOIN branches ON branches.id = warehouses.branch_id").
+		Joins("LEFT JOIN offices ON offices.id = COALESCE(warehouses.office_id, branches.office_id)").
+		Joins("JOIN products ON products.id = sb.product_id").
+		Joins("LEFT JOIN stock_entries AS se ON se.id = sb.last_entry_id").
+		Where("sb.quantity > 0 AND sb.expired_at > ? AND sb.expired_at <= ?", time.Time{}, before)
+	if officeID != uuid.Nil {
+		query = query.Where("offices.id = ?", officeID)
+	}
+
+	err := query.
+		Order("offices.name ASC, offices.id ASC, branches.name ASC, branches.id ASC, warehouses.name ASC, warehouses.id ASC, sb.expired_at ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *stockReportRepository) GetShrinkage(from, to time.Time, warehouseID, officeID uuid.UUID) ([]ShrinkageRow, error) {
+	var rows []ShrinkageRow
+
+	query := r.DB().Table("stock_entries AS se").
+		Select(`se.reason_code, COALESCE(adjustment_reasons.name, se.reason_code) AS reason_name,
+			warehouses.id AS warehouse_id, warehouses.name AS warehouse_name,
+			COUNT(*) AS movements, SUM(-se.quantity) AS quantity, SUM(-se.quantity * se.price) AS value`).
+		Joins("JOIN warehouses ON warehouses.id = se.warehouse_id").
+		Joins("LEFT JOIN branches ON branches.id = warehouses.branch_id").
+		Joins("LEFT JOIN adjustment_reasons ON adjustment_reasons.code = se.reason_code").
+		Where("se.quantity < 0 AND se.reason_code <> '' AND se.date <= ?", to)
+	if !from.IsZero() {
+		query = query.Where("se.date >= ?", from)
+	}
+	if warehouseID != uuid.Nil {
+		query = query.Where("se.warehouse_id = ?", warehouseID)
+	}
+	if officeID != uuid.Nil {
+		query = query.Where("COALESCE(warehouses.office_id, branches.office_id) = ?", officeID)
+	}
+
+	err := query.
+		Group("se.reason_code, adjustment_reasons.name, warehouses.id, warehouses.name").
+		Order("se.reason_code ASC, warehouses.name ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *stockReportRepository) GetStockCardSummary(filter StockCardFilter) (StockCardSummary, error) {
+	var summary StockCardSummary
+
+	query := r.DB().Model(&model.StockEntry{}).
+		Select(`COALESCE(SUM(CASE WHEN date < ? THEN quantity END), 0) AS opening,
+			COALESCE(SUM(CASE WHEN date >= ? AND quantity > 0 THEN quantity END), 0) AS total_in,
+			COALESCE(SUM(CASE WHEN date >= ? AND quantity < 0 THEN -quantity END), 0) AS total_out`,
+			filter.From, filter.From, filter.From).
+		Where("warehouse_id = ? AND product_id = ? AND date <= ?", filter.WarehouseID, filter.ProductID, filter.To)
+	if filter.BatchNumber != "" {
+		query = query.Where("batch_number = ?", filter.BatchNumber)
+	}
+
+	err := query.Scan(&summary).Error
+	return summary, err
+}
+
+func (r *stockReportRepository) GetStockCard(filter StockCardFilter, page, pageSize int) ([]StockCardRow, int64, error) {
+	var rows []StockCardRow
+	var total int64
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	if err := r.stockCardScope(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := r.stockCardQuery(filter).Limit(pageSize).Offset(offset).Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+func (r *stockReportRepository) ListStockCard(filter StockCardFilter) ([]StockCardRow, error) {
+	var rows []StockCardRow
+	err := r.stockCardQuery(filter).Scan(&rows).Error
+	return rows, err
+}
+
+// stockCardScope limits the ledger to the movements of a stock card period
+func (r *stockReportRepository) stockCardScope(filter StockCardFilter) *gorm.DB {
+	query := r.DB().Table("stock_entries AS se").
+		Where("se.warehouse_id = ? AND se.product_id = ? AND se.date >= ? AND se.date <= ?", filter.WarehouseID, filter.ProductID, filter.From, filter.To)
+	if filter.BatchNumber != "" {
+		query = query.Where("se.batch_number = ?", filter.BatchNumber)
+	}
+	return query
+}
+
+// stockCardQuery selects the period rows in ledger order. The running sum is a
+// window over the whole period so it stays correct on every page.
+func (r *stockReportRepository) stockCardQuery(filter StockCardFilter) *gorm.DB {
+	return r.stockCardScope(filter).
+		Select(`se.id AS entry_id, se.date, se.status, se.reference_id, se.order_id, se.reason_code, se.notes,
+			se.batch_number, se.expired_at, se.quantity,
+			SUM(se.quantity) OVER (ORDER BY se.date ASC, se.created_at ASC, se.id ASC) AS cumulative,
+			se.created_by, COALESCE(users.username, '') AS username`).
+		Joins("LEFT JOIN users ON users.id = se.created_by").
+		Order("se.date ASC, se.created_at ASC, se.id ASC")
+}
+
+func (r *stockReportRepository) GetConsumption(from, to time.Time, warehouseID, officeID uuid.UUID) ([]ConsumptionRow, error) {
+	var rows []ConsumptionRow
+
+	query := r.DB().Table("stock_entries AS se").
+		Select(`products.id AS product_id, products.code AS product_code, products.name AS product_name,
+			category_products.id AS category_id, category_products.name AS category_name,
+			SUM(-se.quantity) AS quantity, SUM(-se.quantity * se.price) AS value`).
+		Joins("JOIN warehouses ON warehouses.id = se.warehouse_id").
+		Joins("LEFT JOIN branches ON branches.id = warehouses.branch_id").
+		Joins("JOIN products ON products.id = se.product_id").
+		Joins("LEFT JOIN category_products ON category_products.id = products.category_id").
+		Where("se.status = ? AND se.date >= ? AND se.date <= ?", model.StockEntryStatusIssue, from, to)
+	if warehouseID != uuid.Nil {
+		query = query.Where("se.warehouse_id = ?", warehouseID)
+	}
+	if officeID != uuid.Nil {
+		query = query.Where("COALESCE(warehouses.office_id, branches.office_id) = ?", officeID)
+	}
+
+	err := query.
+		Group("products.id, products.code, products.name, category_products.id, category_products.name").
+		Order("value DESC, products.code ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *stockReportRepository) GetStockMovement(since time.Time, warehouseID, officeID uuid.UUID) ([]StockMovementRow, error) {
+	var rows []StockMovementRow
+
+	outgoing := []string{model.StockEntryStatusIssue, model.StockEntryStatusTransferOut}
+	query := r.DB().Table("stock_balances AS sb").
+		Select(`category_products.id AS category_id, category_products.name AS category_name,
+			products.id AS product_id, products.code AS product_code, products.name AS product_name,
+			warehouses.id AS warehouse_id, warehouses.name AS warehouse_name,
+			SUM(sb.quantity) AS quantity, SUM(sb.quantity * COALESCE(se.price, products.purchase_price)) AS value,
+			MIN(sb.received_at) AS received_at,
+			(SELECT MAX(o.date) FROM stock_entries AS o
+				WHERE o.warehouse_id = sb.warehouse_id AND o.product_id = sb.product_id AND o.status IN ?) AS last_outgoing_at,
+			(SELECT COALESCE(SUM(-o.quantity), 0) FROM stock_entries AS o
+				WHERE o.warehouse_id = sb.warehouse_id AND o.product_id = sb.product_id AND o.status IN ? AND o.date >= ?) AS outgoing_quantity`,
+			outgoing, outgoing, since).
+		Joins("JOIN warehouses ON warehouses.id = sb.warehouse_id").
+		Joins("LEFT JOIN branches ON branches.id = warehouses.branch_id").
+		Joins("JOIN products ON products.id = sb.product_id").
+		Joins("LEFT JOIN category_products ON category_products.id = products.category_id").
+		Joins("LEFT JOIN stock_entries AS se ON se.id = sb.last_entry_id").
+		Where("sb.quantity > 0")
+	if warehouseID != uuid.Nil {
+		query = query.Where("sb.warehouse_id = ?", warehouseID)
+	}
+	if officeID != uuid.Nil {
+		query = query.Where("COALESCE(warehouses.office_id, branches.office_id) = ?", officeID)
+	}
+
+	err := query.
+		Group("sb.warehouse_id, sb.product_id, category_products.id, category_products.name, products.id, products.code, products.name, warehouses.id, warehouses.name").
+		Order("category_products.name ASC, category_products.id ASC, products.code ASC, warehouses.name ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// internalTransfer holds for stock entries of a transfer between two warehouses
+// of the same office. It needs the joins of controlledScope.
+const internalTransfer = `(st.id IS NOT NULL AND
+	COALESCE(source.office_id, source_branch.office_id) IS NOT DISTINCT FROM COALESCE(destination.office_id, destination_branch.office_id))`
+
+func (r *stockReportRepository) GetControlledRegister(filter ControlledRegisterFilter) ([]ControlledRegisterRow, error) {
+	var rows []ControlledRegisterRow
+
+	err := r.controlledScope(filter).
+		Select(`offices.id AS office_id, offices.name AS office_name,
+			products.id AS product_id, products.code AS product_code, products.name AS product_name,
+			products.controlled_class, products.small_unit AS unit,
+			COALESCE(SUM(CASE WHEN se.date < ? THEN se.quantity END), 0) AS opening,
+			COALESCE(SUM(CASE WHEN se.date >= ? AND se.quantity > 0 AND NOT `+internalTransfer+` THEN se.quantity END), 0) AS quantity_in,
+			COALESCE(SUM(CASE WHEN se.date >= ? AND se.quantity < 0 AND NOT `+internalTransfer+` THEN -se.quantity END), 0) AS quantity_out,
+			COALESCE(SUM(CASE WHEN se.date >= ? AND `+internalTransfer+` THEN se.quantity END), 0) AS internal_transfer`,
+			filter.From, filter.From, filter.From, filter.From).
+		Where("se.date <= ?", filter.To).
+		Group("offices.id, offices.name, products.id, products.code, products.name, products.controlled_class, products.small_unit").
+		Having("COALESCE(SUM(CASE WHEN se.date < ? THEN se.quantity END), 0) <> 0 OR COUNT(CASE WHEN se.date >= ? THEN 1 END) > 0", filter.From, filter.From).
+		Order("offices.name ASC, offices.id ASC, products.name ASC, products.id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *stockReportRepository) ListControlledMovements(filter ControlledRegisterFilter) ([]ControlledMovementRow, error) {
+	var rows []ControlledMovementRow
+
+	err := r.controlledScope(filter).
+		Select(`offices.id AS office_id, products.id AS product_id,
+			se.id AS entry_id, se.date, warehouses.name AS warehouse_name, se.status, se.batch_number, se.reference_id,
+			se.quantity, `+internalTransfer+` AS internal,
+			se.counterparty, se.authorized_by, COALESCE(authorizers.username, '') AS authorized_by_name,
+			se.created_by, COALESCE(users.username, '') AS username, se.notes`).
+		Joins("LEFT JOIN users ON users.id = se.created_by").
+		Joins("LEFT JOIN users AS authorizers ON authorizers.id = se.authorized_by").
+		Where("se.date >= ? AND se.date <= ? AND se.quantity <> 0", filter.From, filter.To).
+		Order("offices.name ASC, offices.id ASC, products.name ASC, products.id ASC, se.date ASC, se.created_at ASC, se.id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// controlledScope joins the ledger of controlled products to their office and,
+// for transfers, to the offices on both sides of the transfer
+func (r *stockReportRepository) controlledScope(filter ControlledRegisterFilter) *gorm.DB {
+	query := r.DB().Table("stock_entries AS se").
+		Joins("JOIN products ON products.id = se.product_id AND products.controlled").
+		Joins("JOIN warehouses ON warehouses.id = se.warehouse_id").
+		Joins("LEFT JOIN branches ON branches.id = warehouses.branch_id").
+		Joins("LEFT JOIN offices ON offices.id = COALESCE(warehouses.office_id, branches.office_id)").
+		Joins("LEFT JOIN stock_transfers AS st ON se.status IN ? AND st.id = se.reference_id",
+			[]string{model.StockEntryStatusTransferOut, model.StockEntryStatusTransferIn}).
+		Joins("LEFT JOIN warehouses AS source ON source.id = st.source_warehouse_id").
+		Joins("LEFT JOIN branches AS source_branch ON source_branch.id = source.branch_id").
+		Joins("LEFT JOIN warehouses AS destination ON destination.id = st.destination_warehouse_id").
+		Joins("LEFT JOIN branches AS destination_branch ON destination_branch.id = destination.branch_id")
+	if filter.OfficeID != uuid.Nil {
+		query = query.Where("offices.id = ?", filter.OfficeID)
+	}
+	if filter.Class != "" {
+		query = query.Where("products.controlled_class = ?", filter.Class)
+	}
+	return query
+}
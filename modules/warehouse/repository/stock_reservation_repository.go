@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockReservationFilter struct {
+	WarehouseID uuid.UUID
+	ProductID   uuid.UUID
+	ReferenceID uuid.UUID
+	Status      string
+}
+
+// AvailabilityRow is the available-to-promise stock of a product in a warehouse.
+// OnHand leaves out expired batches and batches not released by QC, which cannot be issued.
+type AvailabilityRow struct {
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	WarehouseName string    `json:"warehouse_name"`
+	ProductID     uuid.UUID `json:"product_id"`
+	ProductCode   string    `json:"product_code"`
+	ProductName   string    `json:"product_name"`
+	OnHand        int       `json:"on_hand"`
+	Reserved      int       `json:"reserved"`
+	Available     int       `json:"available"`
+}
+
+type StockReservationRepository interface {
+	GetAll(page, pageSize int, filter StockReservationFilter) ([]model.StockReservation, int64, error)
+	GetByID(id uuid.UUID) (*model.StockReservation, error)
+	// Lock holds a row lock on the reservation until the transaction ends
+	Lock(id uuid.UUID) error
+	// GetActiveByReference returns the unexpired active reservations held by a document
+	GetActiveByReference(referenceID uuid.UUID) ([]model.StockReservation, error)
+	Create(reservation *model.StockReservation) error
+	Update(reservation *model.StockReservation) error
+	// ExpireOverdue marks active reservations past their expiry as expired
+	ExpireOverdue(now time.Time) (int64, error)
+	// GetAvailability returns on-hand, reserved and available stock per warehouse and product.
+	// Nil IDs leave the corresponding filter out.
+	GetAvailability(warehouseID, productID, officeID uuid.UUID) ([]AvailabilityRow, error)
+	WithTx(tx *gorm.DB) StockReservationRepository
+}
+
+type stockReservationRepository struct {
+	*repository.Repository
+}
+
+func NewStockReservationRepository(db *gorm.DB) StockReservationRepository {
+	return &stockReservationRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *stockReservationRepository) WithTx(tx *gorm.DB) StockReservationRepository {
+	return NewStockReservationRepository(tx)
+}
+
+// Lock waits for concurrent releases or fulfilments of the reservation to finish and keeps
+// others waiting until the transaction ends. Load the reservation after locking to see their changes.
+func (r *stockReservationRepository) Lock(id uuid.UUID) error {
+	return r.DB().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", id).Find(&model.StockReservation{}).Error
+}
+
+func (r *stockReservationRepository) GetAll(page, pageSize int, filter StockReservationFilter) ([]model.StockReservation, int64, error) {
+	var reservations []model.StockReservation
+	var total int64
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	baseQuery := r.DB().Model(&model.StockReservation{})
+	if filter.WarehouseID != uuid.Nil {
+		baseQuery = baseQuery.Where("warehouse_id = ?", filter.WarehouseID)
+	}
+	if filter.ProductID != uuid.Nil {
+		baseQuery = baseQuery.Where("product_id = ?", filter.ProductID)
+	}
+	if filter.ReferenceID != uuid.Nil {
+		baseQuery = baseQuery.Where("reference_id = ?", filter.ReferenceID)
+	}
+	if filter.Status != "" {
+		baseQuery = baseQuery.Where("status = ?", filter.Status)
+	}
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := baseQuery.Order("created_at DESC").Limit(pageSize).Offset(offset).Find(&reservations).Error; err != nil {
+		return nil, 0, err
+	}
+	return reservations, total, nil
+}
+
+func (r *stockReservationRepository) GetByID(id uuid.UUID) (*model.StockReservation, error) {
+	var reservation model.StockReservation
+	err := r.DB().First(&reservation, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+func (r *stockReservationRepository) GetActiveByReference(referenceID uuid.UUID) ([]model.StockReservation, error) {
+	var reservations []model.StockReservation
+	err := r.activeScope(r.DB(), time.Now()).
+		Where("reference_id = ?", referenceID).
+		Find(&reservations).Error
+	return reservations, err
+}
+
+func (r *stockReservationRepository) Create(reservation *model.StockReservation) error {
+	return r.DB().Create(reservation).Error
+}
+
+func (r *stockReservationRepository) Update(reservation *model.StockReservation) error {
+	return r.DB().Save(reservation).Error
+}
+
+func (r *stockReservationRepository) ExpireOverdue(now time.Time) (int64, error) {
+	result := r.DB().Model(&model.StockReservation{}).
+		Where("status = ? AND expires_at IS NOT NULL AND expires_at <= ?", model.StockReservationStatusActive, now).
+		Update("status", model.StockReservationStatusExpired)
+	return result.RowsAffected, result.Error
+}
+
+func (r *stockReservationRepository) GetAvailability(warehouseID, productID, officeID uuid.UUID) ([]AvailabilityRow, error) {
+	var rows []AvailabilityRow
+	now := time.Now()
+
+	onHand := r.DB().Model(&model.StockBalance{}).
+		Select("warehouse_id, product_id, SUM(quantity) AS on_hand").
+		Where("qc_status = ? AND (expired_at <= ? OR expired_at > ?)", model.QCStatusReleased, time.Time{}, now).
+		Group("warehouse_id, product_id")
+	reserved := r.activeScope(r.DB().Model(&model.StockReservation{}), now).
+		Select("warehouse_id, product_id, SUM(quantity) AS reserved").
+		Group("warehouse_id, product_id")
+
+	query := r.DB().Table("(?) AS oh", onHand).
+		Select(`warehouses.id AS warehouse_id, warehouses.name AS warehouse_name,
+			products.id AS product_id, products.code AS product_code, products.name AS product_name,
+			oh.on_hand, COALESCE(rs.reserved, 0) AS reserved, oh.on_hand - COALESCE(rs.reserved, 0) AS available`).
+		Joins("JOIN warehouses ON warehouses.id = oh.warehouse_id").
+		Joins("LEFT JOIN branches ON branches.id = warehouses.branch_id").
+		Joins("JOIN products ON products.id = oh.product_id").
+		Joins("LEFT JOIN (?) AS rs ON rs.warehouse_id = oh.warehouse_id AND rs.product_id = oh.product_id", reserved).
+		Where("oh.on_hand <> 0 OR rs.reserved > 0")
+	if warehouseID != uuid.Nil {
+		query = query.Where("oh.warehouse_id = ?", warehouseID)
+	}
+	if productID != uuid.Nil {
+		query = query.Where("oh.product_id = ?", productID)
+	}
+	if officeID != uuid.Nil {
+		query = query.Where("COALESCE(warehouses.office_id, branches.office_id) = ?", officeID)
+	}
+
+	err := query.Order("products.name ASC, warehouses.name ASC").Scan(&rows).Error
+	return rows, err
+}
+
+// activeScope limits a query to active reservations that have not expired yet
+func (r *stockReservationRepository) activeScope(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.Where("status = ? AND (expires_at IS NULL OR expires_at > ?)", model.StockReservationStatusActive, now)
+}
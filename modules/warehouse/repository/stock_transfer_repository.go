@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockTransferRepository interface {
+	GetAll(page, pageSize int, status string) ([]model.StockTransfer, int64, error)
+	GetByID(id uuid.UUID) (*model.StockTransfer, error)
+	Create(transfer *model.StockTransfer) error
+	Update(transfer *model.StockTransfer) error
+	ReplaceLines(transfer *model.StockTransfer) error
+	// Lock holds a row lock on the transfer until the transaction ends
+	Lock(id uuid.UUID) error
+	WithTx(tx *gorm.DB) StockTransferRepository
+}
+
+type stockTransferRepository struct {
+	*repository.Repository
+}
+
+func NewStockTransferRepository(db *gorm.DB) StockTransferRepository {
+	return &stockTransferRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *stockTransferRepository) WithTx(tx *gorm.DB) StockTransferRepository {
+	return NewStockTransferRepository(tx)
+}
+
+// Lock waits for concurrent changes to the transfer to finish and keeps others waiting until the
+// transaction ends. Load the transfer after locking to see their changes.
+func (r *stockTransferRepository) Lock(id uuid.UUID) error {
+	return r.DB().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", id).Find(&model.StockTransfer{}).Error
+}
+
+func (r *stockTransferRepository) GetAll(page, pageSize int, status string) ([]model.StockTransfer, int64, error) {
+	var transfers []model.StockTransfer
+	var total int64
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	baseQuery := r.DB().Model(&model.StockTransfer{})
+	if status != "" {
+		baseQuery = baseQuery.Where("status = ?", status)
+	}
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := baseQuery.Preload("Lines").Order("created_at DESC").Limit(pageSize).Offset(offset).Find(&transfers).Error; err != nil {
+		return nil, 0, err
+	}
+	return transfers, total, nil
+}
+
+func (r *stockTransferRepository) GetByID(id uuid.UUID) (*model.StockTransfer, error) {
+	var transfer model.StockTransfer
+	err := r.DB().Preload("Lines").First(&transfer, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+func (r *stockTransferRepository) Create(transfer *model.StockTransfer) error {
+	return r.DB().Create(transfer).Error
+}
+
+// Update saves the transfer header together with its existing lines
+func (r *stockTransferRepository) Update(transfer *model.StockTransfer) error {
+	return r.DB().Session(&gorm.Session{FullSaveAssociations: true}).Save(transfer).Error
+}
+
+// ReplaceLines deletes the stored lines of a transfer and inserts the given ones
+func (r *stockTransferRepository) ReplaceLines(transfer *model.StockTransfer) error {
+	if err := r.DB().Where("transfer_id = ?", transfer.ID).Delete(&model.StockTransferLine{}).Error; err != nil {
+		return err
+	}
+	for i := range transfer.Lines {
+		transfer.Lines[i].ID = uuid.Nil
+		transfer.Lines[i].TransferID = transfer.ID
+	}
+	if len(transfer.Lines) == 0 {
+		return nil
+	}
+	return r.DB().Create(&transfer.Lines).Error
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ValuationEntryRow is one ledger movement together with the warehouse,
+// the valuation method of its office and the product category
+type ValuationEntryRow struct {
+	WarehouseID     uuid.UUID
+	WarehouseName   string
+	OfficeID        *uuid.UUID
+	ValuationMethod string
+	ProductID       uuid.UUID
+	PurchasePrice   float64
+	CategoryID      uuid.UUID
+	CategoryName    string
+	Date            time.Time
+	Quantity        int
+	Price           float64
+	Status          string
+}
+
+type StockValuationRepository interface {
+	// GetLedger returns movements dated on or before asOf, ordered by warehouse, product and date.
+	// Receipts carry their landed costs in effect as of asOf in the unit price.
+	GetLedger(asOf time.Time, officeID uuid.UUID) ([]ValuationEntryRow, error)
+	WithTx(tx *gorm.DB) StockValuationRepository
+}
+
+type stockValuationRepository struct {
+	*repository.Repository
+}
+
+func NewStockValuationRepository(db *gorm.DB) StockValuationRepository {
+	return &stockValuationRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *stockValuationRepository) WithTx(tx *gorm.DB) StockValuationRepository {
+	return NewStockValuationRepository(tx)
+}
+
+func (r *stockValuationRepository) GetLedger(asOf time.Time, officeID uuid.UUID) ([]ValuationEntryRow, error) {
+	var rows []ValuationEntryRow
+
+	query := r.DB().Table("stock_entries AS se").
+		Select(`warehouses.id AS warehouse_id, warehouses.name AS warehouse_name,
+			offices.id AS office_id, COALESCE(offices.valuation_method, 'average') AS valuation_method,
+			products.id AS product_id, products.purchase_price,
+			category_products.id AS category_id, category_products.name AS category_name,
+			se.date, se.quantity, se.status,
+			se.price + COALESCE(landed.unit_cost, 0) AS price`).
+		Joins("JOIN warehouses ON warehouses.id = se.warehouse_id").
+		Joins("LEFT JOIN branches ON branches.id = warehouses.branch_id").
+		Joins("LEFT JOIN offices ON offices.id = COALESCE(warehouses.office_id, branches.office_id)").
+		Joins("JOIN products ON products.id = se.product_id").
+		Joins("LEFT JOIN category_products ON category_products.id = products.category_id").
+		Joins(`LEFT JOIN (
+			SELECT lcl.stock_entry_id, SUM(lcl.unit_cost) AS unit_cost
+			FROM landed_cost_lines lcl
+			JOIN landed_costs lc ON lc.id = lcl.landed_cost_id
+			WHERE lc.status IN ? AND lc.date <= ? AND (lc.reversed_at IS NULL OR lc.reversed_at > ?)
+			GROUP BY lcl.stock_entry_id
+		) AS landed ON landed.stock_entry_id = se.id`,
+			[]string{model.LandedCostStatusPosted, model.LandedCostStatusReversed}, asOf, asOf).
+		Where("se.date <= ?", asOf)
+	if officeID != uuid.Nil {
+		query = query.Where("offices.id = ?", officeID)
+	}
+
+	err := query.
+		Order("warehouses.name ASC, warehouses.id ASC, se.product_id ASC, se.date ASC, se.created_at ASC").
+		Scan(&rows).Error
+	return rows, err
+}
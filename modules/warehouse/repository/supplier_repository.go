@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"github.com/antoniusDoni/monorepo/shared/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SupplierRepository interface {
+	GetAll(page, pageSize int, searchTerm, status string) ([]model.Supplier, int64, error)
+	GetByID(id uuid.UUID) (*model.Supplier, error)
+	GetByCode(code string) (*model.Supplier, error)
+	Create(supplier *model.Supplier) error
+	Update(supplier *model.Supplier) error
+	ReplaceContacts(supplier *model.Supplier) error
+	Delete(id uuid.UUID) error
+	WithTx(tx *gorm.DB) SupplierRepository
+}
+
+type supplierRepository struct {
+	*repository.Repository
+}
+
+func NewSupplierRepository(db *gorm.DB) SupplierRepository {
+	return &supplierRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *supplierRepository) WithTx(tx *gorm.DB) SupplierRepository {
+	return NewSupplierRepository(tx)
+}
+
+func (r *supplierRepository) GetAll(page, pageSize int, searchTerm, status string) ([]model.Supplier, int64, error) {
+	var suppliers []model.Supplier
+	var total int64
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	baseQuery := r.DB().Model(&model.Supplier{})
+	if searchTerm != "" {
+		searchTerm = utils.SanitizeSearchTerm(searchTerm)
+		like := "%" + searchTerm + "%"
+		baseQuery = baseQuery.Where("code ILIKE ? OR name ILIKE ? OR city ILIKE ?", like, like, like)
+	}
+	if status != "" {
+		baseQuery = baseQuery.Where("status = ?", status)
+	}
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := baseQuery.Preload("Contacts").Order("name ASC").Limit(pageSize).Offset(offset).Find(&suppliers).Error; err != nil {
+		return nil, 0, err
+	}
+	return suppliers, total, nil
+}
+
+func (r *supplierRepository) GetByID(id uuid.UUID) (*model.Supplier, error) {
+	var supplier model.Supplier
+	err := r.DB().Preload("Contacts").First(&supplier, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // not found, return nil object and nil error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &supplier, nil
+}
+
+func (r *supplierRepository) GetByCode(code string) (*model.Supplier, error) {
+	var supplier model.Supplier
+	err := r.DB().First(&supplier, "code = ?", code).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &supplier, nil
+}
+
+func (r *supplierRepository) Create(supplier *model.Supplier) error {
+	return r.DB().Create(supplier).Error
+}
+
+// Update saves the supplier header; contacts are replaced through ReplaceContacts
+func (r *supplierRepository) Update(supplier *model.Supplier) error {
+	return r.DB().Omit("Contacts").Save(supplier).Error
+}
+
+// ReplaceContacts deletes the stored contacts of a supplier and inserts the given ones
+func (r *supplierRepository) ReplaceContacts(supplier *model.Supplier) error {
+	if err := r.DB().Where("supplier_id = ?", supplier.ID).Delete(&model.SupplierContact{}).Error; err != nil {
+		return err
+	}
+	for i := range supplier.Contacts {
+		supplier.Contacts[i].ID = uuid.Nil
+		supplier.Contacts[i].SupplierID = supplier.ID
+	}
+	if len(supplier.Contacts) == 0 {
+		return nil
+	}
+	return r.DB().Create(&supplier.Contacts).Error
+}
+
+func (r *supplierRepository) Delete(id uuid.UUID) error {
+	return r.DB().Delete(&model.Supplier{}, "id = ?", id).Error
+}
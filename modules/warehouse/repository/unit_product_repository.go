@@ -14,6 +14,8 @@ import (
 type UnitProductRepository interface {
 	GetAll(page, pageSize int, searchTerm string) ([]model.UnitProduct, int64, error)
 	GetByID(id uuid.UUID) (*model.UnitProduct, error)
+	// GetByCode finds a unit by its code, ignoring case
+	GetByCode(code string) (*model.UnitProduct, error)
 	Create(unitProduct *model.UnitProduct) error
 	Update(unitProduct *model.UnitProduct) error
 	Delete(id uuid.UUID) error
@@ -67,6 +69,18 @@ func (r *unitProductRepository) GetByID(id uuid.UUID) (*model.UnitProduct, error
 	return &unitProduct, nil
 }
 
+func (r *unitProductRepository) GetByCode(code string) (*model.UnitProduct, error) {
+	var unitProduct model.UnitProduct
+	err := r.DB().First(&unitProduct, "LOWER(code) = LOWER(?)", code).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &unitProduct, nil
+}
+
 func (r *unitProductRepository) Create(unitProduct *model.UnitProduct) error {
 	return r.DB().Create(unitProduct).Error
 }
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/antoniusDoni/monorepo/shared/repository"
+	"gorm.io/gorm"
+)
+
+// UserRoleRepository reads the roles the core auth module assigns to users
+type UserRoleRepository interface {
+	// HasRole reports whether the user exists and holds the named role
+	HasRole(userID uint, role string) (bool, error)
+}
+
+type userRoleRepository struct {
+	*repository.Repository
+}
+
+func NewUserRoleRepository(db *gorm.DB) UserRoleRepository {
+	return &userRoleRepository{Repository: repository.NewRepository(context.Background(), db)}
+}
+
+func (r *userRoleRepository) HasRole(userID uint, role string) (bool, error) {
+	var count int64
+	err := r.DB().Table("users").
+		Joins("INNER JOIN user_roles ON user_roles.user_id = users.id").
+		Joins("INNER JOIN roles ON roles.id = user_roles.role_id").
+		Where("users.id = ? AND roles.name = ?", userID, role).
+		Count(&count).Error
+	return count > 0, err
+}
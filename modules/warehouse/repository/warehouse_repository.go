@@ -7,15 +7,16 @@ import (
 	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
 	"github.com/antoniusDoni/monorepo/shared/repository"
 	"github.com/antoniusDoni/monorepo/shared/utils"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type WarehouseRepository interface {
 	GetAll(page, pageSize int, searchTerm string) ([]model.Warehouse, int64, error)
-	GetByID(id uint) (*model.Warehouse, error)
+	GetByID(id uuid.UUID) (*model.Warehouse, error)
 	Create(warehouse *model.Warehouse) error
 	Update(warehouse *model.Warehouse) error
-	Delete(id uint) error
+	Delete(id uuid.UUID) error
 }
 
 type warehouseRepository struct {
@@ -54,9 +55,9 @@ func (r *warehouseRepository) GetAll(page, pageSize int, searchTerm string) ([]m
 	return warehouses, total, nil
 }
 
-func (r *warehouseRepository) GetByID(id uint) (*model.Warehouse, error) {
+func (r *warehouseRepository) GetByID(id uuid.UUID) (*model.Warehouse, error) {
 	var warehouse model.Warehouse
-	err := r.DB().First(&warehouse, id).Error
+	err := r.DB().First(&warehouse, "id = ?", id).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil // not found, return nil object and nil error
 	}
@@ -74,6 +75,6 @@ func (r *warehouseRepository) Update(warehouse *model.Warehouse) error {
 	return r.DB().Save(warehouse).Error
 }
 
-func (r *warehouseRepository) Delete(id uint) error {
-	return r.DB().Delete(&model.Warehouse{}, id).Error
+func (r *warehouseRepository) Delete(id uuid.UUID) error {
+	return r.DB().Delete(&model.Warehouse{}, "id = ?", id).Error
 }
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+)
+
+type AdjustmentReasonService interface {
+	GetAll(status string) ([]model.AdjustmentReason, error)
+	GetByID(id uuid.UUID) (*model.AdjustmentReason, error)
+	Create(reason *model.AdjustmentReason) error
+	Update(id uuid.UUID, reason *model.AdjustmentReason) error
+}
+
+type adjustmentReasonService struct {
+	repo repository.AdjustmentReasonRepository
+}
+
+func NewAdjustmentReasonService(repo repository.AdjustmentReasonRepository) AdjustmentReasonService {
+	return &adjustmentReasonService{repo: repo}
+}
+
+func (s *adjustmentReasonService) GetAll(status string) ([]model.AdjustmentReason, error) {
+	return s.repo.GetAll(status)
+}
+
+func (s *adjustmentReasonService) GetByID(id uuid.UUID) (*model.AdjustmentReason, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *adjustmentReasonService) Create(reason *model.AdjustmentReason) error {
+	if err := s.validateReason(reason, uuid.Nil); err != nil {
+		return err
+	}
+	return s.repo.Create(reason)
+}
+
+// Update replaces a reason code. Lines already posted keep the code they were recorded with.
+func (s *adjustmentReasonService) Update(id uuid.UUID, reason *model.AdjustmentReason) error {
+	existing, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.New("adjustment reason not found")
+	}
+	if err := s.validateReason(reason, id); err != nil {
+		return err
+	}
+
+	reason.ID = existing.ID // Ensure the ID is set for update
+	reason.CreatedAt = existing.CreatedAt
+	return s.repo.Update(reason)
+}
+
+// validateReason checks required fields, a unique code, the direction and the status.
+// An empty direction defaults to both and an empty status to active.
+func (s *adjustmentReasonService) validateReason(reason *model.AdjustmentReason, id uuid.UUID) error {
+	if reason == nil {
+		return errors.New("adjustment reason cannot be nil")
+	}
+	if reason.Code == "" {
+		return errors.New("reason code is required")
+	}
+	if reason.Name == "" {
+		return errors.New("reason name is required")
+	}
+
+	switch reason.Direction {
+	case "":
+		reason.Direction = model.AdjustmentDirectionBoth
+	case model.AdjustmentDirectionIncrease, model.AdjustmentDirectionDecrease, model.AdjustmentDirectionBoth:
+	default:
+		return errors.New("invalid reason direction: must be increase, decrease or both")
+	}
+
+	switch reason.Status {
+	case "":
+		reason.Status = model.AdjustmentReasonStatusActive
+	case model.AdjustmentReasonStatusActive, model.AdjustmentReasonStatusInactive:
+	default:
+		return errors.New("invalid reason status: must be active or inactive")
+	}
+
+	existing, err := s.repo.GetByCode(reason.Code)
+	if err != nil {
+		return errors.New("failed to validate reason code: " + err.Error())
+	}
+	if existing != nil && existing.ID != id {
+		return fmt.Errorf("invalid reason code: %q is already used", reason.Code)
+	}
+	return nil
+}
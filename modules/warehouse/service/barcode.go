@@ -0,0 +1,49 @@
+package service
+
+import (
+	"fmt"
+)
+
+// validateBarcode checks the length and GS1 check digit of an EAN-13, EAN-8 or
+// UPC-A barcode. All three weigh the digits left of the check digit 3 and 1
+// alternately, starting with 3 next to it.
+func validateBarcode(code string) error {
+	switch len(code) {
+	case 8, 12, 13:
+	default:
+		return fmt.Errorf("invalid barcode %q: must have 8 (EAN-8), 12 (UPC-A) or 13 (EAN-13) digits", code)
+	}
+
+	sum := 0
+	for i, r := range code {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("invalid barcode %q: must only contain digits", code)
+		}
+		if i == len(code)-1 {
+			break
+		}
+		digit := int(r - '0')
+		if (len(code)-1-i)%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	check := (10 - sum%10) % 10
+	if int(code[len(code)-1]-'0') != check {
+		return fmt.Errorf("invalid barcode %q: check digit should be %d", code, check)
+	}
+	return nil
+}
+
+// barcodeForms returns a code together with its other spelling. A UPC-A code is
+// the EAN-13 code with a leading zero and scanners report either.
+func barcodeForms(code string) []string {
+	switch {
+	case len(code) == 12:
+		return []string{code, "0" + code}
+	case len(code) == 13 && code[0] == '0':
+		return []string{code, code[1:]}
+	}
+	return []string{code}
+}
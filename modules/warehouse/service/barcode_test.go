@@ -0,0 +1,55 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateBarcode(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		wantErr string
+	}{
+		{name: "EAN-13", code: "4006381333931"},
+		{name: "EAN-8", code: "96385074"},
+		{name: "UPC-A", code: "036000291452"},
+		{name: "wrong check digit", code: "4006381333932", wantErr: "check digit should be 1"},
+		{name: "letter", code: "40063813339A1", wantErr: "must only contain digits"},
+		{name: "letter as check digit", code: "400638133393X", wantErr: "must only contain digits"},
+		{name: "wrong length", code: "400638133", wantErr: "must have 8 (EAN-8), 12 (UPC-A) or 13 (EAN-13) digits"},
+		{name: "empty", code: "", wantErr: "must have 8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBarcode(tt.code)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateBarcode(%q) = %v, want nil", tt.code, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateBarcode(%q) = %v, want an error containing %q", tt.code, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBarcodeForms(t *testing.T) {
+	tests := []struct {
+		code string
+		want []string
+	}{
+		{code: "036000291452", want: []string{"036000291452", "0036000291452"}},
+		{code: "0036000291452", want: []string{"0036000291452", "036000291452"}},
+		{code: "4006381333931", want: []string{"4006381333931"}},
+		{code: "96385074", want: []string{"96385074"}},
+	}
+	for _, tt := range tests {
+		if got := barcodeForms(tt.code); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("barcodeForms(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
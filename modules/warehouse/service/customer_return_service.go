@@ -0,0 +1,280 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CustomerReturnService interface {
+	GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.CustomerReturn, int64, error)
+	GetByID(id uuid.UUID) (*model.CustomerReturn, error)
+	Create(ret *model.CustomerReturn) error
+	// Post receives every line into the chosen warehouse as return_in stock entries,
+	// sellable or in quarantine depending on the line disposition
+	Post(id uuid.UUID, userID uint) (*model.CustomerReturn, error)
+	Cancel(id uuid.UUID) (*model.CustomerReturn, error)
+}
+
+type customerReturnService struct {
+	db                *gorm.DB
+	repo              repository.CustomerReturnRepository
+	entryRepo         repository.StockEntryRepository
+	stockEntryService StockEntryService
+	warehouseRepo     repository.WarehouseRepository
+}
+
+func NewCustomerReturnService(db *gorm.DB, repo repository.CustomerReturnRepository, entryRepo repository.StockEntryRepository, stockEntryService StockEntryService, warehouseRepo repository.WarehouseRepository) CustomerReturnService {
+	return &customerReturnService{
+		db:                db,
+		repo:              repo,
+		entryRepo:         entryRepo,
+		stockEntryService: stockEntryService,
+		warehouseRepo:     warehouseRepo,
+	}
+}
+
+func (s *customerReturnService) GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.CustomerReturn, int64, error) {
+	return s.repo.GetAll(page, pageSize, warehouseID, status)
+}
+
+func (s *customerReturnService) GetByID(id uuid.UUID) (*model.CustomerReturn, error) {
+	return s.repo.GetByID(id)
+}
+
+// Create drafts a return of stock issued with the origin document. Batch, expiry
+// and cost of every line are taken from the original issue.
+func (s *customerReturnService) Create(ret *model.CustomerReturn) error {
+	if ret == nil {
+		return errors.New("customer return cannot be nil")
+	}
+	if ret.OriginID == uuid.Nil {
+		return errors.New("origin ID is required")
+	}
+	if ret.WarehouseID == uuid.Nil {
+		return errors.New("warehouse ID is required")
+	}
+	if len(ret.Lines) == 0 {
+		return errors.New("at least one return line is required")
+	}
+
+	warehouse, err := s.warehouseRepo.GetByID(ret.WarehouseID)
+	if err != nil {
+		return errors.New("failed to validate warehouse: " + err.Error())
+	}
+	if warehouse == nil {
+		return errors.New("warehouse not found")
+	}
+
+	for i := range ret.Lines {
+		line := &ret.Lines[i]
+		switch line.Disposition {
+		case "":
+			line.Disposition = model.ReturnDispositionSellable
+		case model.ReturnDispositionSellable, model.ReturnDispositionQuarantine:
+		default:
+			return errors.New("invalid disposition: must be sellable or quarantine")
+		}
+		line.Quantity, line.ConversionResidual, err = s.stockEntryService.ToBaseQuantity(line.ProductID, line.Quantity, line.Unit)
+		if err != nil {
+			return err
+		}
+		line.Unit = ""
+	}
+
+	origin, err := s.entryRepo.ListByReference(ret.OriginID)
+	if err != nil {
+		return err
+	}
+	if err := applyOriginIssues(origin, ret); err != nil {
+		return err
+	}
+
+	ret.Number = generateDocumentNumber("RTC")
+	ret.Status = model.ReturnStatusDraft
+	if ret.Date.IsZero() {
+		ret.Date = time.Now()
+	}
+	return s.repo.Create(ret)
+}
+
+func (s *customerReturnService) Post(id uuid.UUID, userID uint) (*model.CustomerReturn, error) {
+	var ret *model.CustomerReturn
+	err := stockTransaction(s.db, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		entryRepo := s.entryRepo.WithTx(tx)
+
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		ret, err = s.loadWithStatus(repo, id, model.ReturnStatusDraft)
+		if err != nil {
+			return err
+		}
+
+		// Check again against the ledger as other returns may have been posted
+		// since the draft. Locking the origin movements keeps returns of the
+		// same document from racing; the list is read after the lock.
+		if err := entryRepo.LockByReference(ret.OriginID); err != nil {
+			return err
+		}
+		origin, err := entryRepo.ListByReference(ret.OriginID)
+		if err != nil {
+			return err
+		}
+		if err := applyOriginIssues(origin, ret); err != nil {
+			return err
+		}
+
+		for i := range ret.Lines {
+			line := &ret.Lines[i]
+			qcStatus := model.QCStatusReleased
+			if line.Disposition == model.ReturnDispositionQuarantine {
+				qcStatus = model.QCStatusQuarantine
+			}
+
+			entry := &model.StockEntry{
+				WarehouseID:        ret.WarehouseID,
+				ProductID:          line.ProductID,
+				BatchNumber:        line.BatchNumber,
+				ExpiredAt:          line.ExpiredAt,
+				Date:               ret.Date,
+				Quantity:           line.Quantity,
+				ConversionResidual: line.ConversionResidual,
+				Price:              line.Price,
+				Status:             model.StockEntryStatusReturnIn,
+				QCStatus:           qcStatus,
+				ReferenceID:        ret.OriginID,
+				Notes:              "Customer return " + ret.Number,
+				CreatedBy:          userID,
+				AuthorizedBy:       userID,
+				Counterparty:       ret.CustomerName,
+			}
+			if err := s.stockEntryService.Post(tx, entry); err != nil {
+				return err
+			}
+			line.StockEntryID = entry.ID
+		}
+
+		now := time.Now()
+		ret.Status = model.ReturnStatusPosted
+		ret.PostedAt = &now
+		ret.PostedBy = userID
+		return repo.Update(ret)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Cancel abandons a return that has not been posted
+func (s *customerReturnService) Cancel(id uuid.UUID) (*model.CustomerReturn, error) {
+	ret, err := s.loadWithStatus(s.repo, id, model.ReturnStatusDraft)
+	if err != nil {
+		return nil, err
+	}
+	ret.Status = model.ReturnStatusCancelled
+	if err := s.repo.Update(ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// loadWithStatus fetches a return and checks it is in one of the allowed statuses
+func (s *customerReturnService) loadWithStatus(repo repository.CustomerReturnRepository, id uuid.UUID, allowed ...string) (*model.CustomerReturn, error) {
+	ret, err := repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return nil, errors.New("customer return not found")
+	}
+	for _, status := range allowed {
+		if ret.Status == status {
+			return ret, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid return status: return is %s", ret.Status)
+}
+
+// issuedBatch is what an origin document issued of one product batch and how much already came back
+type issuedBatch struct {
+	issued    int
+	returned  int
+	expiredAt time.Time
+	price     float64
+}
+
+// applyOriginIssues matches every line to a batch issued with the origin document,
+// copying its expiry and cost, and checks no more comes back than was issued.
+// A line without a batch number takes the only batch issued of its product.
+func applyOriginIssues(origin []model.StockEntry, ret *model.CustomerReturn) error {
+	type position struct {
+		productID   uuid.UUID
+		batchNumber string
+	}
+	batches := map[position]*issuedBatch{}
+	batchesOf := map[uuid.UUID][]string{}
+	for _, entry := range origin {
+		if entry.Status != model.StockEntryStatusIssue && entry.Status != model.StockEntryStatusReturnIn {
+			continue
+		}
+		key := position{entry.ProductID, entry.BatchNumber}
+		batch, ok := batches[key]
+		if !ok {
+			batch = &issuedBatch{}
+			batches[key] = batch
+		}
+		if entry.Status == model.StockEntryStatusReturnIn {
+			batch.returned += entry.Quantity
+			continue
+		}
+		if batch.issued == 0 {
+			batchesOf[entry.ProductID] = append(batchesOf[entry.ProductID], entry.BatchNumber)
+		}
+		batch.issued -= entry.Quantity
+		batch.expiredAt = entry.ExpiredAt
+		batch.price = entry.Price
+	}
+
+	requested := map[position]int{}
+	for i := range ret.Lines {
+		line := &ret.Lines[i]
+		if line.Quantity <= 0 {
+			return errors.New("returned quantity must be greater than 0")
+		}
+
+		issuedBatches := batchesOf[line.ProductID]
+		if len(issuedBatches) == 0 {
+			return fmt.Errorf("issue of product %s not found on the origin document", line.ProductID)
+		}
+		if line.BatchNumber == "" {
+			if len(issuedBatches) > 1 {
+				return fmt.Errorf("batch number is required: the origin document issued %d batches of product %s", len(issuedBatches), line.ProductID)
+			}
+			line.BatchNumber = issuedBatches[0]
+		}
+
+		key := position{line.ProductID, line.BatchNumber}
+		batch, ok := batches[key]
+		if !ok || batch.issued == 0 {
+			return fmt.Errorf("issue of batch %q not found on the origin document", line.BatchNumber)
+		}
+
+		requested[key] += line.Quantity
+		if left := batch.issued - batch.returned; requested[key] > left {
+			return fmt.Errorf("invalid returned quantity for batch %q: %d left of the issue, returning %d", line.BatchNumber, left, requested[key])
+		}
+
+		line.ExpiredAt = batch.expiredAt
+		line.Price = batch.price
+	}
+	return nil
+}
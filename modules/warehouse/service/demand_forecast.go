@@ -0,0 +1,177 @@
+package service
+
+import (
+	"math"
+)
+
+// Forecast methods
+const (
+	ForecastMethodAuto                 = "auto" // whichever method fits the history best
+	ForecastMethodMovingAverage        = "moving_average"
+	ForecastMethodExponentialSmoothing = "exponential_smoothing"
+)
+
+const (
+	// forecastZ is the normal quantile of the two-sided 95% confidence band
+	forecastZ = 1.96
+	// seasonalityThreshold is the autocorrelation at one season lag above which history counts as seasonal
+	seasonalityThreshold = 0.3
+)
+
+// demandModel is a fitted forecast of a demand series
+type demandModel struct {
+	Method   string
+	Level    float64   // Deseasonalized demand of the next period
+	Sigma    float64   // Standard deviation of the one-step residuals
+	MAE      float64   // Mean absolute one-step error
+	Indices  []float64 // Seasonal index per position in the season, nil when not seasonal
+	Alpha    float64
+	Window   int
+	observed int // Length of the fitted history, to place the forecast in the season
+}
+
+// fitDemand fits a forecast to a demand series, one value per period, oldest first.
+// Seasonality is removed first when the series spans at least two full seasons
+// and its autocorrelation at one season lag is strong enough.
+func fitDemand(series []float64, method string, window int, alpha float64, seasonLength int) demandModel {
+	var indices []float64
+	values := series
+	if seasonLength > 1 && len(series) >= 2*seasonLength && autocorrelation(series, seasonLength) >= seasonalityThreshold {
+		indices = seasonalIndices(series, seasonLength)
+		values = make([]float64, len(series))
+		for i, v := range series {
+			values[i] = v / indices[i%seasonLength]
+		}
+	}
+
+	var fitted demandModel
+	switch method {
+	case ForecastMethodMovingAverage:
+		fitted = fitMovingAverage(values, window)
+	case ForecastMethodExponentialSmoothing:
+		fitted = fitExponentialSmoothing(values, alpha)
+	default:
+		fitted = fitMovingAverage(values, window)
+		if smoothed := fitExponentialSmoothing(values, alpha); smoothed.MAE < fitted.MAE {
+			fitted = smoothed
+		}
+	}
+	fitted.Indices = indices
+	fitted.Alpha = alpha
+	fitted.Window = window
+	fitted.observed = len(series)
+	return fitted
+}
+
+// forecast returns the expected demand of the period h steps ahead (h >= 1)
+// with the half-width of its confidence band
+func (m demandModel) forecast(h int) (float64, float64) {
+	index := 1.0
+	if m.Indices != nil {
+		index = m.Indices[(m.observed+h-1)%len(m.Indices)]
+	}
+
+	// The error of exponential smoothing grows with the horizon; a moving average stays flat
+	spread := m.Sigma
+	if m.Method == ForecastMethodExponentialSmoothing {
+		spread = m.Sigma * math.Sqrt(1+float64(h-1)*m.Alpha*m.Alpha)
+	}
+	return m.Level * index, forecastZ * spread * index
+}
+
+// fitMovingAverage forecasts the mean of the last window periods
+func fitMovingAverage(values []float64, window int) demandModel {
+	if window > len(values) {
+		window = len(values)
+	}
+	var residuals []float64
+	for t := window; t < len(values); t++ {
+		residuals = append(residuals, values[t]-mean(values[t-window:t]))
+	}
+
+	fitted := demandModel{Method: ForecastMethodMovingAverage}
+	if window > 0 {
+		fitted.Level = mean(values[len(values)-window:])
+	}
+	fitted.Sigma, fitted.MAE = errorStats(residuals)
+	return fitted
+}
+
+// fitExponentialSmoothing applies simple exponential smoothing, starting from the first value
+func fitExponentialSmoothing(values []float64, alpha float64) demandModel {
+	fitted := demandModel{Method: ForecastMethodExponentialSmoothing}
+	if len(values) == 0 {
+		return fitted
+	}
+
+	level := values[0]
+	var residuals []float64
+	for _, v := range values[1:] {
+		residuals = append(residuals, v-level)
+		level = alpha*v + (1-alpha)*level
+	}
+	fitted.Level = level
+	fitted.Sigma, fitted.MAE = errorStats(residuals)
+	return fitted
+}
+
+// seasonalIndices averages each position of the season relative to the overall mean.
+// Positions without demand keep a small index so deseasonalizing never divides by zero.
+func seasonalIndices(series []float64, seasonLength int) []float64 {
+	overall := mean(series)
+	indices := make([]float64, seasonLength)
+	for p := range indices {
+		var sum float64
+		var n int
+		for i := p; i < len(series); i += seasonLength {
+			sum += series[i]
+			n++
+		}
+		indices[p] = 1
+		if overall > 0 && n > 0 {
+			indices[p] = math.Max(sum/float64(n)/overall, 0.05)
+		}
+	}
+	return indices
+}
+
+// autocorrelation is the correlation of the series with itself shifted by lag
+func autocorrelation(series []float64, lag int) float64 {
+	m := mean(series)
+	var numerator, denominator float64
+	for i, v := range series {
+		denominator += (v - m) * (v - m)
+		if i >= lag {
+			numerator += (v - m) * (series[i-lag] - m)
+		}
+	}
+	if denominator == 0 {
+		return 0
+	}
+	return numerator / denominator
+}
+
+// errorStats returns the standard deviation and mean absolute value of forecast residuals
+func errorStats(residuals []float64) (float64, float64) {
+	if len(residuals) == 0 {
+		return 0, 0
+	}
+	var squares, absolute float64
+	for _, e := range residuals {
+		squares += e * e
+		absolute += math.Abs(e)
+	}
+	n := float64(len(residuals))
+	return math.Sqrt(squares / n), absolute / n
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
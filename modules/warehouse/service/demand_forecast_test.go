@@ -0,0 +1,100 @@
+package service
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFitDemand(t *testing.T) {
+	tests := []struct {
+		name       string
+		series     []float64
+		method     string
+		window     int
+		alpha      float64
+		wantMethod string
+		wantLevel  float64
+		wantSigma  float64
+		wantMAE    float64
+	}{
+		{
+			name: "moving average of the last window", series: []float64{1, 2, 3, 4, 5, 6},
+			method: ForecastMethodMovingAverage, window: 3, alpha: 0.3,
+			wantMethod: ForecastMethodMovingAverage, wantLevel: 5, wantSigma: 2, wantMAE: 2,
+		},
+		{
+			name: "window longer than the history", series: []float64{4, 6},
+			method: ForecastMethodMovingAverage, window: 7, alpha: 0.3,
+			wantMethod: ForecastMethodMovingAverage, wantLevel: 5,
+		},
+		{
+			name: "exponential smoothing", series: []float64{10, 20, 10},
+			method: ForecastMethodExponentialSmoothing, window: 3, alpha: 0.5,
+			// Levels 10, 15, 12.5 with residuals 10 and -5
+			wantMethod: ForecastMethodExponentialSmoothing, wantLevel: 12.5, wantSigma: math.Sqrt(62.5), wantMAE: 7.5,
+		},
+		{
+			name: "auto picks the method with the smaller error", series: []float64{10, 10, 10, 10, 20, 20, 20, 20},
+			method: ForecastMethodAuto, window: 4, alpha: 0.9,
+			wantMethod: ForecastMethodExponentialSmoothing, wantLevel: 19.999, wantSigma: math.Sqrt(101.0101 / 7), wantMAE: 11.11 / 7,
+		},
+		{
+			name: "empty history", method: ForecastMethodAuto, window: 3, alpha: 0.3,
+			wantMethod: ForecastMethodMovingAverage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fitDemand(tt.series, tt.method, tt.window, tt.alpha, 0)
+			if got.Method != tt.wantMethod {
+				t.Errorf("method = %s, want %s", got.Method, tt.wantMethod)
+			}
+			if math.Abs(got.Level-tt.wantLevel) > 1e-9 {
+				t.Errorf("level = %v, want %v", got.Level, tt.wantLevel)
+			}
+			if math.Abs(got.Sigma-tt.wantSigma) > 1e-9 {
+				t.Errorf("sigma = %v, want %v", got.Sigma, tt.wantSigma)
+			}
+			if math.Abs(got.MAE-tt.wantMAE) > 1e-9 {
+				t.Errorf("MAE = %v, want %v", got.MAE, tt.wantMAE)
+			}
+			if got.Indices != nil {
+				t.Errorf("indices = %v, want none", got.Indices)
+			}
+		})
+	}
+}
+
+func TestFitDemandSeasonal(t *testing.T) {
+	season := []float64{10, 20, 30, 40}
+	var series []float64
+	for range 3 {
+		series = append(series, season...)
+	}
+
+	fitted := fitDemand(series, ForecastMethodMovingAverage, 4, 0.3, len(season))
+	wantIndices := []float64{0.4, 0.8, 1.2, 1.6}
+	if len(fitted.Indices) != len(wantIndices) {
+		t.Fatalf("indices = %v, want %v", fitted.Indices, wantIndices)
+	}
+	for i, want := range wantIndices {
+		if math.Abs(fitted.Indices[i]-want) > 1e-9 {
+			t.Errorf("index %d = %v, want %v", i, fitted.Indices[i], want)
+		}
+	}
+	if math.Abs(fitted.Level-25) > 1e-9 || fitted.Sigma > 1e-9 {
+		t.Errorf("deseasonalized level = %v with sigma %v, want 25 with none", fitted.Level, fitted.Sigma)
+	}
+
+	// The history ends with a full season, so the forecast starts over at its first period
+	for h, want := range []float64{10, 20, 30, 40, 10} {
+		if got, band := fitted.forecast(h + 1); math.Abs(got-want) > 1e-9 || band > 1e-9 {
+			t.Errorf("forecast(%d) = %v ± %v, want %v ± 0", h+1, got, band, want)
+		}
+	}
+
+	// Less than two seasons of history is not enough to tell seasonality
+	if short := fitDemand(series[:7], ForecastMethodMovingAverage, 4, 0.3, len(season)); short.Indices != nil {
+		t.Errorf("indices from %d periods = %v, want none", 7, short.Indices)
+	}
+}
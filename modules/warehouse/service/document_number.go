@@ -0,0 +1,15 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// generateDocumentNumber builds a human readable document number such as TRF-20240115-3F9A2C
+func generateDocumentNumber(prefix string) string {
+	suffix := strings.ToUpper(strings.ReplaceAll(uuid.New().String(), "-", "")[:6])
+	return fmt.Sprintf("%s-%s-%s", prefix, time.Now().Format("20060102"), suffix)
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+)
+
+// Forecast intervals are the period length of the demand series
+const (
+	ForecastIntervalMonth = "month"
+	ForecastIntervalWeek  = "week"
+)
+
+type ForecastService interface {
+	// GetProductForecast projects the demand of a product per warehouse from its
+	// outgoing movements. A nil warehouseID covers every warehouse with demand.
+	GetProductForecast(productID, warehouseID uuid.UUID, options ForecastOptions) (*ProductForecast, error)
+	// DailyDemand is the forecast demand per day of the current month, for reorder planning
+	DailyDemand(productID, warehouseID uuid.UUID) (float64, error)
+}
+
+// ForecastOptions tune a forecast. Zero values take the defaults.
+type ForecastOptions struct {
+	Method   string  // auto, moving_average or exponential_smoothing (default: auto)
+	Interval string  // month or week (default: month)
+	History  int     // Complete periods of history to fit (default: 24 months or 104 weeks)
+	Horizon  int     // Periods to forecast, starting with the current one (default: 3)
+	Window   int     // Moving average window in periods (default: 3)
+	Alpha    float64 // Exponential smoothing factor, 0 < alpha <= 1 (default: 0.3)
+}
+
+// ProductForecast is the projected demand of a product in each warehouse
+type ProductForecast struct {
+	ProductID       uuid.UUID           `json:"product_id"`
+	ProductCode     string              `json:"product_code"`
+	ProductName     string              `json:"product_name"`
+	Unit            string              `json:"unit"`
+	Method          string              `json:"method"`
+	Interval        string              `json:"interval"`
+	History         int                 `json:"history"`
+	Horizon         int                 `json:"horizon"`
+	ConfidenceLevel float64             `json:"confidence_level"`
+	Warehouses      []WarehouseForecast `json:"warehouses"`
+}
+
+type WarehouseForecast struct {
+	WarehouseID       uuid.UUID       `json:"warehouse_id"`
+	WarehouseName     string          `json:"warehouse_name"`
+	Method            string          `json:"method"` // Method used, the best fit when auto was requested
+	Seasonal          bool            `json:"seasonal"`
+	SeasonalIndices   []float64       `json:"seasonal_indices,omitempty"` // Demand of each period in the season relative to the average
+	MeanAbsoluteError float64         `json:"mean_absolute_error"`        // Of one-step forecasts over the history
+	History           []DemandPoint   `json:"history"`
+	Forecast          []ForecastPoint `json:"forecast"`
+}
+
+type DemandPoint struct {
+	PeriodStart time.Time `json:"period_start"`
+	Quantity    float64   `json:"quantity"`
+}
+
+type ForecastPoint struct {
+	PeriodStart time.Time `json:"period_start"`
+	Quantity    float64   `json:"quantity"`
+	Lower       float64   `json:"lower"` // Lower bound of the confidence band, never below zero
+	Upper       float64   `json:"upper"`
+}
+
+type forecastService struct {
+	entryRepo     repository.StockEntryRepository
+	productRepo   repository.ProductRepository
+	warehouseRepo repository.WarehouseRepository
+}
+
+func NewForecastService(entryRepo repository.StockEntryRepository, productRepo repository.ProductRepository, warehouseRepo repository.WarehouseRepository) ForecastService {
+	return &forecastService{entryRepo: entryRepo, productRepo: productRepo, warehouseRepo: warehouseRepo}
+}
+
+func (s *forecastService) GetProductForecast(productID, warehouseID uuid.UUID, options ForecastOptions) (*ProductForecast, error) {
+	if err := applyForecastDefaults(&options); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, errors.New("product not found")
+	}
+	if warehouseID != uuid.Nil {
+		warehouse, err := s.warehouseRepo.GetByID(warehouseID)
+		if err != nil {
+			return nil, err
+		}
+		if warehouse == nil {
+			return nil, errors.New("warehouse not found")
+		}
+	}
+
+	starts, series, err := s.demandSeries(productID, warehouseID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ProductForecast{
+		ProductID:       product.ID,
+		ProductCode:     product.Code,
+		ProductName:     product.Name,
+		Unit:            product.SmallUnit,
+		Method:          options.Method,
+		Interval:        options.Interval,
+		History:         options.History,
+		Horizon:         options.Horizon,
+		ConfidenceLevel: 0.95,
+		Warehouses:      make([]WarehouseForecast, 0, len(series)),
+	}
+
+	for id, values := range series {
+		warehouse, err := s.warehouseRepo.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+
+		fitted := fitDemand(values, options.Method, options.Window, options.Alpha, seasonLength(options.Interval))
+		forecast := WarehouseForecast{
+			WarehouseID:       id,
+			Method:            fitted.Method,
+			Seasonal:          fitted.Indices != nil,
+			SeasonalIndices:   fitted.Indices,
+			MeanAbsoluteError: fitted.MAE,
+			History:           make([]DemandPoint, 0, len(values)),
+			Forecast:          make([]ForecastPoint, 0, options.Horizon),
+		}
+		if warehouse != nil {
+			forecast.WarehouseName = warehouse.Name
+		}
+		for i, v := range values {
+			forecast.History = append(forecast.History, DemandPoint{PeriodStart: starts[i], Quantity: v})
+		}
+
+		start := nextPeriod(starts[len(starts)-1], options.Interval)
+		for h := 1; h <= options.Horizon; h++ {
+			quantity, band := fitted.forecast(h)
+			forecast.Forecast = append(forecast.Forecast, ForecastPoint{
+				PeriodStart: start,
+				Quantity:    quantity,
+				Lower:       max(quantity-band, 0),
+				Upper:       quantity + band,
+			})
+			start = nextPeriod(start, options.Interval)
+		}
+		result.Warehouses = append(result.Warehouses, forecast)
+	}
+
+	sort.Slice(result.Warehouses, func(i, j int) bool {
+		return result.Warehouses[i].WarehouseName < result.Warehouses[j].WarehouseName
+	})
+	return result, nil
+}
+
+func (s *forecastService) DailyDemand(productID, warehouseID uuid.UUID) (float64, error) {
+	options := ForecastOptions{}
+	if err := applyForecastDefaults(&options); err != nil {
+		return 0, err
+	}
+
+	starts, series, err := s.demandSeries(productID, warehouseID, options)
+	if err != nil {
+		return 0, err
+	}
+	values, ok := series[warehouseID]
+	if !ok {
+		return 0, nil
+	}
+
+	quantity, _ := fitDemand(values, options.Method, options.Window, options.Alpha, seasonLength(options.Interval)).forecast(1)
+	current := nextPeriod(starts[len(starts)-1], options.Interval)
+	days := nextPeriod(current, options.Interval).Sub(current).Hours() / 24
+	return quantity / days, nil
+}
+
+// demandSeries buckets the outgoing movements of the last complete periods per
+// warehouse. The current period is still running and is left out. A requested
+// warehouse always gets a series, even without any demand.
+func (s *forecastService) demandSeries(productID, warehouseID uuid.UUID, options ForecastOptions) ([]time.Time, map[uuid.UUID][]float64, error) {
+	current := periodStart(time.Now(), options.Interval)
+	starts := make([]time.Time, options.History)
+	start := current
+	for i := options.History - 1; i >= 0; i-- {
+		start = previousPeriod(start, options.Interval)
+		starts[i] = start
+	}
+
+	entries, err := s.entryRepo.ListOutgoing(productID, warehouseID, starts[0], current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	series := map[uuid.UUID][]float64{}
+	if warehouseID != uuid.Nil {
+		series[warehouseID] = make([]float64, options.History)
+	}
+	for _, entry := range entries {
+		values, ok := series[entry.WarehouseID]
+		if !ok {
+			values = make([]float64, options.History)
+			series[entry.WarehouseID] = values
+		}
+		i := sort.Search(len(starts), func(i int) bool { return starts[i].After(entry.Date) }) - 1
+		if i >= 0 {
+			values[i] += float64(-entry.Quantity)
+		}
+	}
+	return starts, series, nil
+}
+
+// applyForecastDefaults fills zero options and rejects invalid ones
+func applyForecastDefaults(options *ForecastOptions) error {
+	switch options.Method {
+	case "":
+		options.Method = ForecastMethodAuto
+	case ForecastMethodAuto, ForecastMethodMovingAverage, ForecastMethodExponentialSmoothing:
+	default:
+		return errors.New("invalid forecast method: must be auto, moving_average or exponential_smoothing")
+	}
+
+	switch options.Interval {
+	case "", ForecastIntervalMonth:
+		options.Interval = ForecastIntervalMonth
+		if options.History == 0 {
+			options.History = 24
+		}
+	case ForecastIntervalWeek:
+		if options.History == 0 {
+			options.History = 104
+		}
+	default:
+		return errors.New("invalid forecast interval: must be month or week")
+	}
+
+	if options.Horizon == 0 {
+		options.Horizon = 3
+	}
+	if options.Window == 0 {
+		options.Window = 3
+	}
+	if options.Alpha == 0 {
+		options.Alpha = 0.3
+	}
+
+	if options.History < 2 || options.History > 260 {
+		return fmt.Errorf("invalid history: %d periods, must be between 2 and 260", options.History)
+	}
+	if options.Horizon < 1 || options.Horizon > 52 {
+		return fmt.Errorf("invalid horizon: %d periods, must be between 1 and 52", options.Horizon)
+	}
+	if options.Window < 1 || options.Window > options.History {
+		return fmt.Errorf("invalid window: %d periods, must be between 1 and the history", options.Window)
+	}
+	if options.Alpha < 0 || options.Alpha > 1 {
+		return errors.New("invalid alpha: must be between 0 and 1")
+	}
+	return nil
+}
+
+// seasonLength is the number of periods in a year
+func seasonLength(interval string) int {
+	if interval == ForecastIntervalWeek {
+		return 52
+	}
+	return 12
+}
+
+// periodStart returns the first instant of the month, or of the week starting Monday, containing t
+func periodStart(t time.Time, interval string) time.Time {
+	t = t.In(time.Local)
+	if interval == ForecastIntervalWeek {
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.Local)
+}
+
+func nextPeriod(start time.Time, interval string) time.Time {
+	if interval == ForecastIntervalWeek {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+func previousPeriod(start time.Time, interval string) time.Time {
+	if interval == ForecastIntervalWeek {
+		return start.AddDate(0, 0, -7)
+	}
+	return start.AddDate(0, -1, 0)
+}
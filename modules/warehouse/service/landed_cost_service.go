@@ -0,0 +1,347 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LandedCostService interface {
+	GetAll(page, pageSize int, receiptID uuid.UUID, status string) ([]model.LandedCost, int64, error)
+	GetByID(id uuid.UUID) (*model.LandedCost, error)
+	// Create drafts a landed cost against a goods receipt and previews its allocation
+	Create(cost *model.LandedCost) error
+	// Post allocates the charges again and adds them to the cost of the receipt lines
+	Post(id uuid.UUID, userID uint) (*model.LandedCost, error)
+	// Reverse takes a posted landed cost back out of the receipt line costs
+	Reverse(id uuid.UUID, reason string, userID uint) (*model.LandedCost, error)
+	Cancel(id uuid.UUID) (*model.LandedCost, error)
+}
+
+type landedCostService struct {
+	db          *gorm.DB
+	repo        repository.LandedCostRepository
+	receiptRepo repository.GoodsReceiptRepository
+	periodRepo  repository.StockPeriodRepository
+}
+
+func NewLandedCostService(db *gorm.DB, repo repository.LandedCostRepository, receiptRepo repository.GoodsReceiptRepository, periodRepo repository.StockPeriodRepository) LandedCostService {
+	return &landedCostService{
+		db:          db,
+		repo:        repo,
+		receiptRepo: receiptRepo,
+		periodRepo:  periodRepo,
+	}
+}
+
+func (s *landedCostService) GetAll(page, pageSize int, receiptID uuid.UUID, status string) ([]model.LandedCost, int64, error) {
+	return s.repo.GetAll(page, pageSize, receiptID, status)
+}
+
+func (s *landedCostService) GetByID(id uuid.UUID) (*model.LandedCost, error) {
+	return s.repo.GetByID(id)
+}
+
+// Create drafts a landed cost. Without lines the charges are spread over every
+// line of the receipt; the weight method needs each line with its weight.
+func (s *landedCostService) Create(cost *model.LandedCost) error {
+	if cost == nil {
+		return errors.New("landed cost cannot be nil")
+	}
+	if cost.ReceiptID == uuid.Nil {
+		return errors.New("receipt ID is required")
+	}
+	if cost.Method == "" {
+		cost.Method = model.LandedCostByValue
+	}
+	if len(cost.Charges) == 0 {
+		return errors.New("at least one charge is required")
+	}
+
+	receipt, err := s.receiptRepo.GetByID(cost.ReceiptID)
+	if err != nil {
+		return err
+	}
+	if receipt == nil {
+		return errors.New("goods receipt not found")
+	}
+
+	if len(cost.Lines) == 0 {
+		if cost.Method == model.LandedCostByWeight {
+			return errors.New("invalid allocation: the weight method needs every line with its weight")
+		}
+		for _, line := range receipt.Lines {
+			// Lines returned in full have nothing left to carry the charges
+			if line.Quantity-line.ReturnedQuantity <= 0 {
+				continue
+			}
+			cost.Lines = append(cost.Lines, model.LandedCostLine{ReceiptLineID: line.ID})
+		}
+	}
+	if cost.Date.IsZero() {
+		cost.Date = time.Now()
+	}
+	if err := allocateLandedCost(receipt, cost); err != nil {
+		return err
+	}
+
+	cost.Number = generateDocumentNumber("LC")
+	cost.Status = model.LandedCostStatusDraft
+	cost.OrderID = receipt.OrderID
+	cost.WarehouseID = receipt.WarehouseID
+	return s.repo.Create(cost)
+}
+
+func (s *landedCostService) Post(id uuid.UUID, userID uint) (*model.LandedCost, error) {
+	var cost *model.LandedCost
+	err := stockTransaction(s.db, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		receiptRepo := s.receiptRepo.WithTx(tx)
+
+		// A second post waits here and then finds the landed cost posted
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		cost, err = s.loadWithStatus(repo, id, model.LandedCostStatusDraft)
+		if err != nil {
+			return err
+		}
+		if err := s.checkPeriodOpen(tx, cost); err != nil {
+			return err
+		}
+
+		// Supplier returns lock the receipt too, so the quantities allocated over stay put
+		if err := receiptRepo.Lock(cost.ReceiptID); err != nil {
+			return err
+		}
+		receipt, err := receiptRepo.GetByID(cost.ReceiptID)
+		if err != nil {
+			return err
+		}
+		if receipt == nil {
+			return errors.New("goods receipt not found")
+		}
+		if err := allocateLandedCost(receipt, cost); err != nil {
+			return err
+		}
+
+		for _, line := range cost.Lines {
+			if line.StockEntryID == uuid.Nil {
+				return fmt.Errorf("invalid receipt line %s: no stock entry was recorded for it", line.ReceiptLineID)
+			}
+			if err := receiptRepo.AddLandedCost(line.ReceiptLineID, line.UnitCost); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		cost.Status = model.LandedCostStatusPosted
+		cost.PostedAt = &now
+		cost.PostedBy = userID
+		return repo.Update(cost)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cost, nil
+}
+
+// Reverse keeps the document and its allocation for the audit trail. Valuation
+// stops counting it from the moment of reversal.
+func (s *landedCostService) Reverse(id uuid.UUID, reason string, userID uint) (*model.LandedCost, error) {
+	if reason == "" {
+		return nil, errors.New("reversal reason is required")
+	}
+
+	var cost *model.LandedCost
+	err := stockTransaction(s.db, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		receiptRepo := s.receiptRepo.WithTx(tx)
+
+		// A second reversal waits here and then finds the landed cost reversed
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		cost, err = s.loadWithStatus(repo, id, model.LandedCostStatusPosted)
+		if err != nil {
+			return err
+		}
+		if err := s.checkPeriodOpen(tx, cost); err != nil {
+			return err
+		}
+
+		for _, line := range cost.Lines {
+			if err := receiptRepo.AddLandedCost(line.ReceiptLineID, -line.UnitCost); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		cost.Status = model.LandedCostStatusReversed
+		cost.ReversedAt = &now
+		cost.ReversedBy = userID
+		cost.ReversalReason = reason
+		return repo.Update(cost)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cost, nil
+}
+
+// Cancel abandons a landed cost that has not been posted
+func (s *landedCostService) Cancel(id uuid.UUID) (*model.LandedCost, error) {
+	var cost *model.LandedCost
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		cost, err = s.loadWithStatus(repo, id, model.LandedCostStatusDraft)
+		if err != nil {
+			return err
+		}
+		cost.Status = model.LandedCostStatusCancelled
+		return repo.Update(cost)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cost, nil
+}
+
+// checkPeriodOpen rejects changes to a landed cost dated in a closed period,
+// whose valuation snapshot already includes it
+func (s *landedCostService) checkPeriodOpen(tx *gorm.DB, cost *model.LandedCost) error {
+	periodRepo := s.periodRepo.WithTx(tx)
+	if err := periodRepo.LockForPosting(); err != nil {
+		return err
+	}
+	closed, err := periodRepo.GetLatestClosed()
+	if err != nil {
+		return err
+	}
+	if closed != nil && !cost.Date.After(closed.EndAt) {
+		return fmt.Errorf("invalid date: stock period %s is closed", closed.Period)
+	}
+	return nil
+}
+
+// loadWithStatus fetches a landed cost and checks it is in one of the allowed statuses
+func (s *landedCostService) loadWithStatus(repo repository.LandedCostRepository, id uuid.UUID, allowed ...string) (*model.LandedCost, error) {
+	cost, err := repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if cost == nil {
+		return nil, errors.New("landed cost not found")
+	}
+	for _, status := range allowed {
+		if cost.Status == status {
+			return cost, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid landed cost status: landed cost is %s", cost.Status)
+}
+
+// allocateLandedCost checks the charges, copies product, batch, quantity and
+// price from the referenced receipt lines and splits the total over them by
+// the document's method. Quantities are net of supplier returns, so the
+// charges stay with the stock that was kept. Amounts are rounded to cents and the rounding
+// difference goes to the last line, so the lines always add up to the total.
+func allocateLandedCost(receipt *model.GoodsReceipt, cost *model.LandedCost) error {
+	switch cost.Method {
+	case model.LandedCostByValue, model.LandedCostByQuantity, model.LandedCostByWeight:
+	default:
+		return fmt.Errorf("invalid allocation method %q: must be value, quantity or weight", cost.Method)
+	}
+	if cost.Date.Before(receipt.Date) {
+		return errors.New("invalid date: landed cost cannot be dated before the receipt")
+	}
+
+	cost.TotalAmount = 0
+	for _, charge := range cost.Charges {
+		switch charge.Type {
+		case model.LandedCostChargeFreight, model.LandedCostChargeInsurance, model.LandedCostChargeDuty, model.LandedCostChargeOther:
+		default:
+			return fmt.Errorf("invalid charge type %q: must be freight, insurance, duty or other", charge.Type)
+		}
+		if charge.Amount <= 0 {
+			return errors.New("charge amount must be greater than 0")
+		}
+		cost.TotalAmount += charge.Amount
+	}
+	cost.TotalAmount = roundCents(cost.TotalAmount)
+
+	seen := map[uuid.UUID]bool{}
+	bases := make([]float64, len(cost.Lines))
+	var totalBase float64
+	for i := range cost.Lines {
+		line := &cost.Lines[i]
+		if seen[line.ReceiptLineID] {
+			return fmt.Errorf("invalid receipt line %s: listed more than once", line.ReceiptLineID)
+		}
+		seen[line.ReceiptLineID] = true
+
+		var received *model.GoodsReceiptLine
+		for j := range receipt.Lines {
+			if receipt.Lines[j].ID == line.ReceiptLineID {
+				received = &receipt.Lines[j]
+				break
+			}
+		}
+		if received == nil {
+			return fmt.Errorf("receipt line %s not found", line.ReceiptLineID)
+		}
+
+		line.StockEntryID = received.StockEntryID
+		line.ProductID = received.ProductID
+		line.BatchNumber = received.BatchNumber
+		line.Quantity = received.Quantity - received.ReturnedQuantity
+		if line.Quantity <= 0 {
+			return fmt.Errorf("invalid receipt line %s: everything received was returned to the supplier", line.ReceiptLineID)
+		}
+		line.Price = received.Price
+
+		switch cost.Method {
+		case model.LandedCostByValue:
+			bases[i] = float64(line.Quantity) * line.Price
+		case model.LandedCostByQuantity:
+			bases[i] = float64(line.Quantity)
+		case model.LandedCostByWeight:
+			if line.Weight <= 0 {
+				return fmt.Errorf("line weight must be greater than 0 for receipt line %s", line.ReceiptLineID)
+			}
+			bases[i] = line.Weight
+		}
+		totalBase += bases[i]
+	}
+	if totalBase <= 0 {
+		return fmt.Errorf("invalid allocation: the receipt lines have no %s to allocate by", cost.Method)
+	}
+
+	remaining := cost.TotalAmount
+	for i := range cost.Lines {
+		line := &cost.Lines[i]
+		line.Amount = roundCents(cost.TotalAmount * bases[i] / totalBase)
+		if i == len(cost.Lines)-1 {
+			line.Amount = roundCents(remaining)
+		}
+		remaining -= line.Amount
+		line.UnitCost = line.Amount / float64(line.Quantity)
+	}
+	return nil
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/google/uuid"
+)
+
+// landedCostReceipt is a receipt of three lines: 2 of 3 units kept at 10, 1 unit at 40 and 1 unit at 10
+func landedCostReceipt() *model.GoodsReceipt {
+	return &model.GoodsReceipt{
+		Date: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Lines: []model.GoodsReceiptLine{
+			{ID: uuid.New(), ProductID: uuid.New(), BatchNumber: "A", Quantity: 3, ReturnedQuantity: 1, Price: 10},
+			{ID: uuid.New(), ProductID: uuid.New(), BatchNumber: "B", Quantity: 1, Price: 40},
+			{ID: uuid.New(), ProductID: uuid.New(), BatchNumber: "C", Quantity: 1, Price: 10},
+		},
+	}
+}
+
+func TestAllocateLandedCost(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		charges []float64
+		weights []float64
+		want    []float64
+	}{
+		// Bases 20, 40 and 10 of 70
+		{name: "by value", method: model.LandedCostByValue, charges: []float64{70, 30}, want: []float64{28.57, 57.14, 14.29}},
+		// Bases 2, 1 and 1 of 4, the kept quantity
+		{name: "by quantity", method: model.LandedCostByQuantity, charges: []float64{10.01}, want: []float64{5.01, 2.5, 2.5}},
+		// Three equal shares of 33.333 round down and the last line takes the cent left over
+		{name: "by weight", method: model.LandedCostByWeight, charges: []float64{100}, weights: []float64{1, 1, 1}, want: []float64{33.33, 33.33, 33.34}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := landedCostReceipt()
+			cost := &model.LandedCost{Method: tt.method, Date: receipt.Date}
+			for _, amount := range tt.charges {
+				cost.Charges = append(cost.Charges, model.LandedCostCharge{Type: model.LandedCostChargeFreight, Amount: amount})
+			}
+			for i, received := range receipt.Lines {
+				line := model.LandedCostLine{ReceiptLineID: received.ID}
+				if tt.weights != nil {
+					line.Weight = tt.weights[i]
+				}
+				cost.Lines = append(cost.Lines, line)
+			}
+
+			if err := allocateLandedCost(receipt, cost); err != nil {
+				t.Fatalf("allocateLandedCost: %v", err)
+			}
+
+			var total float64
+			for _, amount := range tt.charges {
+				total += amount
+			}
+			if cost.TotalAmount != roundCents(total) {
+				t.Errorf("total = %v, want %v", cost.TotalAmount, roundCents(total))
+			}
+			var allocated float64
+			for i, line := range cost.Lines {
+				received := receipt.Lines[i]
+				if line.ProductID != received.ProductID || line.BatchNumber != received.BatchNumber || line.Price != received.Price {
+					t.Errorf("line %d was not copied from its receipt line", i)
+				}
+				if want := received.Quantity - received.ReturnedQuantity; line.Quantity != want {
+					t.Errorf("line %d quantity = %d, want %d", i, line.Quantity, want)
+				}
+				if math.Abs(line.Amount-tt.want[i]) > 1e-9 {
+					t.Errorf("line %d amount = %v, want %v", i, line.Amount, tt.want[i])
+				}
+				if math.Abs(line.UnitCost-line.Amount/float64(line.Quantity)) > 1e-9 {
+					t.Errorf("line %d unit cost = %v, want %v", i, line.UnitCost, line.Amount/float64(line.Quantity))
+				}
+				allocated += line.Amount
+			}
+			if roundCents(allocated) != cost.TotalAmount {
+				t.Errorf("lines add up to %v, want %v", allocated, cost.TotalAmount)
+			}
+		})
+	}
+}
+
+func TestAllocateLandedCostRejectsInvalidDocuments(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(receipt *model.GoodsReceipt, cost *model.LandedCost)
+		wantErr string
+	}{
+		{
+			name:    "unknown method",
+			change:  func(_ *model.GoodsReceipt, cost *model.LandedCost) { cost.Method = "volume" },
+			wantErr: "invalid allocation method",
+		},
+		{
+			name:    "dated before the receipt",
+			change:  func(receipt *model.GoodsReceipt, cost *model.LandedCost) { cost.Date = receipt.Date.Add(-time.Hour) },
+			wantErr: "cannot be dated before the receipt",
+		},
+		{
+			name:    "unknown charge type",
+			change:  func(_ *model.GoodsReceipt, cost *model.LandedCost) { cost.Charges[0].Type = "storage" },
+			wantErr: "invalid charge type",
+		},
+		{
+			name:    "charge without amount",
+			change:  func(_ *model.GoodsReceipt, cost *model.LandedCost) { cost.Charges[0].Amount = 0 },
+			wantErr: "charge amount must be greater than 0",
+		},
+		{
+			name: "line listed twice",
+			change: func(_ *model.GoodsReceipt, cost *model.LandedCost) {
+				cost.Lines = append(cost.Lines, cost.Lines[0])
+			},
+			wantErr: "listed more than once",
+		},
+		{
+			name: "line of another receipt",
+			change: func(_ *model.GoodsReceipt, cost *model.LandedCost) {
+				cost.Lines[0].ReceiptLineID = uuid.New()
+			},
+			wantErr: "not found",
+		},
+		{
+			name: "everything returned",
+			change: func(receipt *model.GoodsReceipt, _ *model.LandedCost) {
+				receipt.Lines[1].ReturnedQuantity = receipt.Lines[1].Quantity
+			},
+			wantErr: "everything received was returned",
+		},
+		{
+			name: "weight missing",
+			change: func(_ *model.GoodsReceipt, cost *model.LandedCost) {
+				cost.Method = model.LandedCostByWeight
+				cost.Lines[1].Weight = 0
+			},
+			wantErr: "line weight must be greater than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := landedCostReceipt()
+			cost := &model.LandedCost{
+				Method:  model.LandedCostByQuantity,
+				Date:    receipt.Date,
+				Charges: []model.LandedCostCharge{{Type: model.LandedCostChargeDuty, Amount: 12}},
+			}
+			for _, received := range receipt.Lines {
+				cost.Lines = append(cost.Lines, model.LandedCostLine{ReceiptLineID: received.ID, Weight: 1})
+			}
+			tt.change(receipt, cost)
+
+			err := allocateLandedCost(receipt, cost)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
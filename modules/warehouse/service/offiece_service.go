@@ -34,6 +34,9 @@ func (s *officeService) GetByID(id string) (*model.Office, error) {
 	return s.repo.GetByID(id)
 }
 func (s *officeService) Create(office *model.Office) error {
+	if err := validateValuationMethod(office); err != nil {
+		return err
+	}
 	return s.repo.Create(office)
 }
 func (s *officeService) Update(id string, office *model.Office) error {
@@ -44,9 +47,29 @@ func (s *officeService) Update(id string, office *model.Office) error {
 	if existing == nil {
 		return errors.New("Office not found")
 	}
+	// Clients that do not send the valuation method keep the current one,
+	// switching it would re-value the office's history
+	if office.ValuationMethod == "" {
+		office.ValuationMethod = existing.ValuationMethod
+	}
+	if err := validateValuationMethod(office); err != nil {
+		return err
+	}
 	office.ID = existing.ID // Ensure the ID is set for update
 	return s.repo.Update(office)
 }
 func (s *officeService) Delete(id string) error {
 	return s.repo.Delete(id)
 }
+
+// validateValuationMethod defaults an empty valuation method to moving average and rejects unknown ones
+func validateValuationMethod(office *model.Office) error {
+	switch office.ValuationMethod {
+	case "":
+		office.ValuationMethod = model.ValuationMethodAverage
+	case model.ValuationMethodAverage, model.ValuationMethodFIFO:
+	default:
+		return errors.New("invalid valuation method: must be average or fifo")
+	}
+	return nil
+}
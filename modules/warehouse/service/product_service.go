@@ -2,6 +2,9 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
 	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
@@ -14,17 +17,31 @@ type ProductService interface {
 	Create(product *model.Product) error
 	Update(id uuid.UUID, product *model.Product) error
 	Delete(id uuid.UUID) error
+	// GetByBarcode finds the product and packaging unit of a scanned barcode
+	GetByBarcode(code string) (*BarcodeMatch, error)
+}
+
+// BarcodeMatch is the product and packaging unit a scanned barcode stands for.
+// The unit code can be passed as the unit of stock quantities so scanned
+// packages are counted in small units.
+type BarcodeMatch struct {
+	Barcode string            `json:"barcode"`
+	Product model.Product     `json:"product"`
+	Unit    model.UnitProduct `json:"unit"`
+	Factor  int               `json:"factor"` // Small units in one scanned package
 }
 
 type productService struct {
 	repo         repository.ProductRepository
 	categoryRepo repository.CategoryProductRepository
+	unitRepo     repository.UnitProductRepository
 }
 
-func NewProductService(repo repository.ProductRepository, categoryRepo repository.CategoryProductRepository) ProductService {
+func NewProductService(repo repository.ProductRepository, categoryRepo repository.CategoryProductRepository, unitRepo repository.UnitProductRepository) ProductService {
 	return &productService{
 		repo:         repo,
 		categoryRepo: categoryRepo,
+		unitRepo:     unitRepo,
 	}
 }
 
@@ -37,6 +54,13 @@ func (s *productService) GetByID(id uuid.UUID) (*model.Product, error) {
 }
 
 func (s *productService) Create(product *model.Product) error {
+	if err := s.buildUnitHierarchy(product); err != nil {
+		return err
+	}
+	if err := s.validateBarcodes(product, uuid.Nil); err != nil {
+		return err
+	}
+
 	// Validate required fields
 	if err := s.validateProduct(product); err != nil {
 		return err
@@ -60,6 +84,31 @@ func (s *productService) Update(id uuid.UUID, product *model.Product) error {
 		return errors.New("product not found")
 	}
 
+	// Without units in the request the product keeps its packaging hierarchy,
+	// which the checks below still need
+	keepUnits := product.Units == nil
+	if keepUnits {
+		product.Units = existing.Units
+		if len(existing.Units) > 0 {
+			product.SmallUnit = existing.SmallUnit
+			product.LargeUnit = existing.LargeUnit
+			product.ContentPerLargeUnit = existing.ContentPerLargeUnit
+		}
+	} else if err := s.buildUnitHierarchy(product); err != nil {
+		return err
+	}
+	if err := s.checkUnitChange(existing, product); err != nil {
+		return err
+	}
+	// Kept barcodes must still be printed on a unit of the product
+	keepBarcodes := product.Barcodes == nil
+	if keepBarcodes {
+		product.Barcodes = existing.Barcodes
+	}
+	if err := s.validateBarcodes(product, existing.ID); err != nil {
+		return err
+	}
+
 	// Validate required fields
 	if err := s.validateProduct(product); err != nil {
 		return err
@@ -71,7 +120,88 @@ func (s *productService) Update(id uuid.UUID, product *model.Product) error {
 	}
 
 	product.ID = existing.ID // Ensure the ID is set for update
-	return s.repo.Update(product)
+	units, barcodes := product.Units, product.Barcodes
+	if keepUnits {
+		product.Units = nil
+	}
+	if keepBarcodes {
+		product.Barcodes = nil
+	}
+	if err := s.repo.Update(product); err != nil {
+		return err
+	}
+	product.Units, product.Barcodes = units, barcodes
+	return nil
+}
+
+// checkUnitChange keeps the base unit and the factors of the units of a product
+// fixed once stock has moved, since the ledger holds its quantities in base units
+func (s *productService) checkUnitChange(existing, product *model.Product) error {
+	before, err := s.unitFactors(existing)
+	if err != nil {
+		return err
+	}
+	after, err := s.unitFactors(product)
+	if err != nil {
+		return err
+	}
+
+	changed := ""
+	if !strings.EqualFold(baseUnitCode(existing), baseUnitCode(product)) {
+		changed = "base unit"
+	} else {
+		codes := make([]string, 0, len(before))
+		for code := range before {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		for _, code := range codes {
+			if factor, ok := after[code]; ok && factor != before[code] {
+				changed = "factor of " + code
+				break
+			}
+		}
+	}
+	if changed == "" {
+		return nil
+	}
+
+	hasStock, err := s.repo.HasStock(existing.ID)
+	if err != nil {
+		return err
+	}
+	if hasStock {
+		return fmt.Errorf("invalid unit change: product %s has stock, so its %s cannot change", existing.Code, changed)
+	}
+	return nil
+}
+
+// unitFactors maps the lower-cased code of every unit of a product to the base units it holds
+func (s *productService) unitFactors(product *model.Product) (map[string]int, error) {
+	factors := map[string]int{}
+	if len(product.Units) == 0 {
+		factors[strings.ToLower(product.SmallUnit)] = 1
+		if product.LargeUnit != "" && !strings.EqualFold(product.LargeUnit, product.SmallUnit) {
+			factors[strings.ToLower(product.LargeUnit)] = max(product.ContentPerLargeUnit, 1)
+		}
+		return factors, nil
+	}
+
+	for _, level := range product.Units {
+		unit := level.Unit
+		if unit == nil {
+			var err error
+			unit, err = s.unitRepo.GetByID(level.UnitID)
+			if err != nil {
+				return nil, errors.New("failed to validate unit: " + err.Error())
+			}
+			if unit == nil {
+				return nil, fmt.Errorf("unit %s not found", level.UnitID)
+			}
+		}
+		factors[strings.ToLower(unit.Code)] = level.Factor
+	}
+	return factors, nil
 }
 
 // validateProduct validates the product fields
@@ -103,9 +233,147 @@ func (s *productService) validateProduct(product *model.Product) error {
 	if product.CategoryID == uuid.Nil {
 		return errors.New("category ID is required")
 	}
+	if product.Controlled {
+		switch product.ControlledClass {
+		case model.ControlledClassNarcotic, model.ControlledClassPsychotropic, model.ControlledClassPrecursor:
+		default:
+			return fmt.Errorf("invalid controlled class %q: must be narcotic, psychotropic or precursor", product.ControlledClass)
+		}
+	} else if product.ControlledClass != "" {
+		return errors.New("invalid controlled class: product is not controlled")
+	}
+	return nil
+}
+
+// buildUnitHierarchy checks the packaging levels of a product, base unit first,
+// numbers them and derives their factors. The small and large unit fields follow
+// the base and the outermost level so existing unit handling keeps working.
+func (s *productService) buildUnitHierarchy(product *model.Product) error {
+	if product == nil || len(product.Units) == 0 {
+		return nil
+	}
+
+	seen := map[uuid.UUID]bool{}
+	codes := make([]string, len(product.Units))
+	factor := 1
+	for i := range product.Units {
+		level := &product.Units[i]
+		if level.UnitID == uuid.Nil {
+			return errors.New("unit ID is required")
+		}
+		if seen[level.UnitID] {
+			return fmt.Errorf("invalid unit hierarchy: unit %s is used twice", level.UnitID)
+		}
+		seen[level.UnitID] = true
+
+		unit, err := s.unitRepo.GetByID(level.UnitID)
+		if err != nil {
+			return errors.New("failed to validate unit: " + err.Error())
+		}
+		if unit == nil {
+			return fmt.Errorf("unit %s not found", level.UnitID)
+		}
+		codes[i] = unit.Code
+
+		if i == 0 {
+			level.Content = 1
+		} else if level.Content <= 1 {
+			return fmt.Errorf("invalid unit hierarchy: %s must hold more than one %s", codes[i], codes[i-1])
+		}
+		factor *= level.Content
+		level.Level = i
+		level.Factor = factor
+		level.Unit = nil
+	}
+
+	top := product.Units[len(product.Units)-1]
+	product.SmallUnit = codes[0]
+	product.LargeUnit = codes[len(codes)-1]
+	product.ContentPerLargeUnit = top.Factor
 	return nil
 }
 
+// validateBarcodes checks that every barcode of a product is a valid GTIN, is
+// printed on one of its units and is not taken by another product
+func (s *productService) validateBarcodes(product *model.Product, productID uuid.UUID) error {
+	if product == nil {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for i := range product.Barcodes {
+		barcode := &product.Barcodes[i]
+		barcode.Code = strings.TrimSpace(barcode.Code)
+		if barcode.Code == "" {
+			return errors.New("barcode is required")
+		}
+		if err := validateBarcode(barcode.Code); err != nil {
+			return err
+		}
+		forms := barcodeForms(barcode.Code)
+		for _, form := range forms {
+			if seen[form] {
+				return fmt.Errorf("invalid barcode %q: listed twice", barcode.Code)
+			}
+		}
+		seen[barcode.Code] = true
+
+		if barcode.UnitID == uuid.Nil {
+			return errors.New("barcode unit ID is required")
+		}
+		unit, err := s.unitRepo.GetByID(barcode.UnitID)
+		if err != nil {
+			return errors.New("failed to validate unit: " + err.Error())
+		}
+		if unit == nil {
+			return fmt.Errorf("unit %s not found", barcode.UnitID)
+		}
+		if !productHasUnit(product, unit) {
+			return fmt.Errorf("invalid barcode %q: %s is not a unit of the product", barcode.Code, unit.Code)
+		}
+
+		taken, err := s.repo.GetBarcode(forms...)
+		if err != nil {
+			return err
+		}
+		if taken != nil && taken.ProductID != productID {
+			return fmt.Errorf("invalid barcode %q: already assigned to another product", barcode.Code)
+		}
+		barcode.Unit = nil
+	}
+	return nil
+}
+
+func (s *productService) GetByBarcode(code string) (*BarcodeMatch, error) {
+	code = strings.TrimSpace(code)
+	if err := validateBarcode(code); err != nil {
+		return nil, err
+	}
+
+	barcode, err := s.repo.GetBarcode(barcodeForms(code)...)
+	if err != nil || barcode == nil {
+		return nil, err
+	}
+	if barcode.Unit == nil {
+		return nil, fmt.Errorf("unit %s not found", barcode.UnitID)
+	}
+	product, err := s.repo.GetByID(barcode.ProductID)
+	if err != nil || product == nil {
+		return nil, err
+	}
+	factor, err := unitFactor(product, barcode.Unit.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BarcodeMatch{
+		Barcode: barcode.Code,
+		Product: *product,
+		Unit:    *barcode.Unit,
+		Factor:  factor,
+	}, nil
+}
+
 // validateCategoryExists checks if the category exists
 func (s *productService) validateCategoryExists(categoryID uuid.UUID) error {
 	if categoryID == uuid.Nil {
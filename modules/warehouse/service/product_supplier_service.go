@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ProductSupplierService interface {
+	GetByProduct(productID uuid.UUID) ([]model.ProductSupplier, error)
+	Save(link *model.ProductSupplier) error
+	Delete(productID, supplierID uuid.UUID) error
+}
+
+type productSupplierService struct {
+	db           *gorm.DB
+	repo         repository.ProductSupplierRepository
+	productRepo  repository.ProductRepository
+	supplierRepo repository.SupplierRepository
+}
+
+func NewProductSupplierService(db *gorm.DB, repo repository.ProductSupplierRepository, productRepo repository.ProductRepository, supplierRepo repository.SupplierRepository) ProductSupplierService {
+	return &productSupplierService{db: db, repo: repo, productRepo: productRepo, supplierRepo: supplierRepo}
+}
+
+func (s *productSupplierService) GetByProduct(productID uuid.UUID) ([]model.ProductSupplier, error) {
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, errors.New("product not found")
+	}
+	return s.repo.GetByProduct(productID)
+}
+
+// Save creates or replaces the catalog entry of a supplier for a product.
+// Marking it preferred unmarks the product's other suppliers. The last
+// purchase price is kept, as it is maintained by goods receipts.
+func (s *productSupplierService) Save(link *model.ProductSupplier) error {
+	if link == nil {
+		return errors.New("product supplier cannot be nil")
+	}
+	if link.LeadTimeDays < 0 {
+		return errors.New("lead time days cannot be negative")
+	}
+	if link.MinOrderQuantity < 0 {
+		return errors.New("minimum order quantity cannot be negative")
+	}
+
+	product, err := s.productRepo.GetByID(link.ProductID)
+	if err != nil {
+		return errors.New("failed to validate product: " + err.Error())
+	}
+	if product == nil {
+		return errors.New("product not found")
+	}
+	supplier, err := s.supplierRepo.GetByID(link.SupplierID)
+	if err != nil {
+		return errors.New("failed to validate supplier: " + err.Error())
+	}
+	if supplier == nil {
+		return errors.New("supplier not found")
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		existing, err := repo.Get(link.ProductID, link.SupplierID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			link.ID = existing.ID
+			link.CreatedAt = existing.CreatedAt
+			link.LastPurchasePrice = existing.LastPurchasePrice
+			link.LastPurchasedAt = existing.LastPurchasedAt
+		}
+		if link.IsPreferred {
+			if err := repo.ClearPreferred(link.ProductID); err != nil {
+				return err
+			}
+		}
+		return repo.Save(link)
+	})
+	if err != nil {
+		return err
+	}
+	link.Supplier = supplier
+	return nil
+}
+
+func (s *productSupplierService) Delete(productID, supplierID uuid.UUID) error {
+	existing, err := s.repo.Get(productID, supplierID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.New("product supplier not found")
+	}
+	return s.repo.Delete(productID, supplierID)
+}
@@ -0,0 +1,408 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PurchaseOrderService interface {
+	GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.PurchaseOrder, int64, error)
+	GetByID(id uuid.UUID) (*model.PurchaseOrder, error)
+	Create(order *model.PurchaseOrder) error
+	Update(id uuid.UUID, order *model.PurchaseOrder) error
+	Approve(id uuid.UUID, userID uint) (*model.PurchaseOrder, error)
+	Receive(id uuid.UUID, receipt *model.GoodsReceipt) (*model.PurchaseOrder, error)
+	GetReceipts(id uuid.UUID) ([]model.GoodsReceipt, error)
+	Close(id uuid.UUID, userID uint) (*model.PurchaseOrder, error)
+}
+
+// PurchaseOrderPolicy holds the tolerances enforced on goods receipts, in percent
+type PurchaseOrderPolicy struct {
+	OverReceiptTolerance   float64 // How far the received quantity may exceed the ordered quantity
+	PriceVarianceTolerance float64 // How far the received unit price may deviate from the ordered price
+}
+
+type purchaseOrderService struct {
+	db                *gorm.DB
+	repo              repository.PurchaseOrderRepository
+	receiptRepo       repository.GoodsReceiptRepository
+	stockEntryService StockEntryService
+	productRepo       repository.ProductRepository
+	warehouseRepo     repository.WarehouseRepository
+	supplierRepo      repository.SupplierRepository
+	catalogRepo       repository.ProductSupplierRepository
+	policy            PurchaseOrderPolicy
+}
+
+func NewPurchaseOrderService(db *gorm.DB, repo repository.PurchaseOrderRepository, receiptRepo repository.GoodsReceiptRepository, stockEntryService StockEntryService, productRepo repository.ProductRepository, warehouseRepo repository.WarehouseRepository, supplierRepo repository.SupplierRepository, catalogRepo repository.ProductSupplierRepository, policy PurchaseOrderPolicy) PurchaseOrderService {
+	return &purchaseOrderService{
+		db:                db,
+		repo:              repo,
+		receiptRepo:       receiptRepo,
+		stockEntryService: stockEntryService,
+		productRepo:       productRepo,
+		warehouseRepo:     warehouseRepo,
+		supplierRepo:      supplierRepo,
+		catalogRepo:       catalogRepo,
+		policy:            policy,
+	}
+}
+
+func (s *purchaseOrderService) GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.PurchaseOrder, int64, error) {
+	return s.repo.GetAll(page, pageSize, warehouseID, status)
+}
+
+func (s *purchaseOrderService) GetByID(id uuid.UUID) (*model.PurchaseOrder, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *purchaseOrderService) Create(order *model.PurchaseOrder) error {
+	if err := s.validateOrder(order); err != nil {
+		return err
+	}
+
+	order.Number = generateDocumentNumber("PO")
+	order.Status = model.PurchaseOrderStatusDraft
+	if order.OrderDate.IsZero() {
+		order.OrderDate = time.Now()
+	}
+	return s.repo.Create(order)
+}
+
+func (s *purchaseOrderService) Update(id uuid.UUID, order *model.PurchaseOrder) error {
+	existing, err := s.loadWithStatus(s.repo, id, model.PurchaseOrderStatusDraft)
+	if err != nil {
+		return err
+	}
+	if err := s.validateOrder(order); err != nil {
+		return err
+	}
+
+	existing.WarehouseID = order.WarehouseID
+	existing.SupplierID = order.SupplierID
+	existing.SupplierName = order.SupplierName
+	existing.ExpectedDate = order.ExpectedDate
+	existing.Notes = order.Notes
+	existing.Lines = order.Lines
+	if !order.OrderDate.IsZero() {
+		existing.OrderDate = order.OrderDate
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		if err := repo.ReplaceLines(existing); err != nil {
+			return err
+		}
+		lines := existing.Lines
+		existing.Lines = nil
+		if err := repo.Update(existing); err != nil {
+			return err
+		}
+		existing.Lines = lines
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	*order = *existing
+	return nil
+}
+
+// Approve releases a draft order for receiving
+func (s *purchaseOrderService) Approve(id uuid.UUID, userID uint) (*model.PurchaseOrder, error) {
+	order, err := s.loadWithStatus(s.repo, id, model.PurchaseOrderStatusDraft)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	order.Status = model.PurchaseOrderStatusApproved
+	order.ApprovedAt = &now
+	order.ApprovedBy = userID
+	if err := s.repo.Update(order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// Receive books a delivery into the order's warehouse. Each receipt line becomes
+// a receipt stock entry carrying the order ID, batch and expiry. Quantities may
+// exceed the ordered quantity and prices may deviate from the ordered price only
+// within the configured tolerances.
+func (s *purchaseOrderService) Receive(id uuid.UUID, receipt *model.GoodsReceipt) (*model.PurchaseOrder, error) {
+	if receipt == nil || len(receipt.Lines) == 0 {
+		return nil, errors.New("at least one receipt line is required")
+	}
+
+	original := *receipt
+	original.Lines = append([]model.GoodsReceiptLine(nil), receipt.Lines...)
+
+	var order *model.PurchaseOrder
+	err := stockTransaction(s.db, func(tx *gorm.DB) error {
+		*receipt = original
+		receipt.Lines = append([]model.GoodsReceiptLine(nil), original.Lines...)
+		repo := s.repo.WithTx(tx)
+
+		// Concurrent receipts against the order run one after the other, so
+		// each checks the tolerances against what the others received
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		order, err = s.loadWithStatus(repo, id, model.PurchaseOrderStatusApproved, model.PurchaseOrderStatusPartiallyReceived)
+		if err != nil {
+			return err
+		}
+
+		receipt.ID = uuid.New()
+		receipt.Number = generateDocumentNumber("GRN")
+		receipt.OrderID = order.ID
+		receipt.WarehouseID = order.WarehouseID
+		if receipt.Date.IsZero() {
+			receipt.Date = time.Now()
+		}
+
+		for i := range receipt.Lines {
+			received := &receipt.Lines[i]
+			line := findOrderLine(order, received.OrderLineID)
+			if line == nil {
+				return fmt.Errorf("purchase order line %s not found", received.OrderLineID)
+			}
+			if received.Quantity <= 0 {
+				return errors.New("received quantity must be greater than 0")
+			}
+			received.Quantity, received.ConversionResidual, err = s.stockEntryService.ToBaseQuantity(line.ProductID, received.Quantity, received.Unit)
+			if err != nil {
+				return err
+			}
+			received.Unit = ""
+			if received.Price < 0 {
+				return errors.New("received price cannot be negative")
+			}
+			if received.Price == 0 {
+				received.Price = line.UnitPrice()
+			}
+			if err := s.checkReceiptLine(line, received); err != nil {
+				return err
+			}
+
+			// The approver of the order authorizes what is received against it
+			entry := &model.StockEntry{
+				WarehouseID:        order.WarehouseID,
+				ProductID:          line.ProductID,
+				BatchNumber:        received.BatchNumber,
+				ExpiredAt:          received.ExpiredAt,
+				Date:               receipt.Date,
+				Quantity:           received.Quantity,
+				ConversionResidual: received.ConversionResidual,
+				Price:              received.Price,
+				Status:             model.StockEntryStatusReceipt,
+				QCStatus:           received.QCStatus,
+				OrderID:            order.ID,
+				ReferenceID:        receipt.ID,
+				Notes:              "Goods receipt " + receipt.Number,
+				CreatedBy:          receipt.ReceivedBy,
+				AuthorizedBy:       order.ApprovedBy,
+				Counterparty:       order.SupplierName,
+			}
+			if err := s.stockEntryService.Post(tx, entry); err != nil {
+				return err
+			}
+
+			received.ProductID = line.ProductID
+			received.StockEntryID = entry.ID
+			received.QCStatus = entry.QCStatus
+			line.ReceivedQuantity += received.Quantity
+
+			if order.SupplierID != nil {
+				if err := s.recordPurchasePrice(tx, *order.SupplierID, received, receipt.Date); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := s.receiptRepo.WithTx(tx).Create(receipt); err != nil {
+			return err
+		}
+
+		order.Status = model.PurchaseOrderStatusReceived
+		for _, line := range order.Lines {
+			if line.Outstanding() > 0 {
+				order.Status = model.PurchaseOrderStatusPartiallyReceived
+				break
+			}
+		}
+		return repo.Update(order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (s *purchaseOrderService) GetReceipts(id uuid.UUID) ([]model.GoodsReceipt, error) {
+	order, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, errors.New("purchase order not found")
+	}
+	return s.receiptRepo.GetByOrder(id)
+}
+
+// Close finishes an order, giving up on any quantity still outstanding
+func (s *purchaseOrderService) Close(id uuid.UUID, userID uint) (*model.PurchaseOrder, error) {
+	order, err := s.loadWithStatus(s.repo, id, model.PurchaseOrderStatusApproved, model.PurchaseOrderStatusPartiallyReceived, model.PurchaseOrderStatusReceived)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	order.Status = model.PurchaseOrderStatusClosed
+	order.ClosedAt = &now
+	order.ClosedBy = userID
+	if err := s.repo.Update(order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// recordPurchasePrice keeps the supplier catalog's last purchase price current,
+// adding the product to the catalog on its first receipt from the supplier
+func (s *purchaseOrderService) recordPurchasePrice(tx *gorm.DB, supplierID uuid.UUID, received *model.GoodsReceiptLine, date time.Time) error {
+	catalogRepo := s.catalogRepo.WithTx(tx)
+	link, err := catalogRepo.Get(received.ProductID, supplierID)
+	if err != nil {
+		return err
+	}
+	if link == nil {
+		link = &model.ProductSupplier{ProductID: received.ProductID, SupplierID: supplierID}
+	}
+	link.LastPurchasePrice = received.Price
+	link.LastPurchasedAt = &date
+	return catalogRepo.Save(link)
+}
+
+// checkReceiptLine enforces the over-receipt and price-variance tolerances
+func (s *purchaseOrderService) checkReceiptLine(line *model.PurchaseOrderLine, received *model.GoodsReceiptLine) error {
+	ordered := line.OrderedQuantity()
+	allowed := ordered + int(math.Floor(float64(ordered)*s.policy.OverReceiptTolerance/100))
+	if line.ReceivedQuantity+received.Quantity > allowed {
+		return fmt.Errorf("invalid received quantity for line %s: ordered %d, already received %d, receiving %d exceeds the allowed %d",
+			line.ID, ordered, line.ReceivedQuantity, received.Quantity, allowed)
+	}
+
+	expected := line.UnitPrice()
+	if expected > 0 {
+		variance := math.Abs(received.Price-expected) / expected * 100
+		if variance > s.policy.PriceVarianceTolerance {
+			return fmt.Errorf("invalid received price for line %s: %.2f deviates %.1f%% from the ordered %.2f, tolerance is %.1f%%",
+				line.ID, received.Price, variance, expected, s.policy.PriceVarianceTolerance)
+		}
+	}
+	return nil
+}
+
+// loadWithStatus fetches an order and checks it is in one of the allowed statuses
+func (s *purchaseOrderService) loadWithStatus(repo repository.PurchaseOrderRepository, id uuid.UUID, allowed ...string) (*model.PurchaseOrder, error) {
+	order, err := repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, errors.New("purchase order not found")
+	}
+	for _, status := range allowed {
+		if order.Status == status {
+			return order, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid purchase order status: order is %s", order.Status)
+}
+
+// validateOrder checks the warehouse and lines of an order and resolves each line's unit
+func (s *purchaseOrderService) validateOrder(order *model.PurchaseOrder) error {
+	if order == nil {
+		return errors.New("purchase order cannot be nil")
+	}
+	if order.WarehouseID == uuid.Nil {
+		return errors.New("warehouse ID is required")
+	}
+	if len(order.Lines) == 0 {
+		return errors.New("at least one order line is required")
+	}
+
+	warehouse, err := s.warehouseRepo.GetByID(order.WarehouseID)
+	if err != nil {
+		return errors.New("failed to validate warehouse: " + err.Error())
+	}
+	if warehouse == nil {
+		return errors.New("warehouse not found")
+	}
+
+	if order.SupplierID != nil {
+		supplier, err := s.supplierRepo.GetByID(*order.SupplierID)
+		if err != nil {
+			return errors.New("failed to validate supplier: " + err.Error())
+		}
+		if supplier == nil {
+			return errors.New("supplier not found")
+		}
+		if supplier.Status != model.SupplierStatusActive {
+			return fmt.Errorf("invalid supplier: %s is %s", supplier.Code, supplier.Status)
+		}
+		order.SupplierName = supplier.Name
+	}
+
+	for i := range order.Lines {
+		line := &order.Lines[i]
+		if line.Quantity <= 0 {
+			return errors.New("line quantity must be greater than 0")
+		}
+		if line.Price < 0 {
+			return errors.New("line price cannot be negative")
+		}
+		product, err := s.productRepo.GetByID(line.ProductID)
+		if err != nil {
+			return errors.New("failed to validate product: " + err.Error())
+		}
+		if product == nil {
+			return fmt.Errorf("product %s not found", line.ProductID)
+		}
+		if err := resolveOrderUnit(line, product); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveOrderUnit sets the unit content of a line ordered in any unit of the product
+func resolveOrderUnit(line *model.PurchaseOrderLine, product *model.Product) error {
+	if line.Unit == "" {
+		line.Unit = product.SmallUnit
+	}
+	factor, err := unitFactor(product, line.Unit)
+	if err != nil {
+		return err
+	}
+	line.UnitContent = factor
+	return nil
+}
+
+func findOrderLine(order *model.PurchaseOrder, lineID uuid.UUID) *model.PurchaseOrderLine {
+	for i := range order.Lines {
+		if order.Lines[i].ID == lineID {
+			return &order.Lines[i]
+		}
+	}
+	return nil
+}
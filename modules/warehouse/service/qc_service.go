@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// QCService records quality-control decisions on batches. Each decision is a
+// qc_change ledger row of zero quantity carrying the new QC status, so the
+// batch history shows who held, released or rejected it and when.
+type QCService interface {
+	// GetBatches lists batches on hand in a QC status, every status other than released when empty
+	GetBatches(warehouseID, productID uuid.UUID, status string, page, pageSize int) ([]model.StockBalance, int64, error)
+	// Hold puts a released batch back in quarantine pending a QC decision
+	Hold(entry *model.StockEntry) error
+	// Release makes a quarantined batch available for allocation, reservation and issue
+	Release(entry *model.StockEntry) error
+	// Reject marks a quarantined batch as unusable. It can then only leave through
+	// a supplier return, a write-off or a correcting adjustment.
+	Reject(entry *model.StockEntry) error
+}
+
+type qcService struct {
+	db                *gorm.DB
+	balanceRepo       repository.StockBalanceRepository
+	stockEntryService StockEntryService
+}
+
+func NewQCService(db *gorm.DB, balanceRepo repository.StockBalanceRepository, stockEntryService StockEntryService) QCService {
+	return &qcService{
+		db:                db,
+		balanceRepo:       balanceRepo,
+		stockEntryService: stockEntryService,
+	}
+}
+
+func (s *qcService) GetBatches(warehouseID, productID uuid.UUID, status string, page, pageSize int) ([]model.StockBalance, int64, error) {
+	switch status {
+	case "", model.QCStatusReleased, model.QCStatusQuarantine, model.QCStatusRejected:
+	default:
+		return nil, 0, fmt.Errorf("invalid qc status %q: must be released, quarantine or rejected", status)
+	}
+	return s.balanceRepo.GetByQCStatus(warehouseID, productID, status, page, pageSize)
+}
+
+func (s *qcService) Hold(entry *model.StockEntry) error {
+	return s.decide(entry, model.QCStatusQuarantine, model.QCStatusReleased)
+}
+
+func (s *qcService) Release(entry *model.StockEntry) error {
+	return s.decide(entry, model.QCStatusReleased, model.QCStatusQuarantine)
+}
+
+func (s *qcService) Reject(entry *model.StockEntry) error {
+	if entry.Notes == "" {
+		return errors.New("rejection reason is required")
+	}
+	return s.decide(entry, model.QCStatusRejected, model.QCStatusQuarantine)
+}
+
+// decide moves the whole batch position from the from status to the target
+// status. Stock on hand is required so the decision applies to real goods.
+func (s *qcService) decide(entry *model.StockEntry, target, from string) error {
+	if entry.WarehouseID == uuid.Nil {
+		return errors.New("warehouse ID is required")
+	}
+	if entry.ProductID == uuid.Nil {
+		return errors.New("product ID is required")
+	}
+	if entry.BatchNumber == "" {
+		return errors.New("batch number is required")
+	}
+
+	original := *entry
+	return stockTransaction(s.db, func(tx *gorm.DB) error {
+		*entry = original
+
+		balances, err := s.balanceRepo.WithTx(tx).LockProduct(entry.WarehouseID, entry.ProductID)
+		if err != nil {
+			return err
+		}
+		balance := findBalance(balances, entry.BatchNumber)
+		if balance == nil || balance.Quantity <= 0 {
+			return fmt.Errorf("batch %q not found on hand in this warehouse", entry.BatchNumber)
+		}
+		if balance.QCStatus != from {
+			return fmt.Errorf("invalid qc status: batch %q is %s, expected %s", entry.BatchNumber, balance.QCStatus, from)
+		}
+
+		entry.Quantity = 0
+		entry.Status = model.StockEntryStatusQCChange
+		entry.QCStatus = target
+		return s.stockEntryService.Post(tx, entry)
+	})
+}
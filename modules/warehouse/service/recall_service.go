@@ -0,0 +1,471 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RecallService interface {
+	GetAll(page, pageSize int, productID uuid.UUID, status string) ([]model.Recall, int64, error)
+	GetByID(id uuid.UUID) (*model.Recall, error)
+	// Open rejects the batch in every warehouse holding it and creates a return
+	// or disposal task for each of them
+	Open(recall *model.Recall) error
+	// CompleteTask returns or disposes of what is left of the batch in the task's
+	// warehouse. An empty action keeps the action of the task.
+	CompleteTask(recallID, taskID uuid.UUID, action, notes string, authorizedBy uint, counterparty string, userID uint) (*model.Recall, error)
+	// Close ends a recall once every task is done and none of the batch is left on hand
+	Close(id uuid.UUID, userID uint) (*model.Recall, error)
+	// GetTrace follows a batch through every warehouse and document that moved it
+	GetTrace(productID uuid.UUID, batchNumber string) (*BatchTrace, error)
+}
+
+// BatchTrace is where a batch came from, where it went and where it still is
+type BatchTrace struct {
+	ProductID   uuid.UUID       `json:"product_id"`
+	ProductCode string          `json:"product_code"`
+	ProductName string          `json:"product_name"`
+	Unit        string          `json:"unit"`
+	BatchNumber string          `json:"batch_number"`
+	ExpiredAt   time.Time       `json:"expired_at"`
+	Received    int             `json:"received"` // Received from suppliers
+	Consumed    int             `json:"consumed"` // Issued to consuming documents
+	OnHand      int             `json:"on_hand"`
+	Holdings    []BatchHolding  `json:"holdings"`
+	Sources     []BatchDocument `json:"sources"`   // Goods receipts and direct receipts
+	Consumers   []BatchDocument `json:"consumers"` // Documents the batch was issued to
+	Movements   []BatchMovement `json:"movements"` // Every ledger movement, oldest first
+}
+
+// BatchHolding is the stock of the batch in one warehouse
+type BatchHolding struct {
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	WarehouseName string    `json:"warehouse_name"`
+	Quantity      int       `json:"quantity"`
+	QCStatus      string    `json:"qc_status"`
+}
+
+// BatchDocument totals the movements of the batch posted for one document in one warehouse
+type BatchDocument struct {
+	ReferenceID   uuid.UUID `json:"reference_id"`
+	OrderID       uuid.UUID `json:"order_id"`
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	WarehouseName string    `json:"warehouse_name"`
+	Quantity      int       `json:"quantity"` // In small units, always positive
+	FirstDate     time.Time `json:"first_date"`
+	LastDate      time.Time `json:"last_date"`
+}
+
+type BatchMovement struct {
+	ID            uuid.UUID `json:"id"`
+	Date          time.Time `json:"date"`
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	WarehouseName string    `json:"warehouse_name"`
+	Status        string    `json:"status"`
+	Quantity      int       `json:"quantity"`
+	Stock         int       `json:"stock"`
+	QCStatus      string    `json:"qc_status"`
+	OrderID       uuid.UUID `json:"order_id"`
+	ReferenceID   uuid.UUID `json:"reference_id"`
+	Notes         string    `json:"notes"`
+	CreatedBy     uint      `json:"created_by"`
+}
+
+type recallService struct {
+	db                *gorm.DB
+	repo              repository.RecallRepository
+	entryRepo         repository.StockEntryRepository
+	balanceRepo       repository.StockBalanceRepository
+	productRepo       repository.ProductRepository
+	warehouseRepo     repository.WarehouseRepository
+	stockEntryService StockEntryService
+}
+
+func NewRecallService(db *gorm.DB, repo repository.RecallRepository, entryRepo repository.StockEntryRepository, balanceRepo repository.StockBalanceRepository, productRepo repository.ProductRepository, warehouseRepo repository.WarehouseRepository, stockEntryService StockEntryService) RecallService {
+	return &recallService{
+		db:                db,
+		repo:              repo,
+		entryRepo:         entryRepo,
+		balanceRepo:       balanceRepo,
+		productRepo:       productRepo,
+		warehouseRepo:     warehouseRepo,
+		stockEntryService: stockEntryService,
+	}
+}
+
+func (s *recallService) GetAll(page, pageSize int, productID uuid.UUID, status string) ([]model.Recall, int64, error) {
+	return s.repo.GetAll(page, pageSize, productID, status)
+}
+
+func (s *recallService) GetByID(id uuid.UUID) (*model.Recall, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *recallService) Open(recall *model.Recall) error {
+	if recall == nil {
+		return errors.New("recall cannot be nil")
+	}
+	if recall.ProductID == uuid.Nil {
+		return errors.New("product ID is required")
+	}
+	if recall.BatchNumber == "" {
+		return errors.New("batch number is required")
+	}
+	if recall.Reason == "" {
+		return errors.New("recall reason is required")
+	}
+	if recall.Action == "" {
+		recall.Action = model.RecallActionReturn
+	}
+	if err := validateRecallAction(recall.Action); err != nil {
+		return err
+	}
+
+	product, err := s.productRepo.GetByID(recall.ProductID)
+	if err != nil {
+		return err
+	}
+	if product == nil {
+		return errors.New("product not found")
+	}
+
+	original := *recall
+	return stockTransaction(s.db, func(tx *gorm.DB) error {
+		*recall = original
+		repo := s.repo.WithTx(tx)
+		balanceRepo := s.balanceRepo.WithTx(tx)
+
+		// Of two recalls of a batch opened at once, the unique index on open
+		// recalls fails the second; its retry then finds the first one here
+		existing, err := repo.GetOpen(recall.ProductID, recall.BatchNumber)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return fmt.Errorf("invalid recall: batch %q is already under recall %s", recall.BatchNumber, existing.Number)
+		}
+
+		recall.ID = uuid.New()
+		recall.Number = generateDocumentNumber("RCL")
+		recall.Status = model.RecallStatusOpen
+		recall.OpenedAt = time.Now()
+
+		balances, err := balanceRepo.ListByBatch(recall.ProductID, recall.BatchNumber)
+		if err != nil {
+			return err
+		}
+		for _, balance := range balances {
+			// Lock the product in the warehouse and read the batch again before rejecting it
+			locked, err := balanceRepo.LockProduct(balance.WarehouseID, recall.ProductID)
+			if err != nil {
+				return err
+			}
+			current := findBalance(locked, recall.BatchNumber)
+			if current == nil || current.Quantity <= 0 {
+				continue
+			}
+
+			if current.QCStatus != model.QCStatusRejected {
+				entry := &model.StockEntry{
+					WarehouseID: current.WarehouseID,
+					ProductID:   recall.ProductID,
+					BatchNumber: recall.BatchNumber,
+					Status:      model.StockEntryStatusQCChange,
+					QCStatus:    model.QCStatusRejected,
+					ReferenceID: recall.ID,
+					Notes:       "Recall " + recall.Number,
+					CreatedBy:   recall.OpenedBy,
+				}
+				if err := s.stockEntryService.Post(tx, entry); err != nil {
+					return err
+				}
+			}
+
+			recall.Tasks = append(recall.Tasks, model.RecallTask{
+				WarehouseID: current.WarehouseID,
+				Action:      recall.Action,
+				Status:      model.RecallTaskStatusOpen,
+				Quantity:    current.Quantity,
+			})
+		}
+		return repo.Create(recall)
+	})
+}
+
+func (s *recallService) CompleteTask(recallID, taskID uuid.UUID, action, notes string, authorizedBy uint, counterparty string, userID uint) (*model.Recall, error) {
+	if action != "" {
+		if err := validateRecallAction(action); err != nil {
+			return nil, err
+		}
+	}
+
+	var recall *model.Recall
+	err := stockTransaction(s.db, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		// A second completion of the task waits here and then finds it done.
+		// Arrivals of the batch lock the recall before the product too.
+		if err := repo.Lock(recallID); err != nil {
+			return err
+		}
+		var err error
+		recall, err = s.loadOpen(repo, recallID)
+		if err != nil {
+			return err
+		}
+
+		var task *model.RecallTask
+		for i := range recall.Tasks {
+			if recall.Tasks[i].ID == taskID {
+				task = &recall.Tasks[i]
+				break
+			}
+		}
+		if task == nil {
+			return errors.New("recall task not found")
+		}
+		if task.Status != model.RecallTaskStatusOpen {
+			return fmt.Errorf("invalid task status: task is %s", task.Status)
+		}
+		if action != "" {
+			task.Action = action
+		}
+
+		// Stock may already have left through a supplier return or write-off;
+		// whatever is left is moved out now
+		balances, err := s.balanceRepo.WithTx(tx).LockProduct(task.WarehouseID, recall.ProductID)
+		if err != nil {
+			return err
+		}
+		if current := findBalance(balances, recall.BatchNumber); current != nil && current.Quantity > 0 {
+			status := model.StockEntryStatusReturnOut
+			if task.Action == model.RecallActionDispose {
+				status = model.StockEntryStatusWriteOff
+			}
+			entry := &model.StockEntry{
+				WarehouseID:  task.WarehouseID,
+				ProductID:    recall.ProductID,
+				BatchNumber:  recall.BatchNumber,
+				Quantity:     -current.Quantity,
+				Status:       status,
+				ReferenceID:  recall.ID,
+				Notes:        "Recall " + recall.Number,
+				CreatedBy:    userID,
+				AuthorizedBy: authorizedBy,
+				Counterparty: counterparty,
+			}
+			if err := s.stockEntryService.Post(tx, entry); err != nil {
+				return err
+			}
+			task.DoneQuantity = current.Quantity
+			task.StockEntryID = &entry.ID
+		}
+
+		now := time.Now()
+		task.Status = model.RecallTaskStatusDone
+		task.AuthorizedBy = authorizedBy
+		task.Counterparty = counterparty
+		task.Notes = notes
+		task.CompletedAt = &now
+		task.CompletedBy = userID
+		return repo.Update(recall)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recall, nil
+}
+
+func (s *recallService) Close(id uuid.UUID, userID uint) (*model.Recall, error) {
+	var recall *model.Recall
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		// Stock of the batch arriving in the meantime locks the recall too,
+		// so it either gets its task before this check or is no longer rejected
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		recall, err = s.loadOpen(repo, id)
+		if err != nil {
+			return err
+		}
+
+		open := 0
+		for _, task := range recall.Tasks {
+			if task.Status == model.RecallTaskStatusOpen {
+				open++
+			}
+		}
+		if open > 0 {
+			return fmt.Errorf("invalid recall: %d tasks are still open", open)
+		}
+
+		balances, err := s.balanceRepo.WithTx(tx).ListByBatch(recall.ProductID, recall.BatchNumber)
+		if err != nil {
+			return err
+		}
+		if len(balances) > 0 {
+			return fmt.Errorf("invalid recall: batch %q is still on hand in %d warehouses", recall.BatchNumber, len(balances))
+		}
+
+		now := time.Now()
+		recall.Status = model.RecallStatusClosed
+		recall.ClosedAt = &now
+		recall.ClosedBy = userID
+		return repo.Update(recall)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recall, nil
+}
+
+// loadOpen fetches a recall and checks it is still open
+func (s *recallService) loadOpen(repo repository.RecallRepository, id uuid.UUID) (*model.Recall, error) {
+	recall, err := repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if recall == nil {
+		return nil, errors.New("recall not found")
+	}
+	if recall.Status != model.RecallStatusOpen {
+		return nil, fmt.Errorf("invalid recall status: recall is %s", recall.Status)
+	}
+	return recall, nil
+}
+
+func (s *recallService) GetTrace(productID uuid.UUID, batchNumber string) (*BatchTrace, error) {
+	if batchNumber == "" {
+		return nil, errors.New("batch number is required")
+	}
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, errors.New("product not found")
+	}
+
+	entries, err := s.entryRepo.ListByBatch(productID, batchNumber)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := s.balanceRepo.ListByBatch(productID, batchNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	trace := &BatchTrace{
+		ProductID:   product.ID,
+		ProductCode: product.Code,
+		ProductName: product.Name,
+		Unit:        product.SmallUnit,
+		BatchNumber: batchNumber,
+		Holdings:    make([]BatchHolding, 0, len(balances)),
+		Sources:     []BatchDocument{},
+		Consumers:   []BatchDocument{},
+		Movements:   make([]BatchMovement, 0, len(entries)),
+	}
+
+	names := map[uuid.UUID]string{}
+	for _, balance := range balances {
+		if balance.Warehouse != nil {
+			names[balance.WarehouseID] = balance.Warehouse.Name
+		}
+		trace.Holdings = append(trace.Holdings, BatchHolding{
+			WarehouseID:   balance.WarehouseID,
+			WarehouseName: names[balance.WarehouseID],
+			Quantity:      balance.Quantity,
+			QCStatus:      balance.QCStatus,
+		})
+		trace.OnHand += balance.Quantity
+	}
+
+	// documentKey groups the movements of one document in one warehouse
+	type documentKey struct {
+		referenceID uuid.UUID
+		warehouseID uuid.UUID
+	}
+	sources := map[documentKey]int{}
+	consumers := map[documentKey]int{}
+	// addDocument adds a movement to its document total, starting a new one on first use
+	addDocument := func(documents *[]BatchDocument, index map[documentKey]int, entry model.StockEntry) {
+		key := documentKey{entry.ReferenceID, entry.WarehouseID}
+		i, ok := index[key]
+		if !ok {
+			i = len(*documents)
+			index[key] = i
+			*documents = append(*documents, BatchDocument{
+				ReferenceID:   entry.ReferenceID,
+				OrderID:       entry.OrderID,
+				WarehouseID:   entry.WarehouseID,
+				WarehouseName: names[entry.WarehouseID],
+				FirstDate:     entry.Date,
+			})
+		}
+		document := &(*documents)[i]
+		if entry.Quantity < 0 {
+			document.Quantity -= entry.Quantity
+		} else {
+			document.Quantity += entry.Quantity
+		}
+		document.LastDate = entry.Date
+	}
+
+	for _, entry := range entries {
+		if _, ok := names[entry.WarehouseID]; !ok {
+			warehouse, err := s.warehouseRepo.GetByID(entry.WarehouseID)
+			if err != nil {
+				return nil, err
+			}
+			if warehouse != nil {
+				names[entry.WarehouseID] = warehouse.Name
+			}
+		}
+		if !entry.ExpiredAt.IsZero() {
+			trace.ExpiredAt = entry.ExpiredAt
+		}
+
+		switch entry.Status {
+		case model.StockEntryStatusReceipt:
+			trace.Received += entry.Quantity
+			addDocument(&trace.Sources, sources, entry)
+		case model.StockEntryStatusIssue:
+			trace.Consumed += -entry.Quantity
+			addDocument(&trace.Consumers, consumers, entry)
+		}
+
+		trace.Movements = append(trace.Movements, BatchMovement{
+			ID:            entry.ID,
+			Date:          entry.Date,
+			WarehouseID:   entry.WarehouseID,
+			WarehouseName: names[entry.WarehouseID],
+			Status:        entry.Status,
+			Quantity:      entry.Quantity,
+			Stock:         entry.Stock,
+			QCStatus:      entry.QCStatus,
+			OrderID:       entry.OrderID,
+			ReferenceID:   entry.ReferenceID,
+			Notes:         entry.Notes,
+			CreatedBy:     entry.CreatedBy,
+		})
+	}
+	return trace, nil
+}
+
+func validateRecallAction(action string) error {
+	switch action {
+	case model.RecallActionReturn, model.RecallActionDispose:
+		return nil
+	}
+	return fmt.Errorf("invalid recall action %q: must be return or dispose", action)
+}
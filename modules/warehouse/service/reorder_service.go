@@ -0,0 +1,215 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+)
+
+type ReorderService interface {
+	GetAll(page, pageSize int, warehouseID, productID uuid.UUID) ([]model.ReorderSetting, int64, error)
+	Save(setting *model.ReorderSetting) error
+	Delete(id uuid.UUID) error
+	GetSuggestions(warehouseID, officeID uuid.UUID) ([]ReorderSuggestion, error)
+}
+
+// ReorderSuggestion is the quantity to order to bring a product back up to its
+// maximum stock, including the demand expected until the order arrives
+type ReorderSuggestion struct {
+	WarehouseID         uuid.UUID `json:"warehouse_id"`
+	WarehouseName       string    `json:"warehouse_name"`
+	ProductID           uuid.UUID `json:"product_id"`
+	ProductCode         string    `json:"product_code"`
+	ProductName         string    `json:"product_name"`
+	OnHand              int       `json:"on_hand"`  // Released, unexpired stock
+	OnOrder             int       `json:"on_order"` // Still to be received on approved purchase orders
+	MinStock            int       `json:"min_stock"`
+	ReorderPoint        int       `json:"reorder_point"`
+	MaxStock            int       `json:"max_stock"`
+	BelowMinimum        bool      `json:"below_minimum"`
+	ForecastDailyDemand float64   `json:"forecast_daily_demand"`
+	LeadTimeDemand      int       `json:"lead_time_demand"`   // Forecast demand over the supplier lead time
+	ProjectedStock      int       `json:"projected_stock"`    // On hand and on order minus lead time demand
+	SuggestedQuantity   int       `json:"suggested_quantity"` // In small units, a whole number of large units
+	SmallUnit           string    `json:"small_unit"`
+	LargeUnit           string    `json:"large_unit"`
+	ContentPerLargeUnit int       `json:"content_per_large_unit"`
+	LargeUnitQuantity   int       `json:"large_unit_quantity"`
+	// Preferred vendor from the product-supplier catalog, if any
+	SupplierID       *uuid.UUID `json:"supplier_id"`
+	SupplierName     string     `json:"supplier_name"`
+	LeadTimeDays     int        `json:"lead_time_days"`
+	MinOrderQuantity int        `json:"min_order_quantity"`
+}
+
+type reorderService struct {
+	repo          repository.ReorderSettingRepository
+	catalogRepo   repository.ProductSupplierRepository
+	productRepo   repository.ProductRepository
+	warehouseRepo repository.WarehouseRepository
+	forecast      ForecastService
+}
+
+func NewReorderService(repo repository.ReorderSettingRepository, catalogRepo repository.ProductSupplierRepository, productRepo repository.ProductRepository, warehouseRepo repository.WarehouseRepository, forecast ForecastService) ReorderService {
+	return &reorderService{repo: repo, catalogRepo: catalogRepo, productRepo: productRepo, warehouseRepo: warehouseRepo, forecast: forecast}
+}
+
+func (s *reorderService) GetAll(page, pageSize int, warehouseID, productID uuid.UUID) ([]model.ReorderSetting, int64, error) {
+	return s.repo.GetAll(page, pageSize, warehouseID, productID)
+}
+
+// Save creates or replaces the setting of a product in a warehouse
+func (s *reorderService) Save(setting *model.ReorderSetting) error {
+	if err := s.validateSetting(setting); err != nil {
+		return err
+	}
+
+	existing, err := s.repo.Get(setting.WarehouseID, setting.ProductID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		setting.ID = existing.ID
+		setting.CreatedAt = existing.CreatedAt
+	}
+	return s.repo.Save(setting)
+}
+
+func (s *reorderService) Delete(id uuid.UUID) error {
+	setting, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if setting == nil {
+		return errors.New("reorder setting not found")
+	}
+	return s.repo.Delete(id)
+}
+
+// GetSuggestions lists products whose usable stock plus what is already on
+// order, after the demand forecast over the preferred supplier's lead time, is
+// at or below their reorder point. The quantity brings stock back to maximum
+// once that demand is covered, is raised to the supplier's minimum order
+// quantity and rounded up to whole large units.
+func (s *reorderService) GetSuggestions(warehouseID, officeID uuid.UUID) ([]ReorderSuggestion, error) {
+	rows, err := s.repo.GetCandidates(warehouseID, officeID)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]ReorderSuggestion, 0, len(rows))
+	for _, row := range rows {
+		vendor, err := s.catalogRepo.GetPreferred(row.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		dailyDemand := 0.0
+		leadTimeDemand := 0
+		if vendor != nil && vendor.LeadTimeDays > 0 {
+			if dailyDemand, err = s.forecast.DailyDemand(row.ProductID, row.WarehouseID); err != nil {
+				return nil, err
+			}
+			leadTimeDemand = int(math.Ceil(dailyDemand * float64(vendor.LeadTimeDays)))
+		}
+		projected := row.OnHand + row.OnOrder - leadTimeDemand
+		if projected > row.ReorderPoint {
+			continue
+		}
+		shortage := row.MaxStock - projected
+		if shortage <= 0 {
+			continue
+		}
+		if vendor != nil {
+			shortage = max(shortage, vendor.MinOrderQuantity)
+		}
+		quantity := roundUpToLargeUnit(shortage, row.ContentPerLargeUnit)
+
+		suggestion := ReorderSuggestion{
+			WarehouseID:         row.WarehouseID,
+			WarehouseName:       row.WarehouseName,
+			ProductID:           row.ProductID,
+			ProductCode:         row.ProductCode,
+			ProductName:         row.ProductName,
+			OnHand:              row.OnHand,
+			OnOrder:             row.OnOrder,
+			MinStock:            row.MinStock,
+			ReorderPoint:        row.ReorderPoint,
+			MaxStock:            row.MaxStock,
+			BelowMinimum:        row.OnHand < row.MinStock,
+			ForecastDailyDemand: dailyDemand,
+			LeadTimeDemand:      leadTimeDemand,
+			ProjectedStock:      projected,
+			SuggestedQuantity:   quantity,
+			SmallUnit:           row.SmallUnit,
+			LargeUnit:           row.LargeUnit,
+			ContentPerLargeUnit: row.ContentPerLargeUnit,
+			LargeUnitQuantity:   quantity,
+		}
+		if row.ContentPerLargeUnit > 0 {
+			suggestion.LargeUnitQuantity = quantity / row.ContentPerLargeUnit
+		}
+		if vendor != nil {
+			suggestion.SupplierID = &vendor.SupplierID
+			suggestion.SupplierName = vendor.Supplier.Name
+			suggestion.LeadTimeDays = vendor.LeadTimeDays
+			suggestion.MinOrderQuantity = vendor.MinOrderQuantity
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, nil
+}
+
+// roundUpToLargeUnit rounds a small-unit quantity up to a whole number of large units
+func roundUpToLargeUnit(quantity, contentPerLargeUnit int) int {
+	if contentPerLargeUnit <= 1 {
+		return quantity
+	}
+	return (quantity + contentPerLargeUnit - 1) / contentPerLargeUnit * contentPerLargeUnit
+}
+
+// validateSetting checks references and that min <= reorder point <= max.
+// An empty reorder point defaults to the minimum stock.
+func (s *reorderService) validateSetting(setting *model.ReorderSetting) error {
+	if setting == nil {
+		return errors.New("reorder setting cannot be nil")
+	}
+	if setting.WarehouseID == uuid.Nil {
+		return errors.New("warehouse ID is required")
+	}
+	if setting.ProductID == uuid.Nil {
+		return errors.New("product ID is required")
+	}
+	if setting.MinStock < 0 || setting.ReorderPoint < 0 || setting.MaxStock < 0 {
+		return errors.New("stock levels cannot be negative")
+	}
+	if setting.MaxStock <= 0 {
+		return errors.New("max stock must be greater than 0")
+	}
+	if setting.ReorderPoint == 0 {
+		setting.ReorderPoint = setting.MinStock
+	}
+	if setting.ReorderPoint < setting.MinStock || setting.ReorderPoint > setting.MaxStock {
+		return fmt.Errorf("invalid stock levels: expected min (%d) <= reorder point (%d) <= max (%d)", setting.MinStock, setting.ReorderPoint, setting.MaxStock)
+	}
+
+	warehouse, err := s.warehouseRepo.GetByID(setting.WarehouseID)
+	if err != nil {
+		return errors.New("failed to validate warehouse: " + err.Error())
+	}
+	if warehouse == nil {
+		return errors.New("warehouse not found")
+	}
+	product, err := s.productRepo.GetByID(setting.ProductID)
+	if err != nil {
+		return errors.New("failed to validate product: " + err.Error())
+	}
+	if product == nil {
+		return errors.New("product not found")
+	}
+	return nil
+}
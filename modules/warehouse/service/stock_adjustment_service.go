@@ -0,0 +1,473 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StockAdjustmentService interface {
+	GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.StockAdjustment, int64, error)
+	GetByID(id uuid.UUID) (*model.StockAdjustment, error)
+	Create(adjustment *model.StockAdjustment) error
+	Update(id uuid.UUID, adjustment *model.StockAdjustment) error
+	// Submit values the lines and posts them right away unless the document needs approval
+	Submit(id uuid.UUID, userID uint) (*model.StockAdjustment, error)
+	Approve(id uuid.UUID, userID uint) (*model.StockAdjustment, error)
+	Reject(id uuid.UUID, userID uint, notes string) (*model.StockAdjustment, error)
+	Cancel(id uuid.UUID) (*model.StockAdjustment, error)
+	AddAttachment(id uuid.UUID, attachment *model.StockAdjustmentAttachment) error
+	DeleteAttachment(id, attachmentID uuid.UUID) error
+}
+
+// StockAdjustmentPolicy decides which adjustments wait for approval
+type StockAdjustmentPolicy struct {
+	ApprovalThreshold float64 // Documents worth more than this need approval before posting
+}
+
+type stockAdjustmentService struct {
+	db                *gorm.DB
+	repo              repository.StockAdjustmentRepository
+	reasonRepo        repository.AdjustmentReasonRepository
+	entryRepo         repository.StockEntryRepository
+	stockEntryService StockEntryService
+	productRepo       repository.ProductRepository
+	warehouseRepo     repository.WarehouseRepository
+	policy            StockAdjustmentPolicy
+}
+
+func NewStockAdjustmentService(db *gorm.DB, repo repository.StockAdjustmentRepository, reasonRepo repository.AdjustmentReasonRepository, entryRepo repository.StockEntryRepository, stockEntryService StockEntryService, productRepo repository.ProductRepository, warehouseRepo repository.WarehouseRepository, policy StockAdjustmentPolicy) StockAdjustmentService {
+	return &stockAdjustmentService{
+		db:                db,
+		repo:              repo,
+		reasonRepo:        reasonRepo,
+		entryRepo:         entryRepo,
+		stockEntryService: stockEntryService,
+		productRepo:       productRepo,
+		warehouseRepo:     warehouseRepo,
+		policy:            policy,
+	}
+}
+
+func (s *stockAdjustmentService) GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.StockAdjustment, int64, error) {
+	return s.repo.GetAll(page, pageSize, warehouseID, status)
+}
+
+func (s *stockAdjustmentService) GetByID(id uuid.UUID) (*model.StockAdjustment, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *stockAdjustmentService) Create(adjustment *model.StockAdjustment) error {
+	if err := s.validateAdjustment(adjustment); err != nil {
+		return err
+	}
+
+	adjustment.Number = generateDocumentNumber("ADJ")
+	adjustment.Status = model.StockAdjustmentStatusDraft
+	if adjustment.Date.IsZero() {
+		adjustment.Date = time.Now()
+	}
+	return s.repo.Create(adjustment)
+}
+
+func (s *stockAdjustmentService) Update(id uuid.UUID, adjustment *model.StockAdjustment) error {
+	existing, err := s.loadWithStatus(s.repo, id, model.StockAdjustmentStatusDraft)
+	if err != nil {
+		return err
+	}
+	if err := s.validateAdjustment(adjustment); err != nil {
+		return err
+	}
+
+	existing.WarehouseID = adjustment.WarehouseID
+	existing.Notes = adjustment.Notes
+	existing.AuthorizedBy = adjustment.AuthorizedBy
+	existing.Counterparty = adjustment.Counterparty
+	existing.Lines = adjustment.Lines
+	if !adjustment.Date.IsZero() {
+		existing.Date = adjustment.Date
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		// The adjustment may have been submitted since it was read
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		if _, err := s.loadWithStatus(repo, id, model.StockAdjustmentStatusDraft); err != nil {
+			return err
+		}
+		if err := repo.ReplaceLines(existing); err != nil {
+			return err
+		}
+		lines := existing.Lines
+		existing.Lines = nil
+		if err := repo.Update(existing); err != nil {
+			return err
+		}
+		existing.Lines = lines
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	*adjustment = *existing
+	return nil
+}
+
+func (s *stockAdjustmentService) Submit(id uuid.UUID, userID uint) (*model.StockAdjustment, error) {
+	// The submitter is recorded so they cannot approve their own adjustment
+	if userID == 0 {
+		return nil, errors.New("submitting user is required")
+	}
+
+	var adjustment *model.StockAdjustment
+	err := stockTransaction(s.db, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		// A second submission waits here and then finds the adjustment submitted
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		adjustment, err = s.loadWithStatus(repo, id, model.StockAdjustmentStatusDraft)
+		if err != nil {
+			return err
+		}
+
+		reasonNeedsApproval, err := s.valueLines(tx, adjustment)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		adjustment.SubmittedAt = &now
+		adjustment.SubmittedBy = userID
+		adjustment.RequiresApproval = reasonNeedsApproval || adjustment.TotalValue > s.policy.ApprovalThreshold
+		if err := s.checkControlledLines(adjustment); err != nil {
+			return err
+		}
+		if adjustment.RequiresApproval {
+			adjustment.Status = model.StockAdjustmentStatusPendingApproval
+			return repo.Update(adjustment)
+		}
+		return s.post(tx, adjustment, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return adjustment, nil
+}
+
+func (s *stockAdjustmentService) Approve(id uuid.UUID, userID uint) (*model.StockAdjustment, error) {
+	if userID == 0 {
+		return nil, errors.New("approving user is required")
+	}
+
+	var adjustment *model.StockAdjustment
+	err := stockTransaction(s.db, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		// A second approval waits here and then finds the adjustment posted
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		adjustment, err = s.loadWithStatus(repo, id, model.StockAdjustmentStatusPendingApproval)
+		if err != nil {
+			return err
+		}
+		if userID == adjustment.SubmittedBy {
+			return errors.New("invalid approver: an adjustment cannot be approved by the user who submitted it")
+		}
+		return s.post(tx, adjustment, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return adjustment, nil
+}
+
+func (s *stockAdjustmentService) Reject(id uuid.UUID, userID uint, notes string) (*model.StockAdjustment, error) {
+	if notes == "" {
+		return nil, errors.New("rejection reason is required")
+	}
+
+	var adjustment *model.StockAdjustment
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		adjustment, err = s.loadWithStatus(repo, id, model.StockAdjustmentStatusPendingApproval)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		adjustment.Status = model.StockAdjustmentStatusRejected
+		adjustment.RejectedAt = &now
+		adjustment.RejectedBy = userID
+		adjustment.RejectionNotes = notes
+		return repo.Update(adjustment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return adjustment, nil
+}
+
+// Cancel abandons an adjustment that has not been posted
+func (s *stockAdjustmentService) Cancel(id uuid.UUID) (*model.StockAdjustment, error) {
+	var adjustment *model.StockAdjustment
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		adjustment, err = s.loadWithStatus(repo, id, model.StockAdjustmentStatusDraft, model.StockAdjustmentStatusPendingApproval)
+		if err != nil {
+			return err
+		}
+		adjustment.Status = model.StockAdjustmentStatusCancelled
+		return repo.Update(adjustment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return adjustment, nil
+}
+
+// AddAttachment links evidence to an adjustment. Posted adjustments still accept
+// attachments so reports arriving later can be filed with them.
+func (s *stockAdjustmentService) AddAttachment(id uuid.UUID, attachment *model.StockAdjustmentAttachment) error {
+	if attachment.FileName == "" {
+		return errors.New("file name is required")
+	}
+	if attachment.URL == "" {
+		return errors.New("attachment URL is required")
+	}
+	if _, err := s.loadWithStatus(s.repo, id, model.StockAdjustmentStatusDraft, model.StockAdjustmentStatusPendingApproval, model.StockAdjustmentStatusApproved); err != nil {
+		return err
+	}
+
+	attachment.ID = uuid.Nil
+	attachment.AdjustmentID = id
+	return s.repo.AddAttachment(attachment)
+}
+
+// DeleteAttachment removes evidence while the adjustment is still under review
+func (s *stockAdjustmentService) DeleteAttachment(id, attachmentID uuid.UUID) error {
+	if _, err := s.loadWithStatus(s.repo, id, model.StockAdjustmentStatusDraft, model.StockAdjustmentStatusPendingApproval); err != nil {
+		return err
+	}
+	deleted, err := s.repo.DeleteAttachment(id, attachmentID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.New("attachment not found")
+	}
+	return nil
+}
+
+// valueLines prices every line and totals the document. Decreases are valued at
+// the cost of the batch they leave; increases use their own price, falling back
+// to the batch cost and then the product purchase price. It reports whether any
+// line carries a reason that always requires approval.
+func (s *stockAdjustmentService) valueLines(tx *gorm.DB, adjustment *model.StockAdjustment) (bool, error) {
+	entryRepo := s.entryRepo.WithTx(tx)
+	reasons := map[uuid.UUID]*model.AdjustmentReason{}
+	needsApproval := false
+
+	adjustment.TotalValue = 0
+	for i := range adjustment.Lines {
+		line := &adjustment.Lines[i]
+
+		reason, ok := reasons[line.ReasonID]
+		if !ok {
+			var err error
+			reason, err = s.reasonRepo.GetByID(line.ReasonID)
+			if err != nil {
+				return false, err
+			}
+			if reason == nil {
+				return false, fmt.Errorf("adjustment reason %s not found", line.ReasonCode)
+			}
+			reasons[line.ReasonID] = reason
+		}
+		needsApproval = needsApproval || reason.RequiresApproval
+
+		if line.Quantity < 0 || line.Price == 0 {
+			latest, err := entryRepo.GetLatest(adjustment.WarehouseID, line.ProductID, line.BatchNumber)
+			if err != nil {
+				return false, err
+			}
+			if latest != nil {
+				line.Price = latest.Price
+			} else if line.Price == 0 {
+				product, err := s.productRepo.GetByID(line.ProductID)
+				if err != nil {
+					return false, err
+				}
+				if product != nil {
+					line.Price = product.PurchasePrice
+				}
+			}
+		}
+
+		line.Value = math.Abs(float64(line.Quantity)) * line.Price
+		adjustment.TotalValue += line.Value
+	}
+	return needsApproval, nil
+}
+
+// checkControlledLines makes sure controlled product lines can go into the
+// register once the adjustment posts. They need a named counterparty and an
+// authorizing user, which the approver becomes when the document goes through approval.
+func (s *stockAdjustmentService) checkControlledLines(adjustment *model.StockAdjustment) error {
+	for _, line := range adjustment.Lines {
+		product, err := s.productRepo.GetByID(line.ProductID)
+		if err != nil {
+			return err
+		}
+		if product == nil || !product.Controlled {
+			continue
+		}
+		if adjustment.Counterparty == "" {
+			return fmt.Errorf("counterparty is required for controlled product %s", product.Code)
+		}
+		if adjustment.AuthorizedBy == 0 && !adjustment.RequiresApproval {
+			return fmt.Errorf("authorizing user is required for controlled product %s", product.Code)
+		}
+	}
+	return nil
+}
+
+// post turns every line into an adjustment stock entry carrying its reason code
+func (s *stockAdjustmentService) post(tx *gorm.DB, adjustment *model.StockAdjustment, userID uint) error {
+	authorizedBy := adjustment.AuthorizedBy
+	if authorizedBy == 0 && adjustment.RequiresApproval {
+		authorizedBy = userID
+	}
+	for i := range adjustment.Lines {
+		line := &adjustment.Lines[i]
+		entry := &model.StockEntry{
+			WarehouseID:        adjustment.WarehouseID,
+			ProductID:          line.ProductID,
+			BatchNumber:        line.BatchNumber,
+			ExpiredAt:          line.ExpiredAt,
+			Date:               adjustment.Date,
+			Quantity:           line.Quantity,
+			ConversionResidual: line.ConversionResidual,
+			Price:              line.Price,
+			Status:             model.StockEntryStatusAdjustment,
+			ReasonCode:         line.ReasonCode,
+			ReferenceID:        adjustment.ID,
+			Notes:              "Adjustment " + adjustment.Number,
+			CreatedBy:          userID,
+			AuthorizedBy:       authorizedBy,
+			Counterparty:       adjustment.Counterparty,
+		}
+		if err := s.stockEntryService.Post(tx, entry); err != nil {
+			return err
+		}
+		line.StockEntryID = entry.ID
+	}
+
+	now := time.Now()
+	adjustment.Status = model.StockAdjustmentStatusApproved
+	adjustment.ApprovedAt = &now
+	adjustment.ApprovedBy = userID
+	return s.repo.WithTx(tx).Update(adjustment)
+}
+
+// loadWithStatus fetches an adjustment and checks it is in one of the allowed statuses
+func (s *stockAdjustmentService) loadWithStatus(repo repository.StockAdjustmentRepository, id uuid.UUID, allowed ...string) (*model.StockAdjustment, error) {
+	adjustment, err := repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if adjustment == nil {
+		return nil, errors.New("stock adjustment not found")
+	}
+	for _, status := range allowed {
+		if adjustment.Status == status {
+			return adjustment, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid adjustment status: adjustment is %s", adjustment.Status)
+}
+
+// validateAdjustment checks the warehouse, products, quantities and reason codes.
+// Lines are matched to their reason by code.
+func (s *stockAdjustmentService) validateAdjustment(adjustment *model.StockAdjustment) error {
+	if adjustment == nil {
+		return errors.New("stock adjustment cannot be nil")
+	}
+	if adjustment.WarehouseID == uuid.Nil {
+		return errors.New("warehouse ID is required")
+	}
+	if len(adjustment.Lines) == 0 {
+		return errors.New("at least one adjustment line is required")
+	}
+
+	warehouse, err := s.warehouseRepo.GetByID(adjustment.WarehouseID)
+	if err != nil {
+		return errors.New("failed to validate warehouse: " + err.Error())
+	}
+	if warehouse == nil {
+		return errors.New("warehouse not found")
+	}
+
+	for i := range adjustment.Lines {
+		line := &adjustment.Lines[i]
+		if line.Quantity == 0 {
+			return errors.New("line quantity is required")
+		}
+		if line.Price < 0 {
+			return errors.New("line price cannot be negative")
+		}
+		if line.ReasonCode == "" {
+			return errors.New("reason code is required")
+		}
+
+		product, err := s.productRepo.GetByID(line.ProductID)
+		if err != nil {
+			return errors.New("failed to validate product: " + err.Error())
+		}
+		if product == nil {
+			return fmt.Errorf("product %s not found", line.ProductID)
+		}
+		line.Quantity, line.ConversionResidual, err = s.stockEntryService.ToBaseQuantity(product.ID, line.Quantity, line.Unit)
+		if err != nil {
+			return err
+		}
+		line.Unit = ""
+
+		reason, err := s.reasonRepo.GetByCode(line.ReasonCode)
+		if err != nil {
+			return errors.New("failed to validate reason code: " + err.Error())
+		}
+		if reason == nil {
+			return fmt.Errorf("adjustment reason %q not found", line.ReasonCode)
+		}
+		if reason.Status != model.AdjustmentReasonStatusActive {
+			return fmt.Errorf("invalid reason code: %q is inactive", line.ReasonCode)
+		}
+		if !reason.Allows(line.Quantity) {
+			return fmt.Errorf("invalid reason code: %q only allows a stock %s", line.ReasonCode, reason.Direction)
+		}
+		line.ReasonID = reason.ID
+	}
+	return nil
+}
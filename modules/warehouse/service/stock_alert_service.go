@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+)
+
+type StockAlertService interface {
+	GetAll(page, pageSize int, alertType string, acknowledged *bool) ([]model.StockAlert, int64, error)
+	Acknowledge(id uuid.UUID, userID uint) (*model.StockAlert, error)
+	// ScanExpiry raises near-expiry and expired alerts for batches expiring within days.
+	// It returns the number of new alerts.
+	ScanExpiry(days int) (int, error)
+}
+
+type stockAlertService struct {
+	repo       repository.StockAlertRepository
+	reportRepo repository.StockReportRepository
+}
+
+func NewStockAlertService(repo repository.StockAlertRepository, reportRepo repository.StockReportRepository) StockAlertService {
+	return &stockAlertService{repo: repo, reportRepo: reportRepo}
+}
+
+func (s *stockAlertService) GetAll(page, pageSize int, alertType string, acknowledged *bool) ([]model.StockAlert, int64, error) {
+	return s.repo.GetAll(page, pageSize, alertType, acknowledged)
+}
+
+func (s *stockAlertService) Acknowledge(id uuid.UUID, userID uint) (*model.StockAlert, error) {
+	alert, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if alert == nil {
+		return nil, errors.New("stock alert not found")
+	}
+	if alert.AcknowledgedAt != nil {
+		return alert, nil
+	}
+
+	now := time.Now()
+	alert.AcknowledgedAt = &now
+	alert.AcknowledgedBy = userID
+	if err := s.repo.Update(alert); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+func (s *stockAlertService) ScanExpiry(days int) (int, error) {
+	now := time.Now()
+	rows, err := s.reportRepo.GetExpiringBatches(now.AddDate(0, 0, days), uuid.Nil)
+	if err != nil {
+		return 0, err
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	raised := 0
+	for _, row := range rows {
+		alert := &model.StockAlert{
+			Type:        model.StockAlertTypeNearExpiry,
+			AlertDate:   today,
+			WarehouseID: row.WarehouseID,
+			ProductID:   row.ProductID,
+			BatchNumber: row.BatchNumber,
+			ExpiredAt:   row.ExpiredAt,
+			Quantity:    row.Quantity,
+			Message:     fmt.Sprintf("%s batch %s in %s expires on %s", row.ProductName, row.BatchNumber, row.WarehouseName, row.ExpiredAt.Format("2006-01-02")),
+		}
+		if isExpired(row.ExpiredAt, now) {
+			alert.Type = model.StockAlertTypeExpired
+			alert.Message = fmt.Sprintf("%s batch %s in %s expired on %s and must be written off", row.ProductName, row.BatchNumber, row.WarehouseName, row.ExpiredAt.Format("2006-01-02"))
+		}
+
+		created, err := s.repo.CreateIfAbsent(alert)
+		if err != nil {
+			return raised, err
+		}
+		if created {
+			raised++
+		}
+	}
+	return raised, nil
+}
+
+// StartExpiryScanner runs the expiry scan immediately and then once per interval until ctx is done
+func StartExpiryScanner(ctx context.Context, alertService StockAlertService, days int, interval time.Duration) {
+	scan := func() {
+		raised, err := alertService.ScanExpiry(days)
+		if err != nil {
+			log.Printf("Expiry scan failed: %v", err)
+			return
+		}
+		log.Printf("Expiry scan completed: %d new alerts", raised)
+	}
+
+	go func() {
+		scan()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				scan()
+			}
+		}
+	}()
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+)
+
+// batchAllocation is the quantity taken from one batch to fulfil an issue
+type batchAllocation struct {
+	BatchNumber string
+	Quantity    int
+}
+
+// allocateBatches spreads an issue quantity over the available batches.
+// FEFO consumes the batch that expires first, FIFO the batch received first.
+// Expired batches and batches not released by QC are never allocated and batches without an expiry date go last under FEFO.
+// With allowNegative a shortfall is charged to the last allocated batch, or to
+// the unbatched position when no batch has stock.
+func allocateBatches(balances []model.StockBalance, quantity int, policy string, allowNegative bool, now time.Time) ([]batchAllocation, error) {
+	candidates := make([]model.StockBalance, 0, len(balances))
+	available := 0
+	for _, balance := range balances {
+		if balance.Quantity <= 0 || isExpired(balance.ExpiredAt, now) || balance.QCStatus != model.QCStatusReleased {
+			continue
+		}
+		candidates = append(candidates, balance)
+		available += balance.Quantity
+	}
+
+	if available < quantity && !allowNegative {
+		return nil, fmt.Errorf("insufficient stock: available %d, requested %d", available, quantity)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if policy == model.IssuePolicyFIFO {
+			if !a.ReceivedAt.Equal(b.ReceivedAt) {
+				return a.ReceivedAt.Before(b.ReceivedAt)
+			}
+			return expiresBefore(a.ExpiredAt, b.ExpiredAt)
+		}
+		if !a.ExpiredAt.Equal(b.ExpiredAt) {
+			return expiresBefore(a.ExpiredAt, b.ExpiredAt)
+		}
+		return a.ReceivedAt.Before(b.ReceivedAt)
+	})
+
+	var allocations []batchAllocation
+	remaining := quantity
+	for _, balance := range candidates {
+		if remaining == 0 {
+			break
+		}
+		take := balance.Quantity
+		if take > remaining {
+			take = remaining
+		}
+		allocations = append(allocations, batchAllocation{BatchNumber: balance.BatchNumber, Quantity: take})
+		remaining -= take
+	}
+	if remaining > 0 {
+		if n := len(allocations); n > 0 {
+			allocations[n-1].Quantity += remaining
+		} else {
+			allocations = append(allocations, batchAllocation{Quantity: remaining})
+		}
+	}
+	return allocations, nil
+}
+
+// isExpired reports whether a batch with the given expiry date is expired at now
+func isExpired(expiredAt, now time.Time) bool {
+	return !expiredAt.IsZero() && !expiredAt.After(now)
+}
+
+// expiresBefore orders expiry dates ascending with undated batches last
+func expiresBefore(a, b time.Time) bool {
+	if a.IsZero() {
+		return false
+	}
+	if b.IsZero() {
+		return true
+	}
+	return a.Before(b)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+)
+
+func TestAllocateBatches(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	balances := []model.StockBalance{
+		{BatchNumber: "LATE", Quantity: 5, ExpiredAt: now.Add(10 * day), ReceivedAt: now.Add(-20 * day), QCStatus: model.QCStatusReleased},
+		{BatchNumber: "SOON", Quantity: 5, ExpiredAt: now.Add(5 * day), ReceivedAt: now.Add(-10 * day), QCStatus: model.QCStatusReleased},
+		{BatchNumber: "UNDATED", Quantity: 5, ReceivedAt: now.Add(-30 * day), QCStatus: model.QCStatusReleased},
+		{BatchNumber: "EXPIRED", Quantity: 5, ExpiredAt: now.Add(-day), ReceivedAt: now.Add(-40 * day), QCStatus: model.QCStatusReleased},
+		{BatchNumber: "EXPIRES_NOW", Quantity: 5, ExpiredAt: now, ReceivedAt: now.Add(-40 * day), QCStatus: model.QCStatusReleased},
+		{BatchNumber: "HELD", Quantity: 5, ExpiredAt: now.Add(day), ReceivedAt: now.Add(-40 * day), QCStatus: model.QCStatusQuarantine},
+		{BatchNumber: "EMPTY", Quantity: 0, ExpiredAt: now.Add(day), ReceivedAt: now.Add(-40 * day), QCStatus: model.QCStatusReleased},
+	}
+
+	tests := []struct {
+		name          string
+		balances      []model.StockBalance
+		quantity      int
+		policy        string
+		allowNegative bool
+		want          []batchAllocation
+	}{
+		{
+			name: "fefo takes the batch expiring first and undated batches last", balances: balances, quantity: 12, policy: model.IssuePolicyFEFO,
+			want: []batchAllocation{{"SOON", 5}, {"LATE", 5}, {"UNDATED", 2}},
+		},
+		{
+			name: "fifo takes the batch received first", balances: balances, quantity: 12, policy: model.IssuePolicyFIFO,
+			want: []batchAllocation{{"UNDATED", 5}, {"LATE", 5}, {"SOON", 2}},
+		},
+		{
+			name: "shortfall goes to the last batch", balances: balances, quantity: 17, policy: model.IssuePolicyFEFO, allowNegative: true,
+			want: []batchAllocation{{"SOON", 5}, {"LATE", 5}, {"UNDATED", 7}},
+		},
+		{
+			name: "shortfall without stock goes to the unbatched position", quantity: 3, policy: model.IssuePolicyFEFO, allowNegative: true,
+			want: []batchAllocation{{"", 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := allocateBatches(tt.balances, tt.quantity, tt.policy, tt.allowNegative, now)
+			if err != nil {
+				t.Fatalf("allocateBatches: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("allocations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("insufficient stock", func(t *testing.T) {
+		_, err := allocateBatches(balances, 16, model.IssuePolicyFEFO, false, now)
+		if err == nil || !strings.Contains(err.Error(), "insufficient stock: available 15, requested 16") {
+			t.Errorf("error = %v, want insufficient stock with 15 available", err)
+		}
+	})
+}
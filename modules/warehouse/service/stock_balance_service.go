@@ -0,0 +1,63 @@
+package service
+
+import (
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StockBalanceService interface {
+	// GetByWarehouse returns on-hand balances of a warehouse. A non-zero asOf
+	// rebuilds them from the ledger as of that moment instead.
+	GetByWarehouse(warehouseID uuid.UUID, asOf time.Time, page, pageSize int) ([]model.StockBalance, int64, error)
+	GetByProduct(productID uuid.UUID, asOf time.Time, page, pageSize int) ([]model.StockBalance, int64, error)
+	// Rebuild recomputes balances from the StockEntry ledger. Pass uuid.Nil to rebuild all warehouses.
+	Rebuild(warehouseID uuid.UUID) (int64, error)
+}
+
+type stockBalanceService struct {
+	db         *gorm.DB
+	repo       repository.StockBalanceRepository
+	periodRepo repository.StockPeriodRepository
+}
+
+func NewStockBalanceService(db *gorm.DB, repo repository.StockBalanceRepository, periodRepo repository.StockPeriodRepository) StockBalanceService {
+	return &stockBalanceService{db: db, repo: repo, periodRepo: periodRepo}
+}
+
+func (s *stockBalanceService) GetByWarehouse(warehouseID uuid.UUID, asOf time.Time, page, pageSize int) ([]model.StockBalance, int64, error) {
+	if asOf.IsZero() {
+		return s.repo.GetByWarehouse(warehouseID, page, pageSize)
+	}
+	return s.getAsOf(warehouseID, uuid.Nil, asOf, page, pageSize)
+}
+
+func (s *stockBalanceService) GetByProduct(productID uuid.UUID, asOf time.Time, page, pageSize int) ([]model.StockBalance, int64, error) {
+	if asOf.IsZero() {
+		return s.repo.GetByProduct(productID, page, pageSize)
+	}
+	return s.getAsOf(uuid.Nil, productID, asOf, page, pageSize)
+}
+
+// getAsOf starts from the snapshot of the latest period closed by asOf so only
+// the movements after it are replayed from the ledger
+func (s *stockBalanceService) getAsOf(warehouseID, productID uuid.UUID, asOf time.Time, page, pageSize int) ([]model.StockBalance, int64, error) {
+	base, err := s.periodRepo.GetLatestClosedAsOf(asOf)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.repo.GetAsOf(warehouseID, productID, asOf, base, page, pageSize)
+}
+
+func (s *stockBalanceService) Rebuild(warehouseID uuid.UUID) (int64, error) {
+	var rebuilt int64
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		count, err := s.repo.WithTx(tx).Rebuild(warehouseID)
+		rebuilt = count
+		return err
+	})
+	return rebuilt, err
+}
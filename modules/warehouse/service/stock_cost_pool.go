@@ -0,0 +1,134 @@
+package service
+
+import "github.com/antoniusDoni/monorepo/modules/warehouse/model"
+
+// costPool tracks the quantity and cost of one product in one warehouse as
+// ledger movements are replayed in date order
+type costPool interface {
+	// receive adds stock at the given unit cost
+	receive(quantity int, unitCost float64)
+	// issue removes stock and returns the cost of what was removed
+	issue(quantity int) float64
+	quantity() int
+	value() float64
+	// unitCost is the cost at which the next unit would leave the pool
+	unitCost() float64
+}
+
+func newCostPool(method string) costPool {
+	if method == model.ValuationMethodFIFO {
+		return &fifoPool{}
+	}
+	return &averagePool{}
+}
+
+// averagePool values stock at the weighted moving average of every receipt
+type averagePool struct {
+	qty      int
+	total    float64
+	lastCost float64
+}
+
+func (p *averagePool) receive(quantity int, unitCost float64) {
+	p.qty += quantity
+	p.total += float64(quantity) * unitCost
+	p.lastCost = unitCost
+	if p.qty == 0 {
+		p.total = 0
+	}
+}
+
+func (p *averagePool) issue(quantity int) float64 {
+	cost := float64(quantity) * p.unitCost()
+	p.qty -= quantity
+	p.total -= cost
+	if p.qty == 0 {
+		p.total = 0
+	}
+	return cost
+}
+
+func (p *averagePool) quantity() int { return p.qty }
+
+func (p *averagePool) value() float64 { return p.total }
+
+func (p *averagePool) unitCost() float64 {
+	if p.qty > 0 {
+		return p.total / float64(p.qty)
+	}
+	return p.lastCost
+}
+
+// costLayer is the remaining quantity of one receipt. A negative quantity is
+// stock issued before it was received, to be settled by the next receipt.
+type costLayer struct {
+	quantity int
+	unitCost float64
+}
+
+// fifoPool values stock at the cost of the oldest remaining receipts
+type fifoPool struct {
+	layers   []costLayer
+	lastCost float64
+}
+
+func (p *fifoPool) receive(quantity int, unitCost float64) {
+	p.lastCost = unitCost
+	for quantity > 0 && len(p.layers) > 0 && p.layers[0].quantity < 0 {
+		settled := min(quantity, -p.layers[0].quantity)
+		p.layers[0].quantity += settled
+		quantity -= settled
+		if p.layers[0].quantity == 0 {
+			p.layers = p.layers[1:]
+		}
+	}
+	if quantity > 0 {
+		p.layers = append(p.layers, costLayer{quantity: quantity, unitCost: unitCost})
+	}
+}
+
+func (p *fifoPool) issue(quantity int) float64 {
+	cost := 0.0
+	for quantity > 0 && len(p.layers) > 0 && p.layers[0].quantity > 0 {
+		taken := min(quantity, p.layers[0].quantity)
+		cost += float64(taken) * p.layers[0].unitCost
+		p.layers[0].quantity -= taken
+		quantity -= taken
+		if p.layers[0].quantity == 0 {
+			p.layers = p.layers[1:]
+		}
+	}
+	if quantity > 0 {
+		// Issued beyond what was received: owe the quantity at the last known cost
+		cost += float64(quantity) * p.lastCost
+		if len(p.layers) > 0 && p.layers[len(p.layers)-1].quantity < 0 {
+			p.layers[len(p.layers)-1].quantity -= quantity
+		} else {
+			p.layers = append(p.layers, costLayer{quantity: -quantity, unitCost: p.lastCost})
+		}
+	}
+	return cost
+}
+
+func (p *fifoPool) quantity() int {
+	total := 0
+	for _, layer := range p.layers {
+		total += layer.quantity
+	}
+	return total
+}
+
+func (p *fifoPool) value() float64 {
+	total := 0.0
+	for _, layer := range p.layers {
+		total += float64(layer.quantity) * layer.unitCost
+	}
+	return total
+}
+
+func (p *fifoPool) unitCost() float64 {
+	if len(p.layers) > 0 && p.layers[0].quantity > 0 {
+		return p.layers[0].unitCost
+	}
+	return p.lastCost
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+)
+
+// poolStep is one movement replayed into a cost pool with the expected state after it
+type poolStep struct {
+	receive   int
+	issue     int
+	unitCost  float64 // Cost of a receipt
+	wantCost  float64 // Cost returned by an issue
+	wantQty   int
+	wantValue float64
+	wantUnit  float64
+}
+
+func replayPool(t *testing.T, pool costPool, steps []poolStep) {
+	t.Helper()
+	for i, step := range steps {
+		if step.receive > 0 {
+			pool.receive(step.receive, step.unitCost)
+		} else if cost := pool.issue(step.issue); math.Abs(cost-step.wantCost) > 1e-9 {
+			t.Errorf("step %d: issue cost = %v, want %v", i, cost, step.wantCost)
+		}
+		if got := pool.quantity(); got != step.wantQty {
+			t.Errorf("step %d: quantity = %d, want %d", i, got, step.wantQty)
+		}
+		if got := pool.value(); math.Abs(got-step.wantValue) > 1e-9 {
+			t.Errorf("step %d: value = %v, want %v", i, got, step.wantValue)
+		}
+		if got := pool.unitCost(); math.Abs(got-step.wantUnit) > 1e-9 {
+			t.Errorf("step %d: unit cost = %v, want %v", i, got, step.wantUnit)
+		}
+	}
+}
+
+func TestNewCostPool(t *testing.T) {
+	if _, ok := newCostPool(model.ValuationMethodFIFO).(*fifoPool); !ok {
+		t.Error("fifo method did not give a fifo pool")
+	}
+	if _, ok := newCostPool(model.ValuationMethodAverage).(*averagePool); !ok {
+		t.Error("average method did not give an average pool")
+	}
+	if _, ok := newCostPool("").(*averagePool); !ok {
+		t.Error("empty method did not default to an average pool")
+	}
+}
+
+func TestAveragePool(t *testing.T) {
+	replayPool(t, newCostPool(model.ValuationMethodAverage), []poolStep{
+		{receive: 10, unitCost: 2, wantQty: 10, wantValue: 20, wantUnit: 2},
+		{receive: 10, unitCost: 4, wantQty: 20, wantValue: 60, wantUnit: 3},
+		{issue: 5, wantCost: 15, wantQty: 15, wantValue: 45, wantUnit: 3},
+		{issue: 15, wantCost: 45, wantQty: 0, wantValue: 0, wantUnit: 4},
+		// Issued beyond stock at the last receipt cost, settled by the next receipt
+		{issue: 2, wantCost: 8, wantQty: -2, wantValue: -8, wantUnit: 4},
+		{receive: 2, unitCost: 5, wantQty: 0, wantValue: 0, wantUnit: 5},
+		{receive: 4, unitCost: 5, wantQty: 4, wantValue: 20, wantUnit: 5},
+	})
+}
+
+func TestFIFOPool(t *testing.T) {
+	replayPool(t, newCostPool(model.ValuationMethodFIFO), []poolStep{
+		{receive: 10, unitCost: 2, wantQty: 10, wantValue: 20, wantUnit: 2},
+		{receive: 10, unitCost: 4, wantQty: 20, wantValue: 60, wantUnit: 2},
+		{issue: 15, wantCost: 40, wantQty: 5, wantValue: 20, wantUnit: 4},
+		// 5 from the last layer and 3 owed at the last receipt cost
+		{issue: 8, wantCost: 32, wantQty: -3, wantValue: -12, wantUnit: 4},
+		{issue: 1, wantCost: 4, wantQty: -4, wantValue: -16, wantUnit: 4},
+		// The receipt settles what is owed first and keeps the rest as a new layer
+		{receive: 6, unitCost: 6, wantQty: 2, wantValue: 12, wantUnit: 6},
+		{issue: 2, wantCost: 12, wantQty: 0, wantValue: 0, wantUnit: 6},
+	})
+}
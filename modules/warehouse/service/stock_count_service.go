@@ -0,0 +1,356 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StockCountService interface {
+	GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.StockCount, int64, error)
+	GetByID(id uuid.UUID) (*model.StockCount, error)
+	Create(count *model.StockCount) error
+	RecordCounts(id uuid.UUID, entries []StockCountEntry, userID uint) (*model.StockCount, error)
+	Submit(id uuid.UUID, userID uint) (*model.StockCount, error)
+	Approve(id uuid.UUID, counterparty string, userID uint) (*model.StockCount, error)
+	Cancel(id uuid.UUID) (*model.StockCount, error)
+}
+
+// StockCountEntry is a counted quantity. It updates the line with LineID, or
+// the line of the same product and batch; stock found outside the snapshot is
+// added as a new line with an expected quantity of zero.
+type StockCountEntry struct {
+	LineID      uuid.UUID
+	ProductID   uuid.UUID
+	BatchNumber string
+	ExpiredAt   time.Time
+	Quantity    int
+	Unit        string
+	Notes       string
+}
+
+type stockCountService struct {
+	db                *gorm.DB
+	repo              repository.StockCountRepository
+	balanceRepo       repository.StockBalanceRepository
+	stockEntryService StockEntryService
+	productRepo       repository.ProductRepository
+	warehouseRepo     repository.WarehouseRepository
+}
+
+func NewStockCountService(db *gorm.DB, repo repository.StockCountRepository, balanceRepo repository.StockBalanceRepository, stockEntryService StockEntryService, productRepo repository.ProductRepository, warehouseRepo repository.WarehouseRepository) StockCountService {
+	return &stockCountService{
+		db:                db,
+		repo:              repo,
+		balanceRepo:       balanceRepo,
+		stockEntryService: stockEntryService,
+		productRepo:       productRepo,
+		warehouseRepo:     warehouseRepo,
+	}
+}
+
+func (s *stockCountService) GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.StockCount, int64, error) {
+	return s.repo.GetAll(page, pageSize, warehouseID, status)
+}
+
+func (s *stockCountService) GetByID(id uuid.UUID) (*model.StockCount, error) {
+	return s.repo.GetByID(id)
+}
+
+// Create opens a count session and freezes the current balances of the warehouse as expected quantities
+func (s *stockCountService) Create(count *model.StockCount) error {
+	if count == nil {
+		return errors.New("stock count cannot be nil")
+	}
+	if count.WarehouseID == uuid.Nil {
+		return errors.New("warehouse ID is required")
+	}
+	warehouse, err := s.warehouseRepo.GetByID(count.WarehouseID)
+	if err != nil {
+		return errors.New("failed to validate warehouse: " + err.Error())
+	}
+	if warehouse == nil {
+		return errors.New("warehouse not found")
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		balances, err := s.balanceRepo.WithTx(tx).ListByWarehouse(count.WarehouseID)
+		if err != nil {
+			return err
+		}
+
+		count.Number = generateDocumentNumber("CNT")
+		count.Status = model.StockCountStatusCounting
+		count.SnapshotAt = time.Now()
+		count.Lines = make([]model.StockCountLine, 0, len(balances))
+		for _, balance := range balances {
+			count.Lines = append(count.Lines, model.StockCountLine{
+				ProductID:        balance.ProductID,
+				BatchNumber:      balance.BatchNumber,
+				ExpiredAt:        balance.ExpiredAt,
+				ExpectedQuantity: balance.Quantity,
+			})
+		}
+		return s.repo.WithTx(tx).Create(count)
+	})
+}
+
+// RecordCounts stores counted quantities. Counting the same line again overwrites the previous figure.
+func (s *stockCountService) RecordCounts(id uuid.UUID, entries []StockCountEntry, userID uint) (*model.StockCount, error) {
+	if len(entries) == 0 {
+		return nil, errors.New("at least one counted line is required")
+	}
+
+	var count *model.StockCount
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		// Counters recording at the same time take turns, so none overwrites the others' lines
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		count, err = s.loadWithStatus(repo, id, model.StockCountStatusCounting)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			if entry.Quantity < 0 {
+				return errors.New("counted quantity cannot be negative")
+			}
+
+			line, err := s.findOrAddLine(count, entry)
+			if err != nil {
+				return err
+			}
+			quantity, residual, err := s.stockEntryService.ToBaseQuantity(line.ProductID, entry.Quantity, entry.Unit)
+			if err != nil {
+				return err
+			}
+			line.CountedQuantity = &quantity
+			line.ConversionResidual = residual
+			line.VarianceQuantity = quantity - line.ExpectedQuantity
+			line.CountedBy = userID
+			if entry.Notes != "" {
+				line.Notes = entry.Notes
+			}
+		}
+		return repo.Update(count)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// Submit closes counting once every line has a counted quantity
+func (s *stockCountService) Submit(id uuid.UUID, userID uint) (*model.StockCount, error) {
+	var count *model.StockCount
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		count, err = s.loadWithStatus(repo, id, model.StockCountStatusCounting)
+		if err != nil {
+			return err
+		}
+
+		uncounted := 0
+		for _, line := range count.Lines {
+			if line.CountedQuantity == nil {
+				uncounted++
+			}
+		}
+		if uncounted > 0 {
+			return fmt.Errorf("invalid stock count: %d line(s) have not been counted", uncounted)
+		}
+
+		now := time.Now()
+		count.Status = model.StockCountStatusSubmitted
+		count.SubmittedAt = &now
+		count.SubmittedBy = userID
+		return repo.Update(count)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// Approve posts every variance as an adjustment referencing the count session.
+// Variances are taken against the balances at approval rather than the snapshot,
+// so every counted batch ends up on exactly its counted quantity whatever moved
+// since the count opened. The approver authorizes
+// the variances of controlled products.
+func (s *stockCountService) Approve(id uuid.UUID, counterparty string, userID uint) (*model.StockCount, error) {
+	if userID == 0 {
+		return nil, errors.New("approving user is required")
+	}
+
+	var count *model.StockCount
+	err := stockTransaction(s.db, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		// A second approval waits here and then finds the count approved
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		count, err = s.loadWithStatus(repo, id, model.StockCountStatusSubmitted)
+		if err != nil {
+			return err
+		}
+
+		onHand, err := s.lockCountedBalances(tx, count)
+		if err != nil {
+			return err
+		}
+		for i := range count.Lines {
+			line := &count.Lines[i]
+			line.VarianceQuantity = *line.CountedQuantity - onHand[countedBatch{line.ProductID, line.BatchNumber}]
+			if line.VarianceQuantity == 0 {
+				continue
+			}
+			entry := &model.StockEntry{
+				WarehouseID:        count.WarehouseID,
+				ProductID:          line.ProductID,
+				BatchNumber:        line.BatchNumber,
+				ExpiredAt:          line.ExpiredAt,
+				Quantity:           line.VarianceQuantity,
+				ConversionResidual: line.ConversionResidual,
+				Status:             model.StockEntryStatusAdjustment,
+				ReferenceID:        count.ID,
+				Notes:              "Stock count " + count.Number,
+				CreatedBy:          userID,
+				AuthorizedBy:       userID,
+				Counterparty:       counterparty,
+			}
+			if err := s.stockEntryService.Post(tx, entry); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		count.Status = model.StockCountStatusApproved
+		count.ApprovedAt = &now
+		count.ApprovedBy = userID
+		return repo.Update(count)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// Cancel abandons a count that has not been approved
+func (s *stockCountService) Cancel(id uuid.UUID) (*model.StockCount, error) {
+	var count *model.StockCount
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		count, err = s.loadWithStatus(repo, id, model.StockCountStatusCounting, model.StockCountStatusSubmitted)
+		if err != nil {
+			return err
+		}
+		count.Status = model.StockCountStatusCancelled
+		return repo.Update(count)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// countedBatch identifies the balance a count line stands for
+type countedBatch struct {
+	productID   uuid.UUID
+	batchNumber string
+}
+
+// lockCountedBalances locks the balances of every counted product in the
+// warehouse and returns their current quantities by batch
+func (s *stockCountService) lockCountedBalances(tx *gorm.DB, count *model.StockCount) (map[countedBatch]int, error) {
+	balanceRepo := s.balanceRepo.WithTx(tx)
+	onHand := map[countedBatch]int{}
+	locked := map[uuid.UUID]bool{}
+	for _, line := range count.Lines {
+		if locked[line.ProductID] {
+			continue
+		}
+		locked[line.ProductID] = true
+		balances, err := balanceRepo.LockProduct(count.WarehouseID, line.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		for _, balance := range balances {
+			onHand[countedBatch{balance.ProductID, balance.BatchNumber}] = balance.Quantity
+		}
+	}
+	return onHand, nil
+}
+
+// loadWithStatus fetches a count and checks it is in one of the allowed statuses
+func (s *stockCountService) loadWithStatus(repo repository.StockCountRepository, id uuid.UUID, allowed ...string) (*model.StockCount, error) {
+	count, err := repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if count == nil {
+		return nil, errors.New("stock count not found")
+	}
+	for _, status := range allowed {
+		if count.Status == status {
+			return count, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid stock count status: count is %s", count.Status)
+}
+
+// findOrAddLine resolves the line a counted quantity belongs to
+func (s *stockCountService) findOrAddLine(count *model.StockCount, entry StockCountEntry) (*model.StockCountLine, error) {
+	for i := range count.Lines {
+		line := &count.Lines[i]
+		if entry.LineID != uuid.Nil {
+			if line.ID == entry.LineID {
+				return line, nil
+			}
+			continue
+		}
+		if line.ProductID == entry.ProductID && line.BatchNumber == entry.BatchNumber {
+			return line, nil
+		}
+	}
+	if entry.LineID != uuid.Nil {
+		return nil, fmt.Errorf("stock count line %s not found", entry.LineID)
+	}
+
+	if entry.ProductID == uuid.Nil {
+		return nil, errors.New("product ID is required for lines outside the snapshot")
+	}
+	product, err := s.productRepo.GetByID(entry.ProductID)
+	if err != nil {
+		return nil, errors.New("failed to validate product: " + err.Error())
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product %s not found", entry.ProductID)
+	}
+
+	count.Lines = append(count.Lines, model.StockCountLine{
+		CountID:     count.ID,
+		ProductID:   entry.ProductID,
+		BatchNumber: entry.BatchNumber,
+		ExpiredAt:   entry.ExpiredAt,
+	})
+	return &count.Lines[len(count.Lines)-1], nil
+}
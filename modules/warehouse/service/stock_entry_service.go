@@ -0,0 +1,473 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StockEntryService interface {
+	GetAll(page, pageSize int, filter repository.StockEntryFilter) ([]model.StockEntry, int64, error)
+	GetByID(id uuid.UUID) (*model.StockEntry, error)
+	Receive(entry *model.StockEntry) error
+	Issue(entry *model.StockEntry) ([]model.StockEntry, error)
+	WriteOff(entry *model.StockEntry) error
+	// Post appends a movement to the ledger inside an existing transaction.
+	// Other stock documents use it so their movements share one code path.
+	Post(tx *gorm.DB, entry *model.StockEntry) error
+	// PostIssue posts an outgoing movement. Without a batch number the quantity is
+	// allocated across batches using the warehouse issue policy (FEFO or FIFO).
+	PostIssue(tx *gorm.DB, entry *model.StockEntry) ([]model.StockEntry, error)
+	// ToBaseQuantity converts a quantity given in a unit of the product, or in a
+	// unit of the same dimension as its base unit, to whole base units. The
+	// second result is what rounding a standard unit conversion left over.
+	ToBaseQuantity(productID uuid.UUID, quantity int, unit string) (int, float64, error)
+}
+
+type stockEntryService struct {
+	db              *gorm.DB
+	repo            repository.StockEntryRepository
+	balanceRepo     repository.StockBalanceRepository
+	productRepo     repository.ProductRepository
+	warehouseRepo   repository.WarehouseRepository
+	reservationRepo repository.StockReservationRepository
+	periodRepo      repository.StockPeriodRepository
+	recallRepo      repository.RecallRepository
+	unitRepo        repository.UnitProductRepository
+	userRoleRepo    repository.UserRoleRepository
+	authorizerRole  string // Role a user needs to authorize movements of controlled products
+}
+
+func NewStockEntryService(db *gorm.DB, repo repository.StockEntryRepository, balanceRepo repository.StockBalanceRepository, productRepo repository.ProductRepository, warehouseRepo repository.WarehouseRepository, reservationRepo repository.StockReservationRepository, periodRepo repository.StockPeriodRepository, recallRepo repository.RecallRepository, unitRepo repository.UnitProductRepository, userRoleRepo repository.UserRoleRepository, authorizerRole string) StockEntryService {
+	return &stockEntryService{
+		db:              db,
+		repo:            repo,
+		balanceRepo:     balanceRepo,
+		productRepo:     productRepo,
+		warehouseRepo:   warehouseRepo,
+		reservationRepo: reservationRepo,
+		periodRepo:      periodRepo,
+		recallRepo:      recallRepo,
+		unitRepo:        unitRepo,
+		userRoleRepo:    userRoleRepo,
+		authorizerRole:  authorizerRole,
+	}
+}
+
+func (s *stockEntryService) GetAll(page, pageSize int, filter repository.StockEntryFilter) ([]model.StockEntry, int64, error) {
+	return s.repo.GetAll(page, pageSize, filter)
+}
+
+func (s *stockEntryService) GetByID(id uuid.UUID) (*model.StockEntry, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *stockEntryService) Receive(entry *model.StockEntry) error {
+	if entry.Quantity <= 0 {
+		return errors.New("quantity must be greater than 0")
+	}
+	if err := s.normalizeUnit(entry); err != nil {
+		return err
+	}
+	entry.Status = model.StockEntryStatusReceipt
+	return s.record(entry)
+}
+
+func (s *stockEntryService) Issue(entry *model.StockEntry) ([]model.StockEntry, error) {
+	// Issues carry the requested quantity negated
+	if entry.Quantity >= 0 {
+		return nil, errors.New("invalid issue quantity: outgoing movements must be negative")
+	}
+	if err := s.normalizeUnit(entry); err != nil {
+		return nil, err
+	}
+	entry.Status = model.StockEntryStatusIssue
+	if err := s.validateReferences(entry); err != nil {
+		return nil, err
+	}
+
+	original := *entry
+	var entries []model.StockEntry
+	err := stockTransaction(s.db, func(tx *gorm.DB) error {
+		*entry = original
+
+		// Lock the product first so the availability check and the posting see the same stock
+		if _, err := s.balanceRepo.WithTx(tx).LockProduct(entry.WarehouseID, entry.ProductID); err != nil {
+			return err
+		}
+
+		warehouse, err := s.warehouseRepo.GetByID(entry.WarehouseID)
+		if err != nil {
+			return err
+		}
+		if warehouse == nil {
+			return errors.New("warehouse not found")
+		}
+
+		// Direct issues may not dip into stock reserved for other documents
+		// unless the warehouse runs with negative stock anyway
+		if !warehouse.AllowNegativeStock {
+			available, err := availableStock(s.reservationRepo.WithTx(tx), entry.WarehouseID, entry.ProductID)
+			if err != nil {
+				return err
+			}
+			if -entry.Quantity > available {
+				return fmt.Errorf("insufficient available stock: available %d, requested %d", available, -entry.Quantity)
+			}
+		}
+
+		entries, err = s.PostIssue(tx, entry)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// WriteOff removes an expired or unusable batch from stock. A zero quantity writes off the whole batch.
+func (s *stockEntryService) WriteOff(entry *model.StockEntry) error {
+	if entry.BatchNumber == "" {
+		return errors.New("batch number is required")
+	}
+	// Write-offs carry the requested quantity negated, 0 for the whole batch
+	if entry.Quantity > 0 {
+		return errors.New("invalid write-off quantity: outgoing movements must be negative")
+	}
+	if err := s.normalizeUnit(entry); err != nil {
+		return err
+	}
+	entry.Status = model.StockEntryStatusWriteOff
+	if err := s.validateReferences(entry); err != nil {
+		return err
+	}
+
+	original := *entry
+	return stockTransaction(s.db, func(tx *gorm.DB) error {
+		*entry = original
+		if entry.Quantity == 0 {
+			balances, err := s.balanceRepo.WithTx(tx).LockProduct(entry.WarehouseID, entry.ProductID)
+			if err != nil {
+				return err
+			}
+			balance := findBalance(balances, entry.BatchNumber)
+			if balance == nil || balance.Quantity <= 0 {
+				return fmt.Errorf("insufficient stock: batch %q has nothing to write off", entry.BatchNumber)
+			}
+			entry.Quantity = -balance.Quantity
+		}
+		return s.Post(tx, entry)
+	})
+}
+
+func (s *stockEntryService) ToBaseQuantity(productID uuid.UUID, quantity int, unit string) (int, float64, error) {
+	return toBaseQuantity(s.productRepo, s.unitRepo, productID, quantity, unit)
+}
+
+// normalizeUnit converts a quantity given in another unit of the product to small units
+func (s *stockEntryService) normalizeUnit(entry *model.StockEntry) error {
+	quantity, residual, err := s.ToBaseQuantity(entry.ProductID, entry.Quantity, entry.Unit)
+	if err != nil {
+		return err
+	}
+	entry.Quantity = quantity
+	entry.ConversionResidual = residual
+	entry.Unit = ""
+	return nil
+}
+
+// record validates the movement and posts it in its own transaction
+func (s *stockEntryService) record(entry *model.StockEntry) error {
+	if err := s.validateReferences(entry); err != nil {
+		return err
+	}
+	original := *entry
+	return stockTransaction(s.db, func(tx *gorm.DB) error {
+		*entry = original
+		return s.Post(tx, entry)
+	})
+}
+
+func (s *stockEntryService) Post(tx *gorm.DB, entry *model.StockEntry) error {
+	repo := s.repo.WithTx(tx)
+
+	// Controlled substances go into the regulator's register movement by movement,
+	// each naming who authorized it and where the goods came from or went to.
+	// The authorizer must be a user holding the authorizer role, not just any ID.
+	if entry.Quantity != 0 {
+		product, err := s.productRepo.GetByID(entry.ProductID)
+		if err != nil {
+			return err
+		}
+		if product != nil && product.Controlled {
+			if entry.AuthorizedBy == 0 {
+				return errors.New("authorizing user is required for controlled product movements")
+			}
+			if entry.Counterparty == "" {
+				return errors.New("counterparty is required for controlled product movements")
+			}
+			authorized, err := s.userRoleRepo.HasRole(entry.AuthorizedBy, s.authorizerRole)
+			if err != nil {
+				return err
+			}
+			if !authorized {
+				return fmt.Errorf("invalid authorizer: user %d does not hold the %s role", entry.AuthorizedBy, s.authorizerRole)
+			}
+		}
+	}
+
+	// Recalled stock that is still arriving, from transfers in transit or
+	// customers sending it back, comes in rejected like the stock on hand. The
+	// recall is locked before the product, in the order recall tasks take them.
+	var recall *model.Recall
+	if entry.Quantity > 0 && entry.BatchNumber != "" {
+		var err error
+		recall, err = s.openRecall(tx, entry.ProductID, entry.BatchNumber)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Concurrent movements of the product wait here until this transaction ends,
+	// so the latest entry read below cannot change underneath us
+	if _, err := s.balanceRepo.WithTx(tx).LockProduct(entry.WarehouseID, entry.ProductID); err != nil {
+		return err
+	}
+
+	latest, err := repo.GetLatest(entry.WarehouseID, entry.ProductID, entry.BatchNumber)
+	if err != nil {
+		return err
+	}
+
+	previousStock := 0
+	qcStatus := model.QCStatusReleased
+	if latest != nil {
+		previousStock = latest.Stock
+		qcStatus = latest.QCStatus
+
+		// Keep batch attributes on every row so each movement reads on its own
+		if entry.ExpiredAt.IsZero() {
+			entry.ExpiredAt = latest.ExpiredAt
+		}
+		if entry.Quantity < 0 {
+			entry.Price = latest.Price
+			entry.Margin = latest.Margin
+			entry.Tax = latest.Tax
+		}
+	}
+
+	// Expired batches stay frozen until they are written off, corrected or sent
+	// back to the supplier, the same movements that may take held stock out
+	if entry.Quantity < 0 && isExpired(entry.ExpiredAt, time.Now()) && !leavesQCHold(entry.Status) {
+		return fmt.Errorf("batch %q expired on %s and must be written off before it can be issued", entry.BatchNumber, entry.ExpiredAt.Format("2006-01-02"))
+	}
+
+	switch entry.QCStatus {
+	case "", model.QCStatusReleased, model.QCStatusQuarantine, model.QCStatusRejected:
+	default:
+		return fmt.Errorf("invalid qc status %q: must be released, quarantine or rejected", entry.QCStatus)
+	}
+
+	// Receipts into warehouses that hold them for QC always come in quarantined
+	if entry.Status == model.StockEntryStatusReceipt && entry.QCStatus != model.QCStatusQuarantine {
+		warehouse, err := s.warehouseRepo.GetByID(entry.WarehouseID)
+		if err != nil {
+			return err
+		}
+		if warehouse != nil && warehouse.QuarantineReceipts {
+			entry.QCStatus = model.QCStatusQuarantine
+		}
+	}
+
+	if recall != nil {
+		entry.QCStatus = model.QCStatusRejected
+	}
+
+	// A batch position carries one QC status, so stock of another status can only
+	// come in once the position is empty. QC decisions change it in place.
+	if entry.QCStatus == "" {
+		entry.QCStatus = qcStatus
+	} else if entry.QCStatus != qcStatus && previousStock > 0 && entry.Status != model.StockEntryStatusQCChange {
+		return fmt.Errorf("invalid qc status: batch %q already holds %s stock in this warehouse", entry.BatchNumber, qcStatus)
+	}
+	if entry.Quantity < 0 && entry.QCStatus != model.QCStatusReleased && !leavesQCHold(entry.Status) {
+		return fmt.Errorf("batch %q is %s by QC and cannot be issued", entry.BatchNumber, qcHoldLabel(entry.QCStatus))
+	}
+
+	if previousStock+entry.Quantity < 0 {
+		warehouse, err := s.warehouseRepo.GetByID(entry.WarehouseID)
+		if err != nil {
+			return err
+		}
+		if warehouse == nil || !warehouse.AllowNegativeStock {
+			return fmt.Errorf("insufficient stock for batch %q: available %d, requested %d", entry.BatchNumber, previousStock, -entry.Quantity)
+		}
+	}
+
+	if entry.Date.IsZero() {
+		entry.Date = time.Now()
+	}
+
+	// Closed periods are frozen so their snapshots keep matching the ledger.
+	// The lock makes a period being closed wait for this movement, or this
+	// movement wait for the close and then see the period as closed.
+	periodRepo := s.periodRepo.WithTx(tx)
+	if err := periodRepo.LockForPosting(); err != nil {
+		return err
+	}
+	closed, err := periodRepo.GetLatestClosed()
+	if err != nil {
+		return err
+	}
+	if closed != nil && !entry.Date.After(closed.EndAt) {
+		return fmt.Errorf("invalid date: stock period %s is closed", closed.Period)
+	}
+
+	entry.PreviousStock = previousStock
+	entry.Stock = previousStock + entry.Quantity
+
+	if err := repo.Create(entry); err != nil {
+		return err
+	}
+	if err := s.balanceRepo.WithTx(tx).Apply(entry); err != nil {
+		return err
+	}
+	if recall != nil {
+		return s.addRecallTask(tx, recall, entry)
+	}
+	return nil
+}
+
+// openRecall returns the open recall of a batch with its row locked, so the
+// recall cannot close while stock of the batch is arriving
+func (s *stockEntryService) openRecall(tx *gorm.DB, productID uuid.UUID, batchNumber string) (*model.Recall, error) {
+	repo := s.recallRepo.WithTx(tx)
+	recall, err := repo.GetOpen(productID, batchNumber)
+	if err != nil || recall == nil {
+		return nil, err
+	}
+	if err := repo.Lock(recall.ID); err != nil {
+		return nil, err
+	}
+	// The recall may have been closed while we waited for the lock
+	return repo.GetOpen(productID, batchNumber)
+}
+
+// addRecallTask gives a warehouse receiving recalled stock a task of its own
+// unless it still has an open one
+func (s *stockEntryService) addRecallTask(tx *gorm.DB, recall *model.Recall, entry *model.StockEntry) error {
+	for _, task := range recall.Tasks {
+		if task.WarehouseID == entry.WarehouseID && task.Status == model.RecallTaskStatusOpen {
+			return nil
+		}
+	}
+	return s.recallRepo.WithTx(tx).CreateTask(&model.RecallTask{
+		RecallID:    recall.ID,
+		WarehouseID: entry.WarehouseID,
+		Action:      recall.Action,
+		Status:      model.RecallTaskStatusOpen,
+		Quantity:    entry.Stock,
+	})
+}
+
+func (s *stockEntryService) PostIssue(tx *gorm.DB, entry *model.StockEntry) ([]model.StockEntry, error) {
+	if entry.BatchNumber != "" {
+		if err := s.Post(tx, entry); err != nil {
+			return nil, err
+		}
+		return []model.StockEntry{*entry}, nil
+	}
+
+	warehouse, err := s.warehouseRepo.GetByID(entry.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if warehouse == nil {
+		return nil, errors.New("warehouse not found")
+	}
+
+	balances, err := s.balanceRepo.WithTx(tx).LockProduct(entry.WarehouseID, entry.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := allocateBatches(balances, -entry.Quantity, warehouse.IssuePolicy, warehouse.AllowNegativeStock, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]model.StockEntry, 0, len(allocations))
+	for i, allocation := range allocations {
+		batchEntry := *entry
+		batchEntry.BatchNumber = allocation.BatchNumber
+		batchEntry.Quantity = -allocation.Quantity
+		if i > 0 {
+			// The residual of the issued quantity is recorded once
+			batchEntry.ConversionResidual = 0
+		}
+		if err := s.Post(tx, &batchEntry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, batchEntry)
+	}
+	return entries, nil
+}
+
+// findBalance returns the balance row of a batch, or nil if the batch has none
+func findBalance(balances []model.StockBalance, batchNumber string) *model.StockBalance {
+	for i := range balances {
+		if balances[i].BatchNumber == batchNumber {
+			return &balances[i]
+		}
+	}
+	return nil
+}
+
+// leavesQCHold reports whether a movement may take quarantined or rejected
+// stock out: disposal, correction or a return to the supplier
+func leavesQCHold(status string) bool {
+	switch status {
+	case model.StockEntryStatusWriteOff, model.StockEntryStatusAdjustment, model.StockEntryStatusReturnOut:
+		return true
+	}
+	return false
+}
+
+// qcHoldLabel words a QC status for error messages
+func qcHoldLabel(status string) string {
+	if status == model.QCStatusQuarantine {
+		return "held in quarantine"
+	}
+	return status
+}
+
+// validateReferences checks that the warehouse and product of a movement exist
+func (s *stockEntryService) validateReferences(entry *model.StockEntry) error {
+	if entry.WarehouseID == uuid.Nil {
+		return errors.New("warehouse ID is required")
+	}
+	if entry.ProductID == uuid.Nil {
+		return errors.New("product ID is required")
+	}
+
+	warehouse, err := s.warehouseRepo.GetByID(entry.WarehouseID)
+	if err != nil {
+		return errors.New("failed to validate warehouse: " + err.Error())
+	}
+	if warehouse == nil {
+		return errors.New("warehouse not found")
+	}
+
+	product, err := s.productRepo.GetByID(entry.ProductID)
+	if err != nil {
+		return errors.New("failed to validate product: " + err.Error())
+	}
+	if product == nil {
+		return errors.New("product not found")
+	}
+	return nil
+}
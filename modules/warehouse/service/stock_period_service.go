@@ -0,0 +1,224 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StockPeriodService interface {
+	GetAll(page, pageSize int, status string) ([]model.StockPeriod, int64, error)
+	GetByID(id uuid.UUID) (*model.StockPeriod, error)
+	// Close snapshots the balances and valuation of a month (YYYY-MM) and
+	// freezes the ledger up to its end. Periods close in calendar order.
+	Close(period, notes string, userID uint) (*model.StockPeriod, error)
+	// Reopen drops the snapshots of the latest closed period so it can be corrected
+	Reopen(id uuid.UUID, userID uint) (*model.StockPeriod, error)
+}
+
+type stockPeriodService struct {
+	db          *gorm.DB
+	repo        repository.StockPeriodRepository
+	balanceRepo repository.StockBalanceRepository
+	valuation   StockValuationService
+}
+
+func NewStockPeriodService(db *gorm.DB, repo repository.StockPeriodRepository, balanceRepo repository.StockBalanceRepository, valuation StockValuationService) StockPeriodService {
+	return &stockPeriodService{
+		db:          db,
+		repo:        repo,
+		balanceRepo: balanceRepo,
+		valuation:   valuation,
+	}
+}
+
+func (s *stockPeriodService) GetAll(page, pageSize int, status string) ([]model.StockPeriod, int64, error) {
+	return s.repo.GetAll(page, pageSize, status)
+}
+
+func (s *stockPeriodService) GetByID(id uuid.UUID) (*model.StockPeriod, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *stockPeriodService) Close(period, notes string, userID uint) (*model.StockPeriod, error) {
+	start, end, err := periodBounds(period)
+	if err != nil {
+		return nil, err
+	}
+	if !end.Before(time.Now()) {
+		return nil, fmt.Errorf("invalid period: %s has not ended yet", period)
+	}
+
+	var closed *model.StockPeriod
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		// Wait for movements being posted and keep new ones out, so the
+		// snapshots below and the frozen ledger describe the same stock
+		if err := repo.LockForClosing(); err != nil {
+			return err
+		}
+
+		latest, err := repo.GetLatestClosed()
+		if err != nil {
+			return err
+		}
+		if latest != nil && latest.Period == period {
+			return fmt.Errorf("invalid period status: %s is already closed", period)
+		}
+		if latest != nil && latest.EndAt.After(end) {
+			return fmt.Errorf("invalid period: later period %s is already closed", latest.Period)
+		}
+
+		existing, err := repo.GetByPeriod(period)
+		if err != nil {
+			return err
+		}
+
+		// Reports are computed before the period is marked closed so they come from the ledger
+		reports, err := s.valuationReports(s.valuation.WithTx(tx), start, end)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		closed = existing
+		if closed == nil {
+			closed = &model.StockPeriod{Period: period, StartAt: start, EndAt: end}
+		}
+		closed.Status = model.StockPeriodStatusClosed
+		closed.Notes = notes
+		closed.ClosedAt = &now
+		closed.ClosedBy = userID
+		if existing == nil {
+			err = repo.Create(closed)
+		} else {
+			err = repo.Update(closed)
+		}
+		if err != nil {
+			return err
+		}
+
+		balances, err := s.balanceRepo.WithTx(tx).ListAsOf(end, latest)
+		if err != nil {
+			return err
+		}
+		snapshots := make([]model.StockBalanceSnapshot, 0, len(balances))
+		for _, balance := range balances {
+			snapshots = append(snapshots, model.StockBalanceSnapshot{
+				PeriodID:    closed.ID,
+				WarehouseID: balance.WarehouseID,
+				ProductID:   balance.ProductID,
+				BatchNumber: balance.BatchNumber,
+				ExpiredAt:   balance.ExpiredAt,
+				Quantity:    balance.Quantity,
+			})
+		}
+		if err := repo.CreateBalanceSnapshots(snapshots); err != nil {
+			return err
+		}
+
+		for officeID, report := range reports {
+			data, err := json.Marshal(report)
+			if err != nil {
+				return err
+			}
+			snapshot := &model.StockValuationSnapshot{PeriodID: closed.ID, OfficeID: officeID, Report: string(data)}
+			if err := repo.CreateValuationSnapshot(snapshot); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return closed, nil
+}
+
+// valuationReports values the period for all offices together and for each
+// office holding stock, keyed by office ID with uuid.Nil for the overall report
+func (s *stockPeriodService) valuationReports(valuation StockValuationService, start, end time.Time) (map[uuid.UUID]*ValuationReport, error) {
+	all, err := valuation.GetValuationReport(end, start, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := map[uuid.UUID]*ValuationReport{uuid.Nil: all}
+	for _, warehouse := range all.Warehouses {
+		if warehouse.OfficeID == nil {
+			continue
+		}
+		if _, ok := reports[*warehouse.OfficeID]; ok {
+			continue
+		}
+		report, err := valuation.GetValuationReport(end, start, *warehouse.OfficeID)
+		if err != nil {
+			return nil, err
+		}
+		reports[*warehouse.OfficeID] = report
+	}
+	return reports, nil
+}
+
+func (s *stockPeriodService) Reopen(id uuid.UUID, userID uint) (*model.StockPeriod, error) {
+	var period *model.StockPeriod
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		var err error
+		period, err = repo.GetByID(id)
+		if err != nil {
+			return err
+		}
+		if period == nil {
+			return errors.New("stock period not found")
+		}
+		if period.Status != model.StockPeriodStatusClosed {
+			return fmt.Errorf("invalid period status: %s is %s", period.Period, period.Status)
+		}
+
+		latest, err := repo.GetLatestClosed()
+		if err != nil {
+			return err
+		}
+		if latest != nil && latest.ID != period.ID {
+			return fmt.Errorf("invalid period: only the latest closed period %s can be reopened", latest.Period)
+		}
+
+		if err := repo.DeleteSnapshots(period.ID); err != nil {
+			return err
+		}
+		now := time.Now()
+		period.Status = model.StockPeriodStatusOpen
+		period.ReopenedAt = &now
+		period.ReopenedBy = userID
+		return repo.Update(period)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return period, nil
+}
+
+// periodBounds parses a YYYY-MM period into its first and last instant
+func periodBounds(period string) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation("2006-01", period, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("invalid period: must be YYYY-MM")
+	}
+	// The database keeps microseconds, so the end is stored exactly
+	return start, start.AddDate(0, 1, 0).Add(-time.Microsecond), nil
+}
+
+// coversPeriodEnd reports whether asOf falls on the last instant of the period.
+// Request dates carry nanoseconds the stored end does not.
+func coversPeriodEnd(period *model.StockPeriod, asOf time.Time) bool {
+	return !asOf.Before(period.EndAt) && asOf.Before(period.StartAt.In(time.Local).AddDate(0, 1, 0))
+}
@@ -0,0 +1,707 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+)
+
+type StockReportService interface {
+	GetExpiryReport(days int, officeID uuid.UUID) (*ExpiryReport, error)
+	GetShrinkageReport(from, to time.Time, warehouseID, officeID uuid.UUID) (*ShrinkageReport, error)
+	// GetStockCard returns one page of a product's movements in a warehouse.
+	// A pageSize of 0 returns every movement of the period.
+	GetStockCard(filter repository.StockCardFilter, page, pageSize int) (*StockCard, error)
+	// GetABCReport ranks products by the value issued from..to. Products make up class A
+	// until their cumulative share reaches classA percent, class B until classB percent.
+	GetABCReport(from, to time.Time, warehouseID, officeID uuid.UUID, classA, classB float64) (*ABCReport, error)
+	// GetDeadStockReport flags stock with no outgoing movement in the last days as dead and,
+	// when slowCoverDays is positive, stock lasting longer than that at the recent rate as slow-moving
+	GetDeadStockReport(days, slowCoverDays int, warehouseID, officeID uuid.UUID) (*DeadStockReport, error)
+	// GetControlledRegister builds the register of controlled products for a YYYY-MM period,
+	// optionally limited to one office and one controlled class
+	GetControlledRegister(period string, officeID uuid.UUID, class string) (*ControlledRegister, error)
+}
+
+// ExpiryReport lists batches expiring within Days, grouped by office, branch and warehouse
+type ExpiryReport struct {
+	Days          int                 `json:"days"`
+	GeneratedAt   time.Time           `json:"generated_at"`
+	TotalQuantity int                 `json:"total_quantity"`
+	TotalValue    float64             `json:"total_value"`
+	Offices       []ExpiryOfficeGroup `json:"offices"`
+}
+
+type ExpiryOfficeGroup struct {
+	OfficeID      *uuid.UUID          `json:"office_id"`
+	OfficeName    string              `json:"office_name"`
+	TotalQuantity int                 `json:"total_quantity"`
+	TotalValue    float64             `json:"total_value"`
+	Branches      []ExpiryBranchGroup `json:"branches"`
+}
+
+type ExpiryBranchGroup struct {
+	BranchID      *uuid.UUID             `json:"branch_id"`
+	BranchName    string                 `json:"branch_name"`
+	TotalQuantity int                    `json:"total_quantity"`
+	TotalValue    float64                `json:"total_value"`
+	Warehouses    []ExpiryWarehouseGroup `json:"warehouses"`
+}
+
+type ExpiryWarehouseGroup struct {
+	WarehouseID   uuid.UUID       `json:"warehouse_id"`
+	WarehouseName string          `json:"warehouse_name"`
+	TotalQuantity int             `json:"total_quantity"`
+	TotalValue    float64         `json:"total_value"`
+	Batches       []ExpiringBatch `json:"batches"`
+}
+
+type ExpiringBatch struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	ProductCode  string    `json:"product_code"`
+	ProductName  string    `json:"product_name"`
+	BatchNumber  string    `json:"batch_number"`
+	ExpiredAt    time.Time `json:"expired_at"`
+	DaysToExpiry int       `json:"days_to_expiry"` // Negative once the batch has expired
+	Expired      bool      `json:"expired"`
+	Quantity     int       `json:"quantity"`
+	Price        float64   `json:"price"`
+	Value        float64   `json:"value"`
+}
+
+// ShrinkageReport totals stock removed by reasoned adjustments, grouped by reason and warehouse
+type ShrinkageReport struct {
+	From          *time.Time        `json:"from,omitempty"`
+	To            time.Time         `json:"to"`
+	TotalQuantity int               `json:"total_quantity"`
+	TotalValue    float64           `json:"total_value"`
+	Reasons       []ShrinkageReason `json:"reasons"`
+}
+
+type ShrinkageReason struct {
+	ReasonCode string               `json:"reason_code"`
+	ReasonName string               `json:"reason_name"`
+	Quantity   int                  `json:"quantity"`
+	Value      float64              `json:"value"`
+	Warehouses []ShrinkageWarehouse `json:"warehouses"`
+}
+
+type ShrinkageWarehouse struct {
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	WarehouseName string    `json:"warehouse_name"`
+	Movements     int       `json:"movements"`
+	Quantity      int       `json:"quantity"`
+	Value         float64   `json:"value"`
+}
+
+// StockCard is the movement history (kartu stok) of a product in a warehouse,
+// read from the ledger. Opening, totals and closing cover the whole period;
+// Movements holds the requested page.
+type StockCard struct {
+	ProductID      uuid.UUID           `json:"product_id"`
+	ProductCode    string              `json:"product_code"`
+	ProductName    string              `json:"product_name"`
+	Unit           string              `json:"unit"`
+	WarehouseID    uuid.UUID           `json:"warehouse_id"`
+	WarehouseName  string              `json:"warehouse_name"`
+	BatchNumber    string              `json:"batch_number,omitempty"`
+	From           *time.Time          `json:"from,omitempty"`
+	To             time.Time           `json:"to"`
+	OpeningBalance int                 `json:"opening_balance"`
+	TotalIn        int                 `json:"total_in"`
+	TotalOut       int                 `json:"total_out"`
+	ClosingBalance int                 `json:"closing_balance"`
+	Movements      []StockCardMovement `json:"movements"`
+	TotalCount     int64               `json:"total_count"`
+	Page           int                 `json:"page"`
+	PageSize       int                 `json:"page_size"`
+}
+
+type StockCardMovement struct {
+	EntryID     uuid.UUID `json:"entry_id"`
+	Date        time.Time `json:"date"`
+	Status      string    `json:"status"`
+	ReferenceID uuid.UUID `json:"reference_id"`
+	OrderID     uuid.UUID `json:"order_id"`
+	ReasonCode  string    `json:"reason_code,omitempty"`
+	BatchNumber string    `json:"batch_number"`
+	ExpiredAt   time.Time `json:"expired_at"`
+	Opening     int       `json:"opening"` // Balance before the movement
+	In          int       `json:"in"`
+	Out         int       `json:"out"`
+	Balance     int       `json:"balance"` // Balance after the movement
+	CreatedBy   uint      `json:"created_by"`
+	Username    string    `json:"username"`
+	Notes       string    `json:"notes"`
+}
+
+// ABC classes
+const (
+	ABCClassA = "A"
+	ABCClassB = "B"
+	ABCClassC = "C"
+)
+
+// ABCReport classifies products by consumption value. Products without any
+// issue in the period are not listed; the dead stock report covers them.
+type ABCReport struct {
+	From       time.Time         `json:"from"`
+	To         time.Time         `json:"to"`
+	ClassA     float64           `json:"class_a"` // Cumulative share (%) closing class A
+	ClassB     float64           `json:"class_b"` // Cumulative share (%) closing class B
+	TotalValue float64           `json:"total_value"`
+	Classes    []ABCClassSummary `json:"classes"`
+	Products   []ABCProduct      `json:"products"`
+}
+
+type ABCClassSummary struct {
+	Class    string  `json:"class"`
+	Products int     `json:"products"`
+	Quantity int     `json:"quantity"`
+	Value    float64 `json:"value"`
+	Share    float64 `json:"share"` // Percent of the total consumption value
+}
+
+type ABCProduct struct {
+	Rank            int       `json:"rank"`
+	ProductID       uuid.UUID `json:"product_id"`
+	ProductCode     string    `json:"product_code"`
+	ProductName     string    `json:"product_name"`
+	CategoryID      uuid.UUID `json:"category_id"`
+	CategoryName    string    `json:"category_name"`
+	Quantity        int       `json:"quantity"`
+	Value           float64   `json:"value"`
+	Share           float64   `json:"share"`
+	CumulativeShare float64   `json:"cumulative_share"`
+	Class           string    `json:"class"`
+}
+
+// Movement classes of the dead stock report
+const (
+	MovementClassDead = "dead"
+	MovementClassSlow = "slow"
+)
+
+// DeadStockReport lists stock that has not moved, or moves too slowly, grouped by product category
+type DeadStockReport struct {
+	Days          int                 `json:"days"`
+	SlowCoverDays int                 `json:"slow_cover_days"`
+	GeneratedAt   time.Time           `json:"generated_at"`
+	DeadQuantity  int                 `json:"dead_quantity"`
+	DeadValue     float64             `json:"dead_value"`
+	SlowQuantity  int                 `json:"slow_quantity"`
+	SlowValue     float64             `json:"slow_value"`
+	Categories    []DeadStockCategory `json:"categories"`
+}
+
+type DeadStockCategory struct {
+	CategoryID   uuid.UUID       `json:"category_id"`
+	CategoryName string          `json:"category_name"`
+	DeadValue    float64         `json:"dead_value"`
+	SlowValue    float64         `json:"slow_value"`
+	Items        []DeadStockItem `json:"items"`
+}
+
+type DeadStockItem struct {
+	ProductID        uuid.UUID  `json:"product_id"`
+	ProductCode      string     `json:"product_code"`
+	ProductName      string     `json:"product_name"`
+	WarehouseID      uuid.UUID  `json:"warehouse_id"`
+	WarehouseName    string     `json:"warehouse_name"`
+	Quantity         int        `json:"quantity"`
+	Value            float64    `json:"value"`
+	LastOutgoingAt   *time.Time `json:"last_outgoing_at"` // Nil when the stock never left the warehouse
+	IdleDays         int        `json:"idle_days"`        // Days since the last outgoing movement, or since receipt
+	OutgoingQuantity int        `json:"outgoing_quantity"`
+	CoverDays        int        `json:"cover_days,omitempty"` // Days the stock lasts at the recent outgoing rate
+	Class            string     `json:"class"`
+}
+
+// ControlledRegister is the monthly register of controlled substances per
+// office: opening, in, out and closing balance of every controlled product
+// with the movements behind them
+type ControlledRegister struct {
+	Period      string                     `json:"period"`
+	From        time.Time                  `json:"from"`
+	To          time.Time                  `json:"to"`
+	Class       string                     `json:"class,omitempty"`
+	GeneratedAt time.Time                  `json:"generated_at"`
+	Offices     []ControlledRegisterOffice `json:"offices"`
+}
+
+type ControlledRegisterOffice struct {
+	OfficeID   *uuid.UUID                  `json:"office_id"`
+	OfficeName string                      `json:"office_name"`
+	Products   []ControlledRegisterProduct `json:"products"`
+}
+
+type ControlledRegisterProduct struct {
+	ProductID        uuid.UUID            `json:"product_id"`
+	ProductCode      string               `json:"product_code"`
+	ProductName      string               `json:"product_name"`
+	ControlledClass  string               `json:"controlled_class"`
+	Unit             string               `json:"unit"`
+	Opening          int                  `json:"opening"`
+	In               int                  `json:"in"`
+	Out              int                  `json:"out"`
+	InternalTransfer int                  `json:"internal_transfer"` // Net of transfers within the office, non-zero while goods are in transit
+	Closing          int                  `json:"closing"`
+	Movements        []ControlledMovement `json:"movements"`
+}
+
+// ControlledMovement is one register line. Transfers between warehouses of the
+// office go to InternalTransfer instead of In or Out.
+type ControlledMovement struct {
+	EntryID          uuid.UUID `json:"entry_id"`
+	Date             time.Time `json:"date"`
+	WarehouseName    string    `json:"warehouse_name"`
+	Status           string    `json:"status"`
+	BatchNumber      string    `json:"batch_number"`
+	ReferenceID      uuid.UUID `json:"reference_id"`
+	Counterparty     string    `json:"counterparty"`
+	In               int       `json:"in"`
+	Out              int       `json:"out"`
+	InternalTransfer int       `json:"internal_transfer"`
+	Balance          int       `json:"balance"` // Office balance after the movement
+	AuthorizedBy     uint      `json:"authorized_by"`
+	AuthorizedByName string    `json:"authorized_by_name"`
+	CreatedBy        uint      `json:"created_by"`
+	Username         string    `json:"username"`
+	Notes            string    `json:"notes"`
+}
+
+type stockReportService struct {
+	repo          repository.StockReportRepository
+	productRepo   repository.ProductRepository
+	warehouseRepo repository.WarehouseRepository
+}
+
+func NewStockReportService(repo repository.StockReportRepository, productRepo repository.ProductRepository, warehouseRepo repository.WarehouseRepository) StockReportService {
+	return &stockReportService{repo: repo, productRepo: productRepo, warehouseRepo: warehouseRepo}
+}
+
+func (s *stockReportService) GetExpiryReport(days int, officeID uuid.UUID) (*ExpiryReport, error) {
+	if days < 0 {
+		return nil, errors.New("days cannot be negative")
+	}
+
+	now := time.Now()
+	rows, err := s.repo.GetExpiringBatches(now.AddDate(0, 0, days), officeID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ExpiryReport{Days: days, GeneratedAt: now, Offices: []ExpiryOfficeGroup{}}
+	for _, row := range rows {
+		batch := ExpiringBatch{
+			ProductID:    row.ProductID,
+			ProductCode:  row.ProductCode,
+			ProductName:  row.ProductName,
+			BatchNumber:  row.BatchNumber,
+			ExpiredAt:    row.ExpiredAt,
+			DaysToExpiry: int(row.ExpiredAt.Sub(now).Hours() / 24),
+			Expired:      isExpired(row.ExpiredAt, now),
+			Quantity:     row.Quantity,
+			Price:        row.Price,
+			Value:        float64(row.Quantity) * row.Price,
+		}
+
+		// Rows arrive sorted by office, branch and warehouse so groups only ever extend the last one
+		if n := len(report.Offices); n == 0 || !sameUUID(report.Offices[n-1].OfficeID, row.OfficeID) {
+			report.Offices = append(report.Offices, ExpiryOfficeGroup{OfficeID: row.OfficeID, OfficeName: row.OfficeName})
+		}
+		office := &report.Offices[len(report.Offices)-1]
+
+		if n := len(office.Branches); n == 0 || !sameUUID(office.Branches[n-1].BranchID, row.BranchID) {
+			office.Branches = append(office.Branches, ExpiryBranchGroup{BranchID: row.BranchID, BranchName: row.BranchName})
+		}
+		branch := &office.Branches[len(office.Branches)-1]
+
+		if n := len(branch.Warehouses); n == 0 || branch.Warehouses[n-1].WarehouseID != row.WarehouseID {
+			branch.Warehouses = append(branch.Warehouses, ExpiryWarehouseGroup{WarehouseID: row.WarehouseID, WarehouseName: row.WarehouseName})
+		}
+		warehouse := &branch.Warehouses[len(branch.Warehouses)-1]
+
+		warehouse.Batches = append(warehouse.Batches, batch)
+		warehouse.TotalQuantity += batch.Quantity
+		warehouse.TotalValue += batch.Value
+		branch.TotalQuantity += batch.Quantity
+		branch.TotalValue += batch.Value
+		office.TotalQuantity += batch.Quantity
+		office.TotalValue += batch.Value
+		report.TotalQuantity += batch.Quantity
+		report.TotalValue += batch.Value
+	}
+
+	return report, nil
+}
+
+func (s *stockReportService) GetShrinkageReport(from, to time.Time, warehouseID, officeID uuid.UUID) (*ShrinkageReport, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if !from.IsZero() && from.After(to) {
+		return nil, errors.New("invalid period: from is after to")
+	}
+
+	rows, err := s.repo.GetShrinkage(from, to, warehouseID, officeID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ShrinkageReport{To: to, Reasons: []ShrinkageReason{}}
+	if !from.IsZero() {
+		report.From = &from
+	}
+	for _, row := range rows {
+		// Rows arrive sorted by reason code so a new code always starts a new group
+		if n := len(report.Reasons); n == 0 || report.Reasons[n-1].ReasonCode != row.ReasonCode {
+			report.Reasons = append(report.Reasons, ShrinkageReason{ReasonCode: row.ReasonCode, ReasonName: row.ReasonName})
+		}
+		reason := &report.Reasons[len(report.Reasons)-1]
+
+		reason.Warehouses = append(reason.Warehouses, ShrinkageWarehouse{
+			WarehouseID:   row.WarehouseID,
+			WarehouseName: row.WarehouseName,
+			Movements:     row.Movements,
+			Quantity:      row.Quantity,
+			Value:         row.Value,
+		})
+		reason.Quantity += row.Quantity
+		reason.Value += row.Value
+		report.TotalQuantity += row.Quantity
+		report.TotalValue += row.Value
+	}
+
+	return report, nil
+}
+
+func (s *stockReportService) GetStockCard(filter repository.StockCardFilter, page, pageSize int) (*StockCard, error) {
+	if filter.ProductID == uuid.Nil {
+		return nil, errors.New("product id is required")
+	}
+	if filter.WarehouseID == uuid.Nil {
+		return nil, errors.New("warehouse id is required")
+	}
+	if filter.To.IsZero() {
+		filter.To = time.Now()
+	}
+	if !filter.From.IsZero() && filter.From.After(filter.To) {
+		return nil, errors.New("invalid period: from is after to")
+	}
+
+	product, err := s.productRepo.GetByID(filter.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, errors.New("product not found")
+	}
+	warehouse, err := s.warehouseRepo.GetByID(filter.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if warehouse == nil {
+		return nil, errors.New("warehouse not found")
+	}
+
+	summary, err := s.repo.GetStockCardSummary(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []repository.StockCardRow
+	var total int64
+	if pageSize == 0 {
+		rows, err = s.repo.ListStockCard(filter)
+		total = int64(len(rows))
+	} else {
+		rows, total, err = s.repo.GetStockCard(filter, page, pageSize)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	card := &StockCard{
+		ProductID:      product.ID,
+		ProductCode:    product.Code,
+		ProductName:    product.Name,
+		Unit:           product.SmallUnit,
+		WarehouseID:    warehouse.ID,
+		WarehouseName:  warehouse.Name,
+		BatchNumber:    filter.BatchNumber,
+		To:             filter.To,
+		OpeningBalance: summary.Opening,
+		TotalIn:        summary.TotalIn,
+		TotalOut:       summary.TotalOut,
+		ClosingBalance: summary.Opening + summary.TotalIn - summary.TotalOut,
+		Movements:      make([]StockCardMovement, 0, len(rows)),
+		TotalCount:     total,
+		Page:           page,
+		PageSize:       pageSize,
+	}
+	if !filter.From.IsZero() {
+		card.From = &filter.From
+	}
+
+	for _, row := range rows {
+		movement := StockCardMovement{
+			EntryID:     row.EntryID,
+			Date:        row.Date,
+			Status:      row.Status,
+			ReferenceID: row.ReferenceID,
+			OrderID:     row.OrderID,
+			ReasonCode:  row.ReasonCode,
+			BatchNumber: row.BatchNumber,
+			ExpiredAt:   row.ExpiredAt,
+			Balance:     summary.Opening + row.Cumulative,
+			CreatedBy:   row.CreatedBy,
+			Username:    row.Username,
+			Notes:       row.Notes,
+		}
+		movement.Opening = movement.Balance - row.Quantity
+		if row.Quantity > 0 {
+			movement.In = row.Quantity
+		} else {
+			movement.Out = -row.Quantity
+		}
+		card.Movements = append(card.Movements, movement)
+	}
+
+	return card, nil
+}
+
+func (s *stockReportService) GetABCReport(from, to time.Time, warehouseID, officeID uuid.UUID, classA, classB float64) (*ABCReport, error) {
+	if classA <= 0 || classA >= classB || classB > 100 {
+		return nil, fmt.Errorf("invalid class thresholds: need 0 < A (%g) < B (%g) <= 100", classA, classB)
+	}
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.AddDate(-1, 0, 0)
+	}
+	if from.After(to) {
+		return nil, errors.New("invalid period: from is after to")
+	}
+
+	rows, err := s.repo.GetConsumption(from, to, warehouseID, officeID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ABCReport{From: from, To: to, ClassA: classA, ClassB: classB, Products: make([]ABCProduct, 0, len(rows))}
+	for _, row := range rows {
+		report.TotalValue += row.Value
+	}
+
+	classes := map[string]*ABCClassSummary{}
+	for _, class := range []string{ABCClassA, ABCClassB, ABCClassC} {
+		classes[class] = &ABCClassSummary{Class: class}
+	}
+
+	cumulative := 0.0
+	for i, row := range rows {
+		share := 0.0
+		if report.TotalValue > 0 {
+			share = row.Value / report.TotalValue * 100
+		}
+
+		// Rows arrive by value descending; a product belongs to the class its
+		// cumulative share starts in, so the top product is always class A
+		class := ABCClassC
+		switch {
+		case cumulative < classA:
+			class = ABCClassA
+		case cumulative < classB:
+			class = ABCClassB
+		}
+		cumulative += share
+
+		report.Products = append(report.Products, ABCProduct{
+			Rank:            i + 1,
+			ProductID:       row.ProductID,
+			ProductCode:     row.ProductCode,
+			ProductName:     row.ProductName,
+			CategoryID:      row.CategoryID,
+			CategoryName:    row.CategoryName,
+			Quantity:        row.Quantity,
+			Value:           row.Value,
+			Share:           share,
+			CumulativeShare: cumulative,
+			Class:           class,
+		})
+		summary := classes[class]
+		summary.Products++
+		summary.Quantity += row.Quantity
+		summary.Value += row.Value
+		summary.Share += share
+	}
+
+	for _, class := range []string{ABCClassA, ABCClassB, ABCClassC} {
+		report.Classes = append(report.Classes, *classes[class])
+	}
+	return report, nil
+}
+
+func (s *stockReportService) GetDeadStockReport(days, slowCoverDays int, warehouseID, officeID uuid.UUID) (*DeadStockReport, error) {
+	if days <= 0 {
+		return nil, errors.New("days must be greater than 0")
+	}
+	if slowCoverDays < 0 {
+		return nil, errors.New("slow cover days cannot be negative")
+	}
+
+	now := time.Now()
+	rows, err := s.repo.GetStockMovement(now.AddDate(0, 0, -days), warehouseID, officeID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DeadStockReport{Days: days, SlowCoverDays: slowCoverDays, GeneratedAt: now, Categories: []DeadStockCategory{}}
+	for _, row := range rows {
+		lastMoved := row.ReceivedAt
+		if row.LastOutgoingAt != nil && row.LastOutgoingAt.After(lastMoved) {
+			lastMoved = *row.LastOutgoingAt
+		}
+
+		item := DeadStockItem{
+			ProductID:        row.ProductID,
+			ProductCode:      row.ProductCode,
+			ProductName:      row.ProductName,
+			WarehouseID:      row.WarehouseID,
+			WarehouseName:    row.WarehouseName,
+			Quantity:         row.Quantity,
+			Value:            row.Value,
+			LastOutgoingAt:   row.LastOutgoingAt,
+			IdleDays:         int(now.Sub(lastMoved).Hours() / 24),
+			OutgoingQuantity: row.OutgoingQuantity,
+		}
+		switch {
+		case row.OutgoingQuantity == 0 && item.IdleDays >= days:
+			item.Class = MovementClassDead
+		case row.OutgoingQuantity > 0 && slowCoverDays > 0:
+			item.CoverDays = row.Quantity * days / row.OutgoingQuantity
+			if item.CoverDays > slowCoverDays {
+				item.Class = MovementClassSlow
+			}
+		}
+		if item.Class == "" {
+			continue
+		}
+
+		// Rows arrive sorted by category so a new category always starts a new group
+		if n := len(report.Categories); n == 0 || report.Categories[n-1].CategoryID != row.CategoryID {
+			report.Categories = append(report.Categories, DeadStockCategory{CategoryID: row.CategoryID, CategoryName: row.CategoryName})
+		}
+		category := &report.Categories[len(report.Categories)-1]
+		category.Items = append(category.Items, item)
+
+		if item.Class == MovementClassDead {
+			category.DeadValue += item.Value
+			report.DeadQuantity += item.Quantity
+			report.DeadValue += item.Value
+		} else {
+			category.SlowValue += item.Value
+			report.SlowQuantity += item.Quantity
+			report.SlowValue += item.Value
+		}
+	}
+
+	return report, nil
+}
+
+func (s *stockReportService) GetControlledRegister(period string, officeID uuid.UUID, class string) (*ControlledRegister, error) {
+	switch class {
+	case "", model.ControlledClassNarcotic, model.ControlledClassPsychotropic, model.ControlledClassPrecursor:
+	default:
+		return nil, fmt.Errorf("invalid controlled class %q: must be narcotic, psychotropic or precursor", class)
+	}
+	from, to, err := periodBounds(period)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repository.ControlledRegisterFilter{From: from, To: to, OfficeID: officeID, Class: class}
+	rows, err := s.repo.GetControlledRegister(filter)
+	if err != nil {
+		return nil, err
+	}
+	movements, err := s.repo.ListControlledMovements(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ControlledRegister{Period: period, From: from, To: to, Class: class, GeneratedAt: time.Now(), Offices: []ControlledRegisterOffice{}}
+	next := 0
+	for _, row := range rows {
+		// Rows arrive sorted by office so a new office always starts a new group
+		if n := len(report.Offices); n == 0 || !sameUUID(report.Offices[n-1].OfficeID, row.OfficeID) {
+			report.Offices = append(report.Offices, ControlledRegisterOffice{OfficeID: row.OfficeID, OfficeName: row.OfficeName})
+		}
+		office := &report.Offices[len(report.Offices)-1]
+
+		product := ControlledRegisterProduct{
+			ProductID:        row.ProductID,
+			ProductCode:      row.ProductCode,
+			ProductName:      row.ProductName,
+			ControlledClass:  row.ControlledClass,
+			Unit:             row.Unit,
+			Opening:          row.Opening,
+			In:               row.QuantityIn,
+			Out:              row.QuantityOut,
+			InternalTransfer: row.InternalTransfer,
+			Closing:          row.Opening + row.QuantityIn - row.QuantityOut + row.InternalTransfer,
+			Movements:        []ControlledMovement{},
+		}
+
+		// Movements come in the same order as the rows, so each product takes the run that matches it
+		balance := row.Opening
+		for ; next < len(movements); next++ {
+			m := movements[next]
+			if m.ProductID != row.ProductID || !sameUUID(m.OfficeID, row.OfficeID) {
+				break
+			}
+			movement := ControlledMovement{
+				EntryID:          m.EntryID,
+				Date:             m.Date,
+				WarehouseName:    m.WarehouseName,
+				Status:           m.Status,
+				BatchNumber:      m.BatchNumber,
+				ReferenceID:      m.ReferenceID,
+				Counterparty:     m.Counterparty,
+				AuthorizedBy:     m.AuthorizedBy,
+				AuthorizedByName: m.AuthorizedByName,
+				CreatedBy:        m.CreatedBy,
+				Username:         m.Username,
+				Notes:            m.Notes,
+			}
+			switch {
+			case m.Internal:
+				movement.InternalTransfer = m.Quantity
+			case m.Quantity > 0:
+				movement.In = m.Quantity
+			default:
+				movement.Out = -m.Quantity
+			}
+			balance += m.Quantity
+			movement.Balance = balance
+			product.Movements = append(product.Movements, movement)
+		}
+		office.Products = append(office.Products, product)
+	}
+
+	return report, nil
+}
+
+// sameUUID compares two optional IDs, treating two nils as equal
+func sameUUID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
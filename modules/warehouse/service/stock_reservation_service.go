@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StockReservationService interface {
+	GetAll(page, pageSize int, filter repository.StockReservationFilter) ([]model.StockReservation, int64, error)
+	GetByID(id uuid.UUID) (*model.StockReservation, error)
+	Create(reservation *model.StockReservation) error
+	Release(id uuid.UUID, userID uint) (*model.StockReservation, error)
+	// Fulfill issues the reserved stock and closes the reservation
+	Fulfill(id uuid.UUID, authorizedBy uint, counterparty string, userID uint) (*model.StockReservation, error)
+	GetAvailability(warehouseID, productID, officeID uuid.UUID) ([]repository.AvailabilityRow, error)
+	// Reserve holds stock inside an existing transaction, failing when less is available than requested
+	Reserve(tx *gorm.DB, reservation *model.StockReservation) error
+	// CloseByReference ends every active reservation of a document with the given status
+	CloseByReference(tx *gorm.DB, referenceID uuid.UUID, status string, userID uint) error
+	ExpireOverdue() (int64, error)
+}
+
+type stockReservationService struct {
+	db                *gorm.DB
+	repo              repository.StockReservationRepository
+	balanceRepo       repository.StockBalanceRepository
+	stockEntryService StockEntryService
+	productRepo       repository.ProductRepository
+	warehouseRepo     repository.WarehouseRepository
+}
+
+func NewStockReservationService(db *gorm.DB, repo repository.StockReservationRepository, balanceRepo repository.StockBalanceRepository, stockEntryService StockEntryService, productRepo repository.ProductRepository, warehouseRepo repository.WarehouseRepository) StockReservationService {
+	return &stockReservationService{
+		db:                db,
+		repo:              repo,
+		balanceRepo:       balanceRepo,
+		stockEntryService: stockEntryService,
+		productRepo:       productRepo,
+		warehouseRepo:     warehouseRepo,
+	}
+}
+
+func (s *stockReservationService) GetAll(page, pageSize int, filter repository.StockReservationFilter) ([]model.StockReservation, int64, error) {
+	return s.repo.GetAll(page, pageSize, filter)
+}
+
+func (s *stockReservationService) GetByID(id uuid.UUID) (*model.StockReservation, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *stockReservationService) Create(reservation *model.StockReservation) error {
+	if reservation.WarehouseID == uuid.Nil {
+		return errors.New("warehouse ID is required")
+	}
+	if reservation.ProductID == uuid.Nil {
+		return errors.New("product ID is required")
+	}
+	if reservation.ExpiresAt != nil && !reservation.ExpiresAt.After(time.Now()) {
+		return errors.New("invalid expiry: reservation would already be expired")
+	}
+
+	warehouse, err := s.warehouseRepo.GetByID(reservation.WarehouseID)
+	if err != nil {
+		return errors.New("failed to validate warehouse: " + err.Error())
+	}
+	if warehouse == nil {
+		return errors.New("warehouse not found")
+	}
+	product, err := s.productRepo.GetByID(reservation.ProductID)
+	if err != nil {
+		return errors.New("failed to validate product: " + err.Error())
+	}
+	if product == nil {
+		return errors.New("product not found")
+	}
+	reservation.Quantity, reservation.ConversionResidual, err = s.stockEntryService.ToBaseQuantity(product.ID, reservation.Quantity, reservation.Unit)
+	if err != nil {
+		return err
+	}
+	reservation.Unit = ""
+
+	original := *reservation
+	return stockTransaction(s.db, func(tx *gorm.DB) error {
+		*reservation = original
+		return s.Reserve(tx, reservation)
+	})
+}
+
+func (s *stockReservationService) Reserve(tx *gorm.DB, reservation *model.StockReservation) error {
+	if reservation.Quantity <= 0 {
+		return errors.New("reserved quantity must be greater than 0")
+	}
+
+	// Lock the product like issues do, so concurrent reservations and issues
+	// cannot both count the same stock as available
+	if _, err := s.balanceRepo.WithTx(tx).LockProduct(reservation.WarehouseID, reservation.ProductID); err != nil {
+		return err
+	}
+
+	repo := s.repo.WithTx(tx)
+	available, err := availableStock(repo, reservation.WarehouseID, reservation.ProductID)
+	if err != nil {
+		return err
+	}
+	if reservation.Quantity > available {
+		return fmt.Errorf("insufficient available stock: available %d, requested %d", available, reservation.Quantity)
+	}
+
+	reservation.Status = model.StockReservationStatusActive
+	return repo.Create(reservation)
+}
+
+func (s *stockReservationService) Release(id uuid.UUID, userID uint) (*model.StockReservation, error) {
+	var reservation *model.StockReservation
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		reservation, err = s.loadActive(repo, id)
+		if err != nil {
+			return err
+		}
+		s.close(reservation, model.StockReservationStatusReleased, userID)
+		return repo.Update(reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+func (s *stockReservationService) Fulfill(id uuid.UUID, authorizedBy uint, counterparty string, userID uint) (*model.StockReservation, error) {
+	var reservation *model.StockReservation
+	err := stockTransaction(s.db, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		// A second fulfilment waits here and then finds the reservation closed
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		reservation, err = s.loadActive(repo, id)
+		if err != nil {
+			return err
+		}
+
+		// Close first so the reserved quantity counts as available to the issue below
+		s.close(reservation, model.StockReservationStatusFulfilled, userID)
+		if err := repo.Update(reservation); err != nil {
+			return err
+		}
+
+		referenceID := reservation.ReferenceID
+		if referenceID == uuid.Nil {
+			referenceID = reservation.ID
+		}
+		_, err = s.stockEntryService.PostIssue(tx, &model.StockEntry{
+			WarehouseID:        reservation.WarehouseID,
+			ProductID:          reservation.ProductID,
+			BatchNumber:        reservation.BatchNumber,
+			Quantity:           -reservation.Quantity,
+			ConversionResidual: -reservation.ConversionResidual,
+			Status:             model.StockEntryStatusIssue,
+			ReferenceID:        referenceID,
+			Notes:              "Reservation fulfilled",
+			CreatedBy:          userID,
+			AuthorizedBy:       authorizedBy,
+			Counterparty:       counterparty,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+func (s *stockReservationService) GetAvailability(warehouseID, productID, officeID uuid.UUID) ([]repository.AvailabilityRow, error) {
+	return s.repo.GetAvailability(warehouseID, productID, officeID)
+}
+
+func (s *stockReservationService) CloseByReference(tx *gorm.DB, referenceID uuid.UUID, status string, userID uint) error {
+	repo := s.repo.WithTx(tx)
+	reservations, err := repo.GetActiveByReference(referenceID)
+	if err != nil {
+		return err
+	}
+	for i := range reservations {
+		s.close(&reservations[i], status, userID)
+		if err := repo.Update(&reservations[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *stockReservationService) ExpireOverdue() (int64, error) {
+	return s.repo.ExpireOverdue(time.Now())
+}
+
+// loadActive fetches a reservation that still holds stock
+func (s *stockReservationService) loadActive(repo repository.StockReservationRepository, id uuid.UUID) (*model.StockReservation, error) {
+	reservation, err := repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if reservation == nil {
+		return nil, errors.New("stock reservation not found")
+	}
+	if reservation.Status != model.StockReservationStatusActive {
+		return nil, fmt.Errorf("invalid reservation status: reservation is %s", reservation.Status)
+	}
+	if reservation.ExpiresAt != nil && !reservation.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("invalid reservation status: reservation has expired")
+	}
+	return reservation, nil
+}
+
+func (s *stockReservationService) close(reservation *model.StockReservation, status string, userID uint) {
+	now := time.Now()
+	reservation.Status = status
+	reservation.ReleasedAt = &now
+	reservation.ReleasedBy = userID
+}
+
+// availableStock returns the unreserved, unexpired stock of a product in a warehouse
+func availableStock(repo repository.StockReservationRepository, warehouseID, productID uuid.UUID) (int, error) {
+	rows, err := repo.GetAvailability(warehouseID, productID, uuid.Nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return rows[0].Available, nil
+}
+
+// StartReservationSweeper marks overdue reservations expired immediately and then once per interval until ctx is done
+func StartReservationSweeper(ctx context.Context, reservationService StockReservationService, interval time.Duration) {
+	sweep := func() {
+		expired, err := reservationService.ExpireOverdue()
+		if err != nil {
+			log.Printf("Reservation sweep failed: %v", err)
+			return
+		}
+		if expired > 0 {
+			log.Printf("Reservation sweep completed: %d reservations expired", expired)
+		}
+	}
+
+	go func() {
+		sweep()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sweep()
+			}
+		}
+	}()
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// stockTransactionAttempts bounds how often a stock transaction is retried after a conflict
+const stockTransactionAttempts = 3
+
+// Postgres SQLSTATE codes of conflicts that succeed when the transaction is run again
+const (
+	sqlStateUniqueViolation      = "23505" // two transactions created the same balance row
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+// stockTransaction runs fn in a transaction and retries it when it loses a
+// race with a concurrent stock movement. fn must be safe to run again from
+// scratch: every attempt starts from a rolled back database.
+func stockTransaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	var err error
+	for attempt := 1; attempt <= stockTransactionAttempts; attempt++ {
+		err = db.Transaction(fn)
+		if err == nil || !isStockConflict(err) {
+			return err
+		}
+		time.Sleep(time.Duration(attempt*attempt) * 10 * time.Millisecond)
+	}
+	return err
+}
+
+// isStockConflict reports whether err is a lock or key conflict with another transaction
+func isStockConflict(err error) bool {
+	var pgErr interface{ SQLState() string }
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	switch pgErr.SQLState() {
+	case sqlStateUniqueViolation, sqlStateSerializationFailure, sqlStateDeadlockDetected:
+		return true
+	}
+	return false
+}
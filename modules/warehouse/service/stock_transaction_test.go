@@ -0,0 +1,253 @@
+package service
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openStockTestDB connects to the Postgres database named by TEST_DATABASE_URL.
+// The tests need real row locks and unique indexes, so they skip without one.
+func openStockTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	if err := db.Exec(`CREATE EXTENSION IF NOT EXISTS "uuid-ossp"`).Error; err != nil {
+		t.Fatalf("create extension: %v", err)
+	}
+	err = db.AutoMigrate(
+		&model.Warehouse{},
+		&model.CategoryProduct{},
+		&model.Product{},
+		&model.UnitProduct{},
+		&model.ProductUnit{},
+		&model.ProductBarcode{},
+		&model.StockEntry{},
+		&model.StockBalance{},
+		&model.StockReservation{},
+		&model.StockPeriod{},
+		&model.Recall{},
+		&model.RecallTask{},
+	)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+// newStockTestService wires the stock entry service to real repositories and
+// seeds a warehouse and a product of its own, so tests do not share balances
+func newStockTestService(t *testing.T, db *gorm.DB) (StockEntryService, *model.Warehouse, *model.Product) {
+	t.Helper()
+	suffix := uuid.NewString()
+
+	warehouse := &model.Warehouse{Code: "WH-" + suffix, Name: "Concurrency test warehouse", Status: "active"}
+	if err := db.Create(warehouse).Error; err != nil {
+		t.Fatalf("seed warehouse: %v", err)
+	}
+	category := &model.CategoryProduct{Name: "Concurrency test category"}
+	if err := db.Create(category).Error; err != nil {
+		t.Fatalf("seed category: %v", err)
+	}
+	product := &model.Product{Code: "PRD-" + suffix, Name: "Concurrency test product", SmallUnit: "tablet", CategoryID: category.ID}
+	if err := db.Omit("Category").Create(product).Error; err != nil {
+		t.Fatalf("seed product: %v", err)
+	}
+
+	svc := NewStockEntryService(
+		db,
+		repository.NewStockEntryRepository(db),
+		repository.NewStockBalanceRepository(db),
+		repository.NewProductRepository(db),
+		repository.NewWarehouseRepository(db),
+		repository.NewStockReservationRepository(db),
+		repository.NewStockPeriodRepository(db),
+		repository.NewRecallRepository(db),
+		repository.NewUnitProductRepository(db),
+		repository.NewUserRoleRepository(db),
+		"controlled_authorizer",
+	)
+	return svc, warehouse, product
+}
+
+// assertBalance checks the balance row and the ledger of a batch against the expected on-hand quantity
+func assertBalance(t *testing.T, db *gorm.DB, warehouseID, productID uuid.UUID, batchNumber string, want int) {
+	t.Helper()
+	var balance model.StockBalance
+	err := db.Where("warehouse_id = ? AND product_id = ? AND batch_number = ?", warehouseID, productID, batchNumber).
+		First(&balance).Error
+	if err != nil {
+		t.Fatalf("load balance: %v", err)
+	}
+	if balance.Quantity != want {
+		t.Errorf("balance quantity = %d, want %d", balance.Quantity, want)
+	}
+
+	var ledger int
+	err = db.Model(&model.StockEntry{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("warehouse_id = ? AND product_id = ? AND batch_number = ?", warehouseID, productID, batchNumber).
+		Scan(&ledger).Error
+	if err != nil {
+		t.Fatalf("sum ledger: %v", err)
+	}
+	if ledger != want {
+		t.Errorf("ledger quantity = %d, want %d", ledger, want)
+	}
+
+	var negative int64
+	err = db.Model(&model.StockEntry{}).
+		Where("warehouse_id = ? AND product_id = ? AND stock < 0", warehouseID, productID).
+		Count(&negative).Error
+	if err != nil {
+		t.Fatalf("count negative entries: %v", err)
+	}
+	if negative > 0 {
+		t.Errorf("%d ledger rows left the balance below zero", negative)
+	}
+}
+
+func TestIssueConcurrentIssuesNeverGoNegative(t *testing.T) {
+	db := openStockTestDB(t)
+	svc, warehouse, product := newStockTestService(t, db)
+
+	const onHand = 10
+	const issues = 25
+	expiredAt := time.Now().AddDate(1, 0, 0)
+	err := svc.Receive(&model.StockEntry{
+		WarehouseID: warehouse.ID,
+		ProductID:   product.ID,
+		BatchNumber: "B1",
+		Quantity:    onHand,
+		ExpiredAt:   expiredAt,
+		CreatedBy:   1,
+	})
+	if err != nil {
+		t.Fatalf("receive: %v", err)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		failures  []error
+	)
+	start := make(chan struct{})
+	for i := 0; i < issues; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := svc.Issue(&model.StockEntry{
+				WarehouseID: warehouse.ID,
+				ProductID:   product.ID,
+				Quantity:    -1,
+				CreatedBy:   1,
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures = append(failures, err)
+				return
+			}
+			succeeded++
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if succeeded != onHand {
+		t.Errorf("succeeded issues = %d, want %d", succeeded, onHand)
+	}
+	for _, err := range failures {
+		if !strings.Contains(err.Error(), "insufficient") {
+			t.Errorf("issue failed with %v, want an insufficient stock error", err)
+		}
+	}
+	assertBalance(t, db, warehouse.ID, product.ID, "B1", 0)
+}
+
+func TestReceiveRetriesWhenFirstBalanceRowIsCreatedConcurrently(t *testing.T) {
+	db := openStockTestDB(t)
+	svc, warehouse, product := newStockTestService(t, db)
+
+	receipt := func(quantity int) *model.StockEntry {
+		return &model.StockEntry{
+			WarehouseID: warehouse.ID,
+			ProductID:   product.ID,
+			BatchNumber: "B1",
+			Quantity:    quantity,
+			Status:      model.StockEntryStatusReceipt,
+			ExpiredAt:   time.Now().AddDate(1, 0, 0),
+			CreatedBy:   1,
+		}
+	}
+
+	// The first receipt creates the balance row and keeps its transaction open,
+	// so the second one finds no row to lock and collides on the unique index
+	tx := db.Begin()
+	if err := svc.Post(tx, receipt(4)); err != nil {
+		tx.Rollback()
+		t.Fatalf("first receipt: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- svc.Receive(receipt(6))
+	}()
+
+	// Commit only once the second receipt is waiting on the balance row
+	if !waitForLockWait(db, 5*time.Second) {
+		tx.Rollback()
+		t.Fatal("second receipt never waited on the first balance row")
+	}
+	if err := tx.Commit().Error; err != nil {
+		t.Fatalf("commit first receipt: %v", err)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("second receipt was not retried: %v", err)
+	}
+	assertBalance(t, db, warehouse.ID, product.ID, "B1", 10)
+
+	var latest model.StockEntry
+	err := db.Where("warehouse_id = ? AND product_id = ?", warehouse.ID, product.ID).
+		Order("created_at DESC").First(&latest).Error
+	if err != nil {
+		t.Fatalf("load latest entry: %v", err)
+	}
+	if latest.PreviousStock != 4 || latest.Stock != 10 {
+		t.Errorf("retried receipt posted %d -> %d, want 4 -> 10", latest.PreviousStock, latest.Stock)
+	}
+}
+
+// waitForLockWait polls until another session of the test database is blocked on a lock
+func waitForLockWait(db *gorm.DB, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		var waiting int64
+		err := db.Raw("SELECT COUNT(*) FROM pg_stat_activity WHERE datname = current_database() AND wait_event_type = 'Lock'").
+			Scan(&waiting).Error
+		if err == nil && waiting > 0 {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
@@ -0,0 +1,461 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StockTransferService interface {
+	GetAll(page, pageSize int, status string) ([]model.StockTransfer, int64, error)
+	GetByID(id uuid.UUID) (*model.StockTransfer, error)
+	Create(transfer *model.StockTransfer) error
+	Update(id uuid.UUID, transfer *model.StockTransfer) error
+	Ship(id uuid.UUID, userID uint) (*model.StockTransfer, error)
+	Dispatch(id uuid.UUID) (*model.StockTransfer, error)
+	Receive(id uuid.UUID, receipt TransferReceipt, userID uint) (*model.StockTransfer, error)
+	Cancel(id uuid.UUID, userID uint) (*model.StockTransfer, error)
+}
+
+// TransferReceipt describes the quantities that arrived at the destination.
+// When Close is set, whatever is still outstanding is recorded as a discrepancy
+// and the transfer is marked received.
+type TransferReceipt struct {
+	Lines []TransferReceiptLine
+	Close bool
+}
+
+type TransferReceiptLine struct {
+	LineID           uuid.UUID
+	Quantity         int
+	Unit             string
+	DiscrepancyNotes string
+}
+
+type stockTransferService struct {
+	db                *gorm.DB
+	repo              repository.StockTransferRepository
+	stockEntryService StockEntryService
+	productRepo       repository.ProductRepository
+	warehouseRepo     repository.WarehouseRepository
+	reservations      StockReservationService
+}
+
+func NewStockTransferService(db *gorm.DB, repo repository.StockTransferRepository, stockEntryService StockEntryService, productRepo repository.ProductRepository, warehouseRepo repository.WarehouseRepository, reservations StockReservationService) StockTransferService {
+	return &stockTransferService{
+		db:                db,
+		repo:              repo,
+		stockEntryService: stockEntryService,
+		productRepo:       productRepo,
+		warehouseRepo:     warehouseRepo,
+		reservations:      reservations,
+	}
+}
+
+func (s *stockTransferService) GetAll(page, pageSize int, status string) ([]model.StockTransfer, int64, error) {
+	return s.repo.GetAll(page, pageSize, status)
+}
+
+func (s *stockTransferService) GetByID(id uuid.UUID) (*model.StockTransfer, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *stockTransferService) Create(transfer *model.StockTransfer) error {
+	if err := s.validateTransfer(transfer); err != nil {
+		return err
+	}
+
+	transfer.Number = generateDocumentNumber("TRF")
+	transfer.Status = model.StockTransferStatusDraft
+	original := *transfer
+	original.Lines = append([]model.StockTransferLine(nil), transfer.Lines...)
+	return stockTransaction(s.db, func(tx *gorm.DB) error {
+		*transfer = original
+		transfer.Lines = append([]model.StockTransferLine(nil), original.Lines...)
+		if err := s.repo.WithTx(tx).Create(transfer); err != nil {
+			return err
+		}
+		return s.reserveLines(tx, transfer)
+	})
+}
+
+func (s *stockTransferService) Update(id uuid.UUID, transfer *model.StockTransfer) error {
+	existing, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.New("stock transfer not found")
+	}
+	if existing.Status != model.StockTransferStatusDraft {
+		return errors.New("invalid transfer status: only draft transfers can be edited")
+	}
+	if err := s.validateTransfer(transfer); err != nil {
+		return err
+	}
+
+	existing.SourceWarehouseID = transfer.SourceWarehouseID
+	existing.DestinationWarehouseID = transfer.DestinationWarehouseID
+	existing.Notes = transfer.Notes
+	existing.Lines = transfer.Lines
+
+	err = stockTransaction(s.db, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		// The transfer may have shipped since it was read
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		if _, err := s.loadWithStatus(repo, id, model.StockTransferStatusDraft); err != nil {
+			return err
+		}
+		if err := repo.ReplaceLines(existing); err != nil {
+			return err
+		}
+		lines := existing.Lines
+		existing.Lines = nil
+		if err := repo.Update(existing); err != nil {
+			return err
+		}
+		existing.Lines = lines
+
+		// Release the old lines first so their stock counts towards the new ones
+		if err := s.reservations.CloseByReference(tx, existing.ID, model.StockReservationStatusReleased, existing.CreatedBy); err != nil {
+			return err
+		}
+		return s.reserveLines(tx, existing)
+	})
+	if err != nil {
+		return err
+	}
+
+	*transfer = *existing
+	return nil
+}
+
+// Ship issues every line out of the source warehouse
+func (s *stockTransferService) Ship(id uuid.UUID, userID uint) (*model.StockTransfer, error) {
+	var transfer *model.StockTransfer
+	err := stockTransaction(s.db, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		// A second shipment of the same transfer waits here and then finds it shipped
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		transfer, err = s.loadWithStatus(repo, id, model.StockTransferStatusDraft)
+		if err != nil {
+			return err
+		}
+		if err := s.reservations.CloseByReference(tx, transfer.ID, model.StockReservationStatusFulfilled, userID); err != nil {
+			return err
+		}
+		destination, err := s.warehouseName(transfer.DestinationWarehouseID)
+		if err != nil {
+			return err
+		}
+
+		// Lines without a batch are allocated by the warehouse issue policy and
+		// split into one line per issued batch, so each batch is received with
+		// its own expiry and cost
+		lines := make([]model.StockTransferLine, 0, len(transfer.Lines))
+		for _, line := range transfer.Lines {
+			entry := &model.StockEntry{
+				WarehouseID:        transfer.SourceWarehouseID,
+				ProductID:          line.ProductID,
+				BatchNumber:        line.BatchNumber,
+				Quantity:           -line.Quantity,
+				ConversionResidual: -line.ConversionResidual,
+				Status:             model.StockEntryStatusTransferOut,
+				ReferenceID:        transfer.ID,
+				Notes:              "Transfer " + transfer.Number,
+				CreatedBy:          userID,
+				AuthorizedBy:       userID,
+				Counterparty:       destination,
+			}
+			issued, err := s.stockEntryService.PostIssue(tx, entry)
+			if err != nil {
+				return err
+			}
+			for i, batch := range issued {
+				batchLine := line
+				if i > 0 {
+					batchLine.ID = uuid.New()
+					batchLine.ConversionResidual = 0
+				}
+				batchLine.BatchNumber = batch.BatchNumber
+				batchLine.Quantity = -batch.Quantity
+				batchLine.ExpiredAt = batch.ExpiredAt
+				batchLine.Price = batch.Price
+				lines = append(lines, batchLine)
+			}
+		}
+		transfer.Lines = lines
+
+		now := time.Now()
+		transfer.Status = model.StockTransferStatusShipped
+		transfer.ShippedAt = &now
+		transfer.ShippedBy = userID
+		return repo.Update(transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// Dispatch marks a shipped transfer as handed over to the carrier
+func (s *stockTransferService) Dispatch(id uuid.UUID) (*model.StockTransfer, error) {
+	var transfer *model.StockTransfer
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		// Lock so a receipt running meanwhile is not overwritten
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		transfer, err = s.loadWithStatus(repo, id, model.StockTransferStatusShipped)
+		if err != nil {
+			return err
+		}
+		transfer.Status = model.StockTransferStatusInTransit
+		return repo.Update(transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// Receive puts the arrived quantities into the destination warehouse
+func (s *stockTransferService) Receive(id uuid.UUID, receipt TransferReceipt, userID uint) (*model.StockTransfer, error) {
+	if len(receipt.Lines) == 0 && !receipt.Close {
+		return nil, errors.New("at least one receipt line is required")
+	}
+
+	var transfer *model.StockTransfer
+	err := stockTransaction(s.db, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		// Concurrent receipts of the transfer run one after the other, so none
+		// receives more than is still outstanding
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		transfer, err = s.loadWithStatus(repo, id, model.StockTransferStatusShipped, model.StockTransferStatusInTransit)
+		if err != nil {
+			return err
+		}
+		source, err := s.warehouseName(transfer.SourceWarehouseID)
+		if err != nil {
+			return err
+		}
+
+		for _, received := range receipt.Lines {
+			line := findTransferLine(transfer, received.LineID)
+			if line == nil {
+				return fmt.Errorf("transfer line %s not found", received.LineID)
+			}
+			if received.Quantity < 0 {
+				return errors.New("received quantity cannot be negative")
+			}
+			var residual float64
+			received.Quantity, residual, err = s.stockEntryService.ToBaseQuantity(line.ProductID, received.Quantity, received.Unit)
+			if err != nil {
+				return err
+			}
+			if received.Quantity > line.Outstanding() {
+				return fmt.Errorf("invalid received quantity for line %s: outstanding %d, received %d", line.ID, line.Outstanding(), received.Quantity)
+			}
+			if received.DiscrepancyNotes != "" {
+				line.DiscrepancyNotes = received.DiscrepancyNotes
+			}
+			if received.Quantity == 0 {
+				continue
+			}
+
+			entry := &model.StockEntry{
+				WarehouseID:        transfer.DestinationWarehouseID,
+				ProductID:          line.ProductID,
+				BatchNumber:        line.BatchNumber,
+				ExpiredAt:          line.ExpiredAt,
+				Price:              line.Price,
+				Quantity:           received.Quantity,
+				Status:             model.StockEntryStatusTransferIn,
+				ConversionResidual: residual,
+				ReferenceID:        transfer.ID,
+				Notes:              "Transfer " + transfer.Number,
+				CreatedBy:          userID,
+				AuthorizedBy:       userID,
+				Counterparty:       source,
+			}
+			if err := s.stockEntryService.Post(tx, entry); err != nil {
+				return err
+			}
+			line.ReceivedQuantity += received.Quantity
+		}
+
+		outstanding := 0
+		for i := range transfer.Lines {
+			line := &transfer.Lines[i]
+			if receipt.Close && line.Outstanding() > 0 {
+				line.DiscrepancyQuantity += line.Outstanding()
+			}
+			outstanding += line.Outstanding()
+		}
+
+		if outstanding == 0 {
+			now := time.Now()
+			transfer.Status = model.StockTransferStatusReceived
+			transfer.ReceivedAt = &now
+			transfer.ReceivedBy = userID
+		} else {
+			transfer.Status = model.StockTransferStatusInTransit
+		}
+		return repo.Update(transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// Cancel abandons a transfer that has not been shipped yet and releases its reservations
+func (s *stockTransferService) Cancel(id uuid.UUID, userID uint) (*model.StockTransfer, error) {
+	var transfer *model.StockTransfer
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		transfer, err = s.loadWithStatus(repo, id, model.StockTransferStatusDraft)
+		if err != nil {
+			return err
+		}
+		if err := s.reservations.CloseByReference(tx, transfer.ID, model.StockReservationStatusReleased, userID); err != nil {
+			return err
+		}
+		transfer.Status = model.StockTransferStatusCancelled
+		return repo.Update(transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// reserveLines holds the stock of every line at the source warehouse until the transfer ships
+func (s *stockTransferService) reserveLines(tx *gorm.DB, transfer *model.StockTransfer) error {
+	for _, line := range transfer.Lines {
+		reservation := &model.StockReservation{
+			WarehouseID:   transfer.SourceWarehouseID,
+			ProductID:     line.ProductID,
+			BatchNumber:   line.BatchNumber,
+			Quantity:      line.Quantity,
+			ReferenceType: model.ReservationReferenceStockTransfer,
+			ReferenceID:   transfer.ID,
+			Notes:         "Transfer " + transfer.Number,
+			CreatedBy:     transfer.CreatedBy,
+		}
+		if err := s.reservations.Reserve(tx, reservation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// warehouseName names the warehouse on the other side of a transfer for the controlled substance register
+func (s *stockTransferService) warehouseName(id uuid.UUID) (string, error) {
+	warehouse, err := s.warehouseRepo.GetByID(id)
+	if err != nil {
+		return "", err
+	}
+	if warehouse == nil {
+		return "", errors.New("warehouse not found")
+	}
+	return warehouse.Name, nil
+}
+
+// loadWithStatus fetches a transfer and checks it is in one of the allowed statuses
+func (s *stockTransferService) loadWithStatus(repo repository.StockTransferRepository, id uuid.UUID, allowed ...string) (*model.StockTransfer, error) {
+	transfer, err := repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer == nil {
+		return nil, errors.New("stock transfer not found")
+	}
+	for _, status := range allowed {
+		if transfer.Status == status {
+			return transfer, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid transfer status: transfer is %s", transfer.Status)
+}
+
+// validateTransfer checks warehouses, products and quantities of a transfer
+func (s *stockTransferService) validateTransfer(transfer *model.StockTransfer) error {
+	if transfer == nil {
+		return errors.New("stock transfer cannot be nil")
+	}
+	if transfer.SourceWarehouseID == uuid.Nil {
+		return errors.New("source warehouse ID is required")
+	}
+	if transfer.DestinationWarehouseID == uuid.Nil {
+		return errors.New("destination warehouse ID is required")
+	}
+	if transfer.SourceWarehouseID == transfer.DestinationWarehouseID {
+		return errors.New("invalid transfer: source and destination warehouse must differ")
+	}
+	if len(transfer.Lines) == 0 {
+		return errors.New("at least one transfer line is required")
+	}
+
+	for _, warehouseID := range []uuid.UUID{transfer.SourceWarehouseID, transfer.DestinationWarehouseID} {
+		warehouse, err := s.warehouseRepo.GetByID(warehouseID)
+		if err != nil {
+			return errors.New("failed to validate warehouse: " + err.Error())
+		}
+		if warehouse == nil {
+			return fmt.Errorf("warehouse %s not found", warehouseID)
+		}
+	}
+
+	for i := range transfer.Lines {
+		line := &transfer.Lines[i]
+		if line.Quantity <= 0 {
+			return errors.New("line quantity must be greater than 0")
+		}
+		product, err := s.productRepo.GetByID(line.ProductID)
+		if err != nil {
+			return errors.New("failed to validate product: " + err.Error())
+		}
+		if product == nil {
+			return fmt.Errorf("product %s not found", line.ProductID)
+		}
+		line.Quantity, line.ConversionResidual, err = s.stockEntryService.ToBaseQuantity(product.ID, line.Quantity, line.Unit)
+		if err != nil {
+			return err
+		}
+		line.Unit = ""
+	}
+	return nil
+}
+
+func findTransferLine(transfer *model.StockTransfer, lineID uuid.UUID) *model.StockTransferLine {
+	for i := range transfer.Lines {
+		if transfer.Lines[i].ID == lineID {
+			return &transfer.Lines[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StockValuationService interface {
+	GetValuationReport(asOf, from time.Time, officeID uuid.UUID) (*ValuationReport, error)
+	// WithTx returns a service that reads the ledger through the given transaction
+	WithTx(tx *gorm.DB) StockValuationService
+}
+
+// ValuationReport is the value of stock on hand as of a date, per warehouse
+// and per category, together with the cost of goods issued since From
+type ValuationReport struct {
+	AsOf              time.Time            `json:"as_of"`
+	From              time.Time            `json:"from"`
+	TotalQuantity     int                  `json:"total_quantity"`
+	TotalValue        float64              `json:"total_value"`
+	CostOfGoodsIssued float64              `json:"cost_of_goods_issued"`
+	Warehouses        []WarehouseValuation `json:"warehouses"`
+	Categories        []CategoryValuation  `json:"categories"`
+}
+
+type WarehouseValuation struct {
+	WarehouseID       uuid.UUID           `json:"warehouse_id"`
+	WarehouseName     string              `json:"warehouse_name"`
+	OfficeID          *uuid.UUID          `json:"office_id"`
+	ValuationMethod   string              `json:"valuation_method"`
+	Quantity          int                 `json:"quantity"`
+	Value             float64             `json:"value"`
+	CostOfGoodsIssued float64             `json:"cost_of_goods_issued"`
+	Categories        []CategoryValuation `json:"categories"`
+}
+
+type CategoryValuation struct {
+	CategoryID        uuid.UUID `json:"category_id"`
+	CategoryName      string    `json:"category_name"`
+	Quantity          int       `json:"quantity"`
+	Value             float64   `json:"value"`
+	CostOfGoodsIssued float64   `json:"cost_of_goods_issued"`
+}
+
+type stockValuationService struct {
+	repo       repository.StockValuationRepository
+	periodRepo repository.StockPeriodRepository
+}
+
+func NewStockValuationService(repo repository.StockValuationRepository, periodRepo repository.StockPeriodRepository) StockValuationService {
+	return &stockValuationService{repo: repo, periodRepo: periodRepo}
+}
+
+func (s *stockValuationService) WithTx(tx *gorm.DB) StockValuationService {
+	return NewStockValuationService(s.repo.WithTx(tx), s.periodRepo.WithTx(tx))
+}
+
+// GetValuationReport replays the ledger up to asOf through a cost pool per
+// warehouse and product, using the valuation method of the warehouse's office.
+// Only issues count towards the cost of goods issued; transfers and write-offs
+// reduce value without being treated as consumption. The report of a closed
+// month, from its first to its last day, is read from the period snapshot.
+func (s *stockValuationService) GetValuationReport(asOf, from time.Time, officeID uuid.UUID) (*ValuationReport, error) {
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	if !from.IsZero() && from.After(asOf) {
+		return nil, errors.New("invalid period: from must not be after as of")
+	}
+
+	if report, err := s.snapshotReport(asOf, from, officeID); err != nil || report != nil {
+		return report, err
+	}
+
+	rows, err := s.repo.GetLedger(asOf, officeID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ValuationReport{AsOf: asOf, From: from, Warehouses: []WarehouseValuation{}, Categories: []CategoryValuation{}}
+	totals := map[uuid.UUID]*CategoryValuation{}
+
+	var warehouse *WarehouseValuation
+	var categories map[uuid.UUID]*CategoryValuation
+	var pool costPool
+	var current repository.ValuationEntryRow
+
+	// flushProduct adds the remaining stock of the current pool to its warehouse and category
+	flushProduct := func() {
+		if pool == nil {
+			return
+		}
+		for _, category := range []*CategoryValuation{categoryTotal(categories, current), categoryTotal(totals, current)} {
+			category.Quantity += pool.quantity()
+			category.Value += pool.value()
+		}
+		warehouse.Quantity += pool.quantity()
+		warehouse.Value += pool.value()
+		report.TotalQuantity += pool.quantity()
+		report.TotalValue += pool.value()
+	}
+	// flushWarehouse appends the current warehouse with its categories sorted by name
+	flushWarehouse := func() {
+		if warehouse == nil {
+			return
+		}
+		warehouse.Categories = sortedCategories(categories)
+		report.Warehouses = append(report.Warehouses, *warehouse)
+	}
+
+	for _, row := range rows {
+		if warehouse == nil || warehouse.WarehouseID != row.WarehouseID {
+			flushProduct()
+			flushWarehouse()
+			warehouse = &WarehouseValuation{
+				WarehouseID:     row.WarehouseID,
+				WarehouseName:   row.WarehouseName,
+				OfficeID:        row.OfficeID,
+				ValuationMethod: row.ValuationMethod,
+			}
+			categories = map[uuid.UUID]*CategoryValuation{}
+			pool = nil
+		}
+		if pool == nil || current.ProductID != row.ProductID {
+			flushProduct()
+			pool = newCostPool(row.ValuationMethod)
+		}
+		current = row
+
+		if row.Quantity > 0 {
+			pool.receive(row.Quantity, receiptUnitCost(pool, row))
+			continue
+		}
+
+		cost := pool.issue(-row.Quantity)
+		if row.Status != model.StockEntryStatusIssue || row.Date.Before(from) {
+			continue
+		}
+		for _, category := range []*CategoryValuation{categoryTotal(categories, row), categoryTotal(totals, row)} {
+			category.CostOfGoodsIssued += cost
+		}
+		warehouse.CostOfGoodsIssued += cost
+		report.CostOfGoodsIssued += cost
+	}
+	flushProduct()
+	flushWarehouse()
+
+	report.Categories = sortedCategories(totals)
+	return report, nil
+}
+
+// snapshotReport returns the stored report when asOf and from span exactly one
+// closed period, or nil when the report has to be computed from the ledger
+func (s *stockValuationService) snapshotReport(asOf, from time.Time, officeID uuid.UUID) (*ValuationReport, error) {
+	period, err := s.periodRepo.GetLatestClosedAsOf(asOf)
+	if err != nil || period == nil || !coversPeriodEnd(period, asOf) || !from.Equal(period.StartAt) {
+		return nil, err
+	}
+
+	snapshot, err := s.periodRepo.GetValuationSnapshot(period.ID, officeID)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+
+	var report ValuationReport
+	if err := json.Unmarshal([]byte(snapshot.Report), &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// receiptUnitCost is the cost of incoming stock. Movements without a price,
+// such as count surpluses, come in at the current cost of the pool, falling
+// back to the product purchase price.
+func receiptUnitCost(pool costPool, row repository.ValuationEntryRow) float64 {
+	if row.Price > 0 {
+		return row.Price
+	}
+	if cost := pool.unitCost(); cost > 0 {
+		return cost
+	}
+	return row.PurchasePrice
+}
+
+// categoryTotal returns the running totals of the row's category, creating them on first use
+func categoryTotal(categories map[uuid.UUID]*CategoryValuation, row repository.ValuationEntryRow) *CategoryValuation {
+	category, ok := categories[row.CategoryID]
+	if !ok {
+		category = &CategoryValuation{CategoryID: row.CategoryID, CategoryName: row.CategoryName}
+		categories[row.CategoryID] = category
+	}
+	return category
+}
+
+func sortedCategories(categories map[uuid.UUID]*CategoryValuation) []CategoryValuation {
+	result := make([]CategoryValuation, 0, len(categories))
+	for _, category := range categories {
+		result = append(result, *category)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CategoryName != result[j].CategoryName {
+			return result[i].CategoryName < result[j].CategoryName
+		}
+		return result[i].CategoryID.String() < result[j].CategoryID.String()
+	})
+	return result
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SupplierReturnService interface {
+	GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.SupplierReturn, int64, error)
+	GetByID(id uuid.UUID) (*model.SupplierReturn, error)
+	Create(ret *model.SupplierReturn) error
+	// Post issues every line out of the receiving warehouse as return_out stock entries
+	Post(id uuid.UUID, userID uint) (*model.SupplierReturn, error)
+	Cancel(id uuid.UUID) (*model.SupplierReturn, error)
+}
+
+type supplierReturnService struct {
+	db                *gorm.DB
+	repo              repository.SupplierReturnRepository
+	receiptRepo       repository.GoodsReceiptRepository
+	orderRepo         repository.PurchaseOrderRepository
+	stockEntryService StockEntryService
+}
+
+func NewSupplierReturnService(db *gorm.DB, repo repository.SupplierReturnRepository, receiptRepo repository.GoodsReceiptRepository, orderRepo repository.PurchaseOrderRepository, stockEntryService StockEntryService) SupplierReturnService {
+	return &supplierReturnService{
+		db:                db,
+		repo:              repo,
+		receiptRepo:       receiptRepo,
+		orderRepo:         orderRepo,
+		stockEntryService: stockEntryService,
+	}
+}
+
+func (s *supplierReturnService) GetAll(page, pageSize int, warehouseID uuid.UUID, status string) ([]model.SupplierReturn, int64, error) {
+	return s.repo.GetAll(page, pageSize, warehouseID, status)
+}
+
+func (s *supplierReturnService) GetByID(id uuid.UUID) (*model.SupplierReturn, error) {
+	return s.repo.GetByID(id)
+}
+
+// Create drafts a return against a goods receipt. Product, batch and price of
+// every line are taken from the receipt line it references.
+func (s *supplierReturnService) Create(ret *model.SupplierReturn) error {
+	if ret == nil {
+		return errors.New("supplier return cannot be nil")
+	}
+	if ret.ReceiptID == uuid.Nil {
+		return errors.New("receipt ID is required")
+	}
+	if len(ret.Lines) == 0 {
+		return errors.New("at least one return line is required")
+	}
+
+	receipt, err := s.receiptRepo.GetByID(ret.ReceiptID)
+	if err != nil {
+		return err
+	}
+	if receipt == nil {
+		return errors.New("goods receipt not found")
+	}
+	order, err := s.orderRepo.GetByID(receipt.OrderID)
+	if err != nil {
+		return err
+	}
+	if order == nil {
+		return errors.New("purchase order not found")
+	}
+	// Quantities given in another unit are converted with the product of the receipt line
+	for i := range ret.Lines {
+		line := &ret.Lines[i]
+		for _, received := range receipt.Lines {
+			if received.ID != line.ReceiptLineID {
+				continue
+			}
+			line.Quantity, line.ConversionResidual, err = s.stockEntryService.ToBaseQuantity(received.ProductID, line.Quantity, line.Unit)
+			if err != nil {
+				return err
+			}
+			line.Unit = ""
+		}
+	}
+	if err := applyReceiptLines(receipt, ret); err != nil {
+		return err
+	}
+
+	ret.Number = generateDocumentNumber("RTS")
+	ret.Status = model.ReturnStatusDraft
+	ret.OrderID = receipt.OrderID
+	ret.SupplierID = order.SupplierID
+	ret.WarehouseID = receipt.WarehouseID
+	if ret.Date.IsZero() {
+		ret.Date = time.Now()
+	}
+	return s.repo.Create(ret)
+}
+
+func (s *supplierReturnService) Post(id uuid.UUID, userID uint) (*model.SupplierReturn, error) {
+	var ret *model.SupplierReturn
+	err := stockTransaction(s.db, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		receiptRepo := s.receiptRepo.WithTx(tx)
+
+		if err := repo.Lock(id); err != nil {
+			return err
+		}
+		var err error
+		ret, err = s.loadWithStatus(repo, id, model.ReturnStatusDraft)
+		if err != nil {
+			return err
+		}
+
+		// Check again against the receipt as other returns may have been posted
+		// since the draft. The lock keeps returns of the receipt from racing.
+		if err := receiptRepo.Lock(ret.ReceiptID); err != nil {
+			return err
+		}
+		receipt, err := receiptRepo.GetByID(ret.ReceiptID)
+		if err != nil {
+			return err
+		}
+		if receipt == nil {
+			return errors.New("goods receipt not found")
+		}
+		if err := applyReceiptLines(receipt, ret); err != nil {
+			return err
+		}
+		order, err := s.orderRepo.WithTx(tx).GetByID(ret.OrderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return errors.New("purchase order not found")
+		}
+
+		for i := range ret.Lines {
+			line := &ret.Lines[i]
+			entry := &model.StockEntry{
+				WarehouseID:        ret.WarehouseID,
+				ProductID:          line.ProductID,
+				BatchNumber:        line.BatchNumber,
+				Date:               ret.Date,
+				Quantity:           -line.Quantity,
+				ConversionResidual: -line.ConversionResidual,
+				Status:             model.StockEntryStatusReturnOut,
+				OrderID:            ret.OrderID,
+				ReferenceID:        ret.ReceiptID,
+				Notes:              "Supplier return " + ret.Number,
+				CreatedBy:          userID,
+				AuthorizedBy:       userID,
+				Counterparty:       order.SupplierName,
+			}
+			if err := s.stockEntryService.Post(tx, entry); err != nil {
+				return err
+			}
+			if err := receiptRepo.AddReturnedQuantity(line.ReceiptLineID, line.Quantity); err != nil {
+				return err
+			}
+			line.StockEntryID = entry.ID
+		}
+
+		now := time.Now()
+		ret.Status = model.ReturnStatusPosted
+		ret.PostedAt = &now
+		ret.PostedBy = userID
+		return repo.Update(ret)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Cancel abandons a return that has not been posted
+func (s *supplierReturnService) Cancel(id uuid.UUID) (*model.SupplierReturn, error) {
+	ret, err := s.loadWithStatus(s.repo, id, model.ReturnStatusDraft)
+	if err != nil {
+		return nil, err
+	}
+	ret.Status = model.ReturnStatusCancelled
+	if err := s.repo.Update(ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// loadWithStatus fetches a return and checks it is in one of the allowed statuses
+func (s *supplierReturnService) loadWithStatus(repo repository.SupplierReturnRepository, id uuid.UUID, allowed ...string) (*model.SupplierReturn, error) {
+	ret, err := repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return nil, errors.New("supplier return not found")
+	}
+	for _, status := range allowed {
+		if ret.Status == status {
+			return ret, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid return status: return is %s", ret.Status)
+}
+
+// applyReceiptLines copies product, batch and price from the referenced receipt
+// lines and checks no line returns more than is left of what was received
+func applyReceiptLines(receipt *model.GoodsReceipt, ret *model.SupplierReturn) error {
+	requested := map[uuid.UUID]int{}
+	for i := range ret.Lines {
+		line := &ret.Lines[i]
+		if line.Quantity <= 0 {
+			return errors.New("returned quantity must be greater than 0")
+		}
+
+		var received *model.GoodsReceiptLine
+		for j := range receipt.Lines {
+			if receipt.Lines[j].ID == line.ReceiptLineID {
+				received = &receipt.Lines[j]
+				break
+			}
+		}
+		if received == nil {
+			return fmt.Errorf("receipt line %s not found", line.ReceiptLineID)
+		}
+
+		requested[received.ID] += line.Quantity
+		if left := received.Quantity - received.ReturnedQuantity; requested[received.ID] > left {
+			return fmt.Errorf("invalid returned quantity for batch %q: %d left of the receipt, returning %d", received.BatchNumber, left, requested[received.ID])
+		}
+
+		line.ProductID = received.ProductID
+		line.BatchNumber = received.BatchNumber
+		line.Price = received.Price
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SupplierService interface {
+	GetAll(page, pageSize int, searchTerm, status string) ([]model.Supplier, int64, error)
+	GetByID(id uuid.UUID) (*model.Supplier, error)
+	Create(supplier *model.Supplier) error
+	Update(id uuid.UUID, supplier *model.Supplier) error
+	Delete(id uuid.UUID) error
+}
+
+type supplierService struct {
+	db   *gorm.DB
+	repo repository.SupplierRepository
+}
+
+func NewSupplierService(db *gorm.DB, repo repository.SupplierRepository) SupplierService {
+	return &supplierService{db: db, repo: repo}
+}
+
+func (s *supplierService) GetAll(page, pageSize int, searchTerm, status string) ([]model.Supplier, int64, error) {
+	return s.repo.GetAll(page, pageSize, searchTerm, status)
+}
+
+func (s *supplierService) GetByID(id uuid.UUID) (*model.Supplier, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *supplierService) Create(supplier *model.Supplier) error {
+	if err := s.validateSupplier(supplier, uuid.Nil); err != nil {
+		return err
+	}
+	return s.repo.Create(supplier)
+}
+
+func (s *supplierService) Update(id uuid.UUID, supplier *model.Supplier) error {
+	existing, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.New("supplier not found")
+	}
+	if err := s.validateSupplier(supplier, id); err != nil {
+		return err
+	}
+
+	supplier.ID = existing.ID // Ensure the ID is set for update
+	supplier.CreatedAt = existing.CreatedAt
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		if err := repo.Update(supplier); err != nil {
+			return err
+		}
+		return repo.ReplaceContacts(supplier)
+	})
+}
+
+func (s *supplierService) Delete(id uuid.UUID) error {
+	existing, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.New("supplier not found")
+	}
+	return s.repo.Delete(id)
+}
+
+// validateSupplier checks required fields, a unique code and the status.
+// An empty status defaults to active.
+func (s *supplierService) validateSupplier(supplier *model.Supplier, id uuid.UUID) error {
+	if supplier == nil {
+		return errors.New("supplier cannot be nil")
+	}
+	if supplier.Code == "" {
+		return errors.New("supplier code is required")
+	}
+	if supplier.Name == "" {
+		return errors.New("supplier name is required")
+	}
+	if supplier.PaymentTermDays < 0 {
+		return errors.New("payment term days cannot be negative")
+	}
+
+	switch supplier.Status {
+	case "":
+		supplier.Status = model.SupplierStatusActive
+	case model.SupplierStatusActive, model.SupplierStatusInactive:
+	default:
+		return errors.New("invalid supplier status: must be active or inactive")
+	}
+
+	for _, contact := range supplier.Contacts {
+		if contact.Name == "" {
+			return errors.New("contact name is required")
+		}
+	}
+
+	existing, err := s.repo.GetByCode(supplier.Code)
+	if err != nil {
+		return errors.New("failed to validate supplier code: " + err.Error())
+	}
+	if existing != nil && existing.ID != id {
+		return fmt.Errorf("invalid supplier code: %q is already used", supplier.Code)
+	}
+	return nil
+}
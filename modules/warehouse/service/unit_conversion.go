@@ -0,0 +1,156 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+)
+
+// unitFactor returns how many base units one unit of the product holds. The
+// unit is matched by UnitProduct code or ID against the packaging hierarchy,
+// or against the small and large unit of products without one. An empty unit
+// is the base unit.
+func unitFactor(product *model.Product, unit string) (int, error) {
+	if unit == "" {
+		return 1, nil
+	}
+
+	if len(product.Units) > 0 {
+		names := make([]string, 0, len(product.Units))
+		for _, level := range product.Units {
+			code := level.UnitID.String()
+			if level.Unit != nil {
+				code = level.Unit.Code
+			}
+			if strings.EqualFold(unit, code) || unit == level.UnitID.String() {
+				return level.Factor, nil
+			}
+			names = append(names, code)
+		}
+		return 0, fmt.Errorf("invalid unit %q for product %s: must be one of %s", unit, product.Code, strings.Join(names, ", "))
+	}
+
+	switch {
+	case strings.EqualFold(unit, product.SmallUnit):
+		return 1, nil
+	case strings.EqualFold(unit, product.LargeUnit):
+		return max(product.ContentPerLargeUnit, 1), nil
+	}
+	return 0, fmt.Errorf("invalid unit %q for product %s: must be %q or %q", unit, product.Code, product.LargeUnit, product.SmallUnit)
+}
+
+// productHasUnit reports whether the unit is part of the product's packaging
+func productHasUnit(product *model.Product, unit *model.UnitProduct) bool {
+	if len(product.Units) > 0 {
+		for _, level := range product.Units {
+			if level.UnitID == unit.ID {
+				return true
+			}
+		}
+		return false
+	}
+	return strings.EqualFold(unit.Code, product.SmallUnit) || strings.EqualFold(unit.Code, product.LargeUnit)
+}
+
+// toBaseQuantity converts a quantity given in a unit of the product to base
+// units. A unit outside the product's own units converts with the standard
+// factor of its dimension, for example lb against a product stocked in kg.
+// Stock is kept in whole base units, so such a conversion is rounded to the
+// nearest whole base unit, halves away from zero, and the residual (what was
+// given less what is stored, in base units) is returned for the caller to
+// record with the quantity. Conversions that round to nothing are rejected.
+func toBaseQuantity(productRepo repository.ProductRepository, unitRepo repository.UnitProductRepository, productID uuid.UUID, quantity int, unit string) (int, float64, error) {
+	if unit == "" || quantity == 0 {
+		return quantity, 0, nil
+	}
+	product, err := productRepo.GetByID(productID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if product == nil {
+		return 0, 0, errors.New("product not found")
+	}
+	factor, err := unitFactor(product, unit)
+	if err == nil {
+		return quantity * factor, 0, nil
+	}
+
+	from, lookupErr := unitRepo.GetByCode(unit)
+	if lookupErr != nil {
+		return 0, 0, lookupErr
+	}
+	base, lookupErr := unitRepo.GetByCode(baseUnitCode(product))
+	if lookupErr != nil {
+		return 0, 0, lookupErr
+	}
+	if from == nil || base == nil {
+		return 0, 0, err
+	}
+	converted, err := convertUnit(float64(quantity), from, base)
+	if err != nil {
+		return 0, 0, err
+	}
+	whole, residual, ok := roundConversion(quantity, from, base)
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid quantity: %d %s is %.6g %s, which does not fit a stock quantity", quantity, from.Code, converted, base.Code)
+	}
+	if whole == 0 {
+		return 0, 0, fmt.Errorf("invalid quantity: %d %s is %.6g %s, which rounds to no whole %s", quantity, from.Code, converted, base.Code, base.Code)
+	}
+	return whole, residual, nil
+}
+
+// roundConversion converts a quantity between two units of the same dimension in
+// exact decimal arithmetic and rounds it to a whole number, halves away from
+// zero. It returns the rounded quantity and what rounding left over. Factors are
+// read back in their shortest decimal form, the one they were entered in.
+func roundConversion(quantity int, from, to *model.UnitProduct) (int, float64, bool) {
+	fromFactor, ok := new(big.Rat).SetString(strconv.FormatFloat(from.Factor, 'g', -1, 64))
+	if !ok {
+		return 0, 0, false
+	}
+	toFactor, ok := new(big.Rat).SetString(strconv.FormatFloat(to.Factor, 'g', -1, 64))
+	if !ok || toFactor.Sign() == 0 {
+		return 0, 0, false
+	}
+	exact := new(big.Rat).SetInt64(int64(quantity))
+	exact.Mul(exact, fromFactor).Quo(exact, toFactor)
+
+	half := new(big.Rat).Add(new(big.Rat).Abs(exact), big.NewRat(1, 2))
+	whole := new(big.Int).Quo(half.Num(), half.Denom())
+	if exact.Sign() < 0 {
+		whole.Neg(whole)
+	}
+	if !whole.IsInt64() || whole.Int64() != int64(int(whole.Int64())) {
+		return 0, 0, false
+	}
+
+	residual, _ := new(big.Rat).Sub(exact, new(big.Rat).SetInt(whole)).Float64()
+	return int(whole.Int64()), residual, true
+}
+
+// convertUnit converts a quantity between two units of the same dimension
+func convertUnit(quantity float64, from, to *model.UnitProduct) (float64, error) {
+	if from.Dimension == "" || to.Dimension == "" || from.Factor <= 0 || to.Factor <= 0 {
+		return 0, fmt.Errorf("invalid conversion: %s to %s has no standard factor", from.Code, to.Code)
+	}
+	if from.Dimension != to.Dimension {
+		return 0, fmt.Errorf("invalid conversion: %s is a %s unit and %s a %s unit", from.Code, from.Dimension, to.Code, to.Dimension)
+	}
+	// Cut the noise of the float division, factors carry far fewer digits
+	return strconv.ParseFloat(strconv.FormatFloat(quantity*from.Factor/to.Factor, 'g', 12, 64), 64)
+}
+
+// baseUnitCode is the code of the unit stock of the product is kept in
+func baseUnitCode(product *model.Product) string {
+	if len(product.Units) > 0 && product.Units[0].Unit != nil {
+		return product.Units[0].Unit.Code
+	}
+	return product.SmallUnit
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
+	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
+)
+
+// fakeProductRepository serves one product; other methods are not used by the conversion
+type fakeProductRepository struct {
+	repository.ProductRepository
+	product *model.Product
+}
+
+func (r *fakeProductRepository) GetByID(id uuid.UUID) (*model.Product, error) {
+	if r.product == nil || r.product.ID != id {
+		return nil, nil
+	}
+	return r.product, nil
+}
+
+// fakeUnitRepository serves units by code, ignoring case like the real repository
+type fakeUnitRepository struct {
+	repository.UnitProductRepository
+	units []model.UnitProduct
+}
+
+func (r *fakeUnitRepository) GetByCode(code string) (*model.UnitProduct, error) {
+	for i := range r.units {
+		if strings.EqualFold(r.units[i].Code, code) {
+			return &r.units[i], nil
+		}
+	}
+	return nil, nil
+}
+
+var massUnits = []model.UnitProduct{
+	{Code: "kg", Dimension: model.UnitDimensionMass, Factor: 1},
+	{Code: "g", Dimension: model.UnitDimensionMass, Factor: 0.001},
+	{Code: "lb", Dimension: model.UnitDimensionMass, Factor: 0.45359237},
+	{Code: "oz", Dimension: model.UnitDimensionMass, Factor: 0.028349523125},
+	{Code: "l", Dimension: model.UnitDimensionVolume, Factor: 1},
+}
+
+func TestToBaseQuantityConvertsStandardUnits(t *testing.T) {
+	product := &model.Product{ID: uuid.New(), Code: "FLOUR", SmallUnit: "kg", LargeUnit: "sack", ContentPerLargeUnit: 25}
+	products := &fakeProductRepository{product: product}
+	units := &fakeUnitRepository{units: massUnits}
+
+	tests := []struct {
+		name         string
+		quantity     int
+		unit         string
+		want         int
+		wantResidual float64
+	}{
+		{name: "base unit", quantity: 7, unit: "", want: 7},
+		{name: "unit of the product", quantity: 2, unit: "sack", want: 50},
+		{name: "exact standard unit", quantity: 3000, unit: "g", want: 3},
+		{name: "lb rounded down", quantity: 100, unit: "lb", want: 45, wantResidual: 0.359237},
+		{name: "lb rounded up", quantity: 10, unit: "LB", want: 5, wantResidual: -0.4640763},
+		{name: "outgoing lb", quantity: -10, unit: "lb", want: -5, wantResidual: 0.4640763},
+		{name: "half rounds away from zero", quantity: 2500, unit: "g", want: 3, wantResidual: -0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, residual, err := toBaseQuantity(products, units, product.ID, tt.quantity, tt.unit)
+			if err != nil {
+				t.Fatalf("toBaseQuantity: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("quantity = %d, want %d", got, tt.want)
+			}
+			if math.Abs(residual-tt.wantResidual) > 1e-12 {
+				t.Errorf("residual = %v, want %v", residual, tt.wantResidual)
+			}
+		})
+	}
+}
+
+func TestToBaseQuantityRejectsUnusableUnits(t *testing.T) {
+	product := &model.Product{ID: uuid.New(), Code: "FLOUR", SmallUnit: "kg"}
+	products := &fakeProductRepository{product: product}
+	units := &fakeUnitRepository{units: massUnits}
+
+	tests := []struct {
+		name     string
+		quantity int
+		unit     string
+		wantErr  string
+	}{
+		{name: "rounds to nothing", quantity: 400, unit: "g", wantErr: "rounds to no whole kg"},
+		{name: "other dimension", quantity: 5, unit: "l", wantErr: "invalid conversion"},
+		{name: "unknown unit", quantity: 5, unit: "crate", wantErr: "invalid unit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := toBaseQuantity(products, units, product.ID, tt.quantity, tt.unit)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoundConversionKeepsDecimalFactorsExact(t *testing.T) {
+	oz := &massUnits[3]
+	g := &massUnits[1]
+
+	// 0.001 and 0.028349523125 are not exact in binary floating point
+	got, residual, ok := roundConversion(16, oz, g)
+	if !ok {
+		t.Fatal("conversion did not fit a stock quantity")
+	}
+	if got != 454 {
+		t.Errorf("16 oz = %d g, want 454", got)
+	}
+	if math.Abs(residual-(-0.40763)) > 1e-12 {
+		t.Errorf("residual = %v, want -0.40763", residual)
+	}
+
+	if got, residual, _ := roundConversion(1000, g, &massUnits[0]); got != 1 || residual != 0 {
+		t.Errorf("1000 g = %d kg with residual %v, want exactly 1", got, residual)
+	}
+}
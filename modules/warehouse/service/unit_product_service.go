@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
 	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
@@ -14,6 +15,17 @@ type UnitProductService interface {
 	Create(unitProduct *model.UnitProduct) error
 	Update(id uuid.UUID, unitProduct *model.UnitProduct) error
 	Delete(id uuid.UUID) error
+	// Convert converts a quantity between two units of the same dimension
+	Convert(quantity float64, from, to string) (*UnitConversion, error)
+}
+
+// UnitConversion is a quantity converted between two units
+type UnitConversion struct {
+	Quantity  float64 `json:"quantity"`
+	From      string  `json:"from"`
+	To        string  `json:"to"`
+	Dimension string  `json:"dimension"`
+	Result    float64 `json:"result"`
 }
 
 type unitProductService struct {
@@ -33,6 +45,9 @@ func (s *unitProductService) GetByID(id uuid.UUID) (*model.UnitProduct, error) {
 }
 
 func (s *unitProductService) Create(unitProduct *model.UnitProduct) error {
+	if err := validateUnitProduct(unitProduct); err != nil {
+		return err
+	}
 	return s.repo.Create(unitProduct)
 }
 
@@ -44,10 +59,61 @@ func (s *unitProductService) Update(id uuid.UUID, unitProduct *model.UnitProduct
 	if existing == nil {
 		return errors.New("unit product not found")
 	}
+	if err := validateUnitProduct(unitProduct); err != nil {
+		return err
+	}
 	unitProduct.ID = existing.ID // Ensure the ID is set for update
 	return s.repo.Update(unitProduct)
 }
 
 func (s *unitProductService) Delete(id uuid.UUID) error {
 	return s.repo.Delete(id)
-}
\ No newline at end of file
+}
+func (s *unitProductService) Convert(quantity float64, from, to string) (*UnitConversion, error) {
+	if from == "" {
+		return nil, errors.New("from unit is required")
+	}
+	if to == "" {
+		return nil, errors.New("to unit is required")
+	}
+
+	units := make([]*model.UnitProduct, 2)
+	for i, code := range []string{from, to} {
+		unit, err := s.repo.GetByCode(code)
+		if err != nil {
+			return nil, err
+		}
+		if unit == nil {
+			return nil, fmt.Errorf("unit %q not found", code)
+		}
+		units[i] = unit
+	}
+
+	result, err := convertUnit(quantity, units[0], units[1])
+	if err != nil {
+		return nil, err
+	}
+	return &UnitConversion{
+		Quantity:  quantity,
+		From:      units[0].Code,
+		To:        units[1].Code,
+		Dimension: units[0].Dimension,
+		Result:    result,
+	}, nil
+}
+
+// validateUnitProduct checks the dimension and standard factor of a unit
+func validateUnitProduct(unitProduct *model.UnitProduct) error {
+	switch unitProduct.Dimension {
+	case "", model.UnitDimensionMass, model.UnitDimensionVolume, model.UnitDimensionLength, model.UnitDimensionCount:
+	default:
+		return errors.New("invalid dimension: must be mass, volume, length or count")
+	}
+	if unitProduct.Factor < 0 {
+		return errors.New("factor cannot be negative")
+	}
+	if unitProduct.Factor > 0 && unitProduct.Dimension == "" {
+		return errors.New("dimension is required for a unit with a factor")
+	}
+	return nil
+}
@@ -5,14 +5,15 @@ import (
 
 	"github.com/antoniusDoni/monorepo/modules/warehouse/model"
 	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
+	"github.com/google/uuid"
 )
 
 type WarehouseService interface {
 	GetAll(page, pageSize int, searchTerm string) ([]model.Warehouse, int64, error)
-	GetByID(id uint) (*model.Warehouse, error)
+	GetByID(id uuid.UUID) (*model.Warehouse, error)
 	Create(warehouse *model.Warehouse) error
-	Update(id uint, warehouse *model.Warehouse) error
-	Delete(id uint) error
+	Update(id uuid.UUID, warehouse *model.Warehouse) error
+	Delete(id uuid.UUID) error
 }
 
 type warehouseService struct {
@@ -27,15 +28,18 @@ func (s *warehouseService) GetAll(page, pageSize int, searchTerm string) ([]mode
 	return s.repo.GetAll(page, pageSize, searchTerm)
 }
 
-func (s *warehouseService) GetByID(id uint) (*model.Warehouse, error) {
+func (s *warehouseService) GetByID(id uuid.UUID) (*model.Warehouse, error) {
 	return s.repo.GetByID(id)
 }
 
 func (s *warehouseService) Create(warehouse *model.Warehouse) error {
+	if err := validateIssuePolicy(warehouse); err != nil {
+		return err
+	}
 	return s.repo.Create(warehouse)
 }
 
-func (s *warehouseService) Update(id uint, warehouse *model.Warehouse) error {
+func (s *warehouseService) Update(id uuid.UUID, warehouse *model.Warehouse) error {
 	existing, err := s.repo.GetByID(id)
 	if err != nil {
 		return err
@@ -43,10 +47,25 @@ func (s *warehouseService) Update(id uint, warehouse *model.Warehouse) error {
 	if existing == nil {
 		return errors.New("warehouse not found")
 	}
+	if err := validateIssuePolicy(warehouse); err != nil {
+		return err
+	}
 	warehouse.ID = existing.ID // Ensure the ID is set for update
 	return s.repo.Update(warehouse)
 }
 
-func (s *warehouseService) Delete(id uint) error {
+func (s *warehouseService) Delete(id uuid.UUID) error {
 	return s.repo.Delete(id)
 }
+
+// validateIssuePolicy defaults an empty issue policy to FEFO and rejects unknown ones
+func validateIssuePolicy(warehouse *model.Warehouse) error {
+	switch warehouse.IssuePolicy {
+	case "":
+		warehouse.IssuePolicy = model.IssuePolicyFEFO
+	case model.IssuePolicyFEFO, model.IssuePolicyFIFO:
+	default:
+		return errors.New("invalid issue policy: must be fefo or fifo")
+	}
+	return nil
+}
@@ -1,7 +1,12 @@
 package warehouse
 
 import (
+	"context"
+	"errors"
 	"log"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/antoniusDoni/monorepo/modules/warehouse/handler"
 	"github.com/antoniusDoni/monorepo/modules/warehouse/repository"
@@ -10,6 +15,23 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	// DefaultExpiryAlertDays is how far ahead the daily scan looks for expiring batches
+	DefaultExpiryAlertDays = 30
+	// DefaultOverReceiptTolerance is the percentage a goods receipt may exceed the ordered quantity
+	DefaultOverReceiptTolerance = 0
+	// DefaultPriceVarianceTolerance is the percentage a received price may deviate from the ordered price
+	DefaultPriceVarianceTolerance = 5
+	// DefaultAdjustmentApprovalThreshold is the document value above which stock adjustments need approval
+	DefaultAdjustmentApprovalThreshold = 1000000
+	// DefaultQCRole is the role allowed to hold, release and reject batches
+	DefaultQCRole = "qc"
+	// DefaultControlledAuthorizerRole is the role allowed to authorize movements of controlled products
+	DefaultControlledAuthorizerRole = "controlled_authorizer"
+	// DefaultAdjustmentApproverRole is the role allowed to approve and reject stock adjustments
+	DefaultAdjustmentApproverRole = "adjustment_approver"
+)
+
 // ModuleDependencies holds the dependencies needed for the warehouse module
 type ModuleDependencies struct {
 	DB         *gorm.DB
@@ -27,16 +49,16 @@ func RegisterRoutes(apiGroup *echo.Group, deps *ModuleDependencies) error {
 	officeService := service.NewOfficeService(deps.OfficeRepo)
 	officeHandler := handler.NewOfficeHandler(officeService)
 
-	// Initialize category product repository (shared)
+	// Initialize category and unit product repositories (shared)
 	categoryProductRepo := repository.NewCategoryProductRepository(deps.DB)
+	unitProductRepo := repository.NewUnitProductRepository(deps.DB)
 
 	// Initialize product handler
 	productRepo := repository.NewProductRepository(deps.DB)
-	productService := service.NewProductService(productRepo, categoryProductRepo)
+	productService := service.NewProductService(productRepo, categoryProductRepo, unitProductRepo)
 	productHandler := handler.NewProductHandler(productService)
 
 	// Initialize unit product handler
-	unitProductRepo := repository.NewUnitProductRepository(deps.DB)
 	unitProductService := service.NewUnitProductService(unitProductRepo)
 	unitProductHandler := handler.NewUnitProductHandler(unitProductService)
 
@@ -44,6 +66,128 @@ func RegisterRoutes(apiGroup *echo.Group, deps *ModuleDependencies) error {
 	categoryProductService := service.NewCategoryProductService(categoryProductRepo)
 	categoryProductHandler := handler.NewCategoryProductHandler(categoryProductService)
 
+	// Initialize stock balance handler
+	stockBalanceRepo := repository.NewStockBalanceRepository(deps.DB)
+	stockPeriodRepo := repository.NewStockPeriodRepository(deps.DB)
+	stockBalanceService := service.NewStockBalanceService(deps.DB, stockBalanceRepo, stockPeriodRepo)
+	stockBalanceHandler := handler.NewStockBalanceHandler(stockBalanceService)
+
+	// Initialize stock entry handler
+	stockReservationRepo := repository.NewStockReservationRepository(deps.DB)
+	stockEntryRepo := repository.NewStockEntryRepository(deps.DB)
+	recallRepo := repository.NewRecallRepository(deps.DB)
+	userRoleRepo := repository.NewUserRoleRepository(deps.DB)
+	controlledAuthorizerRole := getRole("CONTROLLED_AUTHORIZER_ROLE", DefaultControlledAuthorizerRole)
+	if controlledAuthorizerRole == "" {
+		return errors.New("CONTROLLED_AUTHORIZER_ROLE must name the role allowed to authorize controlled product movements")
+	}
+	stockEntryService := service.NewStockEntryService(deps.DB, stockEntryRepo, stockBalanceRepo, productRepo, whRepo, stockReservationRepo, stockPeriodRepo, recallRepo, unitProductRepo, userRoleRepo, controlledAuthorizerRole)
+	stockEntryHandler := handler.NewStockEntryHandler(stockEntryService)
+
+	// Initialize QC handler
+	qcService := service.NewQCService(deps.DB, stockBalanceRepo, stockEntryService)
+	qcRole := getRole("QC_ROLE", DefaultQCRole)
+	if qcRole == "" {
+		return errors.New("QC_ROLE must name the role allowed to make QC decisions")
+	}
+	qcHandler := handler.NewQCHandler(qcService, qcRole)
+
+	// Initialize stock adjustment handlers
+	adjustmentReasonRepo := repository.NewAdjustmentReasonRepository(deps.DB)
+	adjustmentReasonService := service.NewAdjustmentReasonService(adjustmentReasonRepo)
+	adjustmentReasonHandler := handler.NewAdjustmentReasonHandler(adjustmentReasonService)
+	stockAdjustmentRepo := repository.NewStockAdjustmentRepository(deps.DB)
+	stockAdjustmentPolicy := service.StockAdjustmentPolicy{
+		ApprovalThreshold: getFloatEnv("ADJUSTMENT_APPROVAL_THRESHOLD", DefaultAdjustmentApprovalThreshold),
+	}
+	stockAdjustmentService := service.NewStockAdjustmentService(deps.DB, stockAdjustmentRepo, adjustmentReasonRepo, stockEntryRepo, stockEntryService, productRepo, whRepo, stockAdjustmentPolicy)
+	adjustmentApproverRole := getRole("ADJUSTMENT_APPROVER_ROLE", DefaultAdjustmentApproverRole)
+	if adjustmentApproverRole == "" {
+		return errors.New("ADJUSTMENT_APPROVER_ROLE must name the role allowed to approve stock adjustments")
+	}
+	stockAdjustmentHandler := handler.NewStockAdjustmentHandler(stockAdjustmentService, adjustmentApproverRole)
+
+	// Initialize stock reservation handler and the hourly expiry sweep
+	stockReservationService := service.NewStockReservationService(deps.DB, stockReservationRepo, stockBalanceRepo, stockEntryService, productRepo, whRepo)
+	stockReservationHandler := handler.NewStockReservationHandler(stockReservationService)
+	service.StartReservationSweeper(context.Background(), stockReservationService, time.Hour)
+
+	// Initialize stock transfer handler
+	stockTransferRepo := repository.NewStockTransferRepository(deps.DB)
+	stockTransferService := service.NewStockTransferService(deps.DB, stockTransferRepo, stockEntryService, productRepo, whRepo, stockReservationService)
+	stockTransferHandler := handler.NewStockTransferHandler(stockTransferService)
+
+	// Initialize stock count handler
+	stockCountRepo := repository.NewStockCountRepository(deps.DB)
+	stockCountService := service.NewStockCountService(deps.DB, stockCountRepo, stockBalanceRepo, stockEntryService, productRepo, whRepo)
+	stockCountHandler := handler.NewStockCountHandler(stockCountService)
+
+	// Initialize stock report handler
+	stockReportRepo := repository.NewStockReportRepository(deps.DB)
+	stockReportService := service.NewStockReportService(stockReportRepo, productRepo, whRepo)
+	stockReportHandler := handler.NewStockReportHandler(stockReportService)
+
+	// Initialize stock valuation handler
+	stockValuationRepo := repository.NewStockValuationRepository(deps.DB)
+	stockValuationService := service.NewStockValuationService(stockValuationRepo, stockPeriodRepo)
+	stockValuationHandler := handler.NewStockValuationHandler(stockValuationService)
+
+	// Initialize stock period handler
+	stockPeriodService := service.NewStockPeriodService(deps.DB, stockPeriodRepo, stockBalanceRepo, stockValuationService)
+	stockPeriodHandler := handler.NewStockPeriodHandler(stockPeriodService)
+
+	// Initialize supplier handler
+	supplierRepo := repository.NewSupplierRepository(deps.DB)
+	supplierService := service.NewSupplierService(deps.DB, supplierRepo)
+	supplierHandler := handler.NewSupplierHandler(supplierService)
+
+	// Initialize product supplier handler
+	productSupplierRepo := repository.NewProductSupplierRepository(deps.DB)
+	productSupplierService := service.NewProductSupplierService(deps.DB, productSupplierRepo, productRepo, supplierRepo)
+	productSupplierHandler := handler.NewProductSupplierHandler(productSupplierService)
+
+	// Initialize forecast handler
+	forecastService := service.NewForecastService(stockEntryRepo, productRepo, whRepo)
+	forecastHandler := handler.NewForecastHandler(forecastService)
+
+	// Initialize reorder handler
+	reorderSettingRepo := repository.NewReorderSettingRepository(deps.DB)
+	reorderService := service.NewReorderService(reorderSettingRepo, productSupplierRepo, productRepo, whRepo, forecastService)
+	reorderHandler := handler.NewReorderHandler(reorderService)
+
+	// Initialize purchase order handler
+	purchaseOrderRepo := repository.NewPurchaseOrderRepository(deps.DB)
+	goodsReceiptRepo := repository.NewGoodsReceiptRepository(deps.DB)
+	purchaseOrderPolicy := service.PurchaseOrderPolicy{
+		OverReceiptTolerance:   getFloatEnv("PO_OVER_RECEIPT_TOLERANCE", DefaultOverReceiptTolerance),
+		PriceVarianceTolerance: getFloatEnv("PO_PRICE_VARIANCE_TOLERANCE", DefaultPriceVarianceTolerance),
+	}
+	purchaseOrderService := service.NewPurchaseOrderService(deps.DB, purchaseOrderRepo, goodsReceiptRepo, stockEntryService, productRepo, whRepo, supplierRepo, productSupplierRepo, purchaseOrderPolicy)
+	purchaseOrderHandler := handler.NewPurchaseOrderHandler(purchaseOrderService)
+
+	// Initialize return handlers
+	supplierReturnRepo := repository.NewSupplierReturnRepository(deps.DB)
+	supplierReturnService := service.NewSupplierReturnService(deps.DB, supplierReturnRepo, goodsReceiptRepo, purchaseOrderRepo, stockEntryService)
+	supplierReturnHandler := handler.NewSupplierReturnHandler(supplierReturnService)
+	customerReturnRepo := repository.NewCustomerReturnRepository(deps.DB)
+	customerReturnService := service.NewCustomerReturnService(deps.DB, customerReturnRepo, stockEntryRepo, stockEntryService, whRepo)
+	customerReturnHandler := handler.NewCustomerReturnHandler(customerReturnService)
+
+	// Initialize landed cost handler
+	landedCostRepo := repository.NewLandedCostRepository(deps.DB)
+	landedCostService := service.NewLandedCostService(deps.DB, landedCostRepo, goodsReceiptRepo, stockPeriodRepo)
+	landedCostHandler := handler.NewLandedCostHandler(landedCostService)
+
+	// Initialize recall handler
+	recallService := service.NewRecallService(deps.DB, recallRepo, stockEntryRepo, stockBalanceRepo, productRepo, whRepo, stockEntryService)
+	recallHandler := handler.NewRecallHandler(recallService)
+
+	// Initialize stock alert handler and the daily expiry scan
+	stockAlertRepo := repository.NewStockAlertRepository(deps.DB)
+	stockAlertService := service.NewStockAlertService(stockAlertRepo, stockReportRepo)
+	stockAlertHandler := handler.NewStockAlertHandler(stockAlertService)
+	service.StartExpiryScanner(context.Background(), stockAlertService, getExpiryAlertDays(), 24*time.Hour)
+
 	// Register all handlers
 	handlers := []handler.RouteRegistrar{
 		whHandler,
@@ -51,6 +195,27 @@ func RegisterRoutes(apiGroup *echo.Group, deps *ModuleDependencies) error {
 		productHandler,
 		unitProductHandler,
 		categoryProductHandler,
+		stockEntryHandler,
+		stockBalanceHandler,
+		qcHandler,
+		stockAdjustmentHandler,
+		adjustmentReasonHandler,
+		stockReservationHandler,
+		stockTransferHandler,
+		stockCountHandler,
+		stockReportHandler,
+		stockValuationHandler,
+		stockPeriodHandler,
+		stockAlertHandler,
+		supplierHandler,
+		productSupplierHandler,
+		forecastHandler,
+		reorderHandler,
+		purchaseOrderHandler,
+		supplierReturnHandler,
+		customerReturnHandler,
+		landedCostHandler,
+		recallHandler,
 	}
 
 	for _, h := range handlers {
@@ -59,4 +224,35 @@ func RegisterRoutes(apiGroup *echo.Group, deps *ModuleDependencies) error {
 
 	log.Printf("Warehouse module: registered %d handlers", len(handlers))
 	return nil
-}
\ No newline at end of file
+}
+
+// getExpiryAlertDays gets the expiry alert window from environment or returns default
+func getExpiryAlertDays() int {
+	if days := os.Getenv("EXPIRY_ALERT_DAYS"); days != "" {
+		if val, err := strconv.Atoi(days); err == nil && val >= 0 {
+			return val
+		}
+		log.Printf("Invalid EXPIRY_ALERT_DAYS environment variable '%s', using default %d", days, DefaultExpiryAlertDays)
+	}
+	return DefaultExpiryAlertDays
+}
+
+// getRole gets a role required for guarded decisions from environment or returns
+// the default. An empty variable is returned as is and fails module startup.
+func getRole(name, def string) string {
+	if role, ok := os.LookupEnv(name); ok {
+		return role
+	}
+	return def
+}
+
+// getFloatEnv reads a non-negative number from the environment or returns the default
+func getFloatEnv(name string, def float64) float64 {
+	if value := os.Getenv(name); value != "" {
+		if val, err := strconv.ParseFloat(value, 64); err == nil && val >= 0 {
+			return val
+		}
+		log.Printf("Invalid %s environment variable '%s', using default %g", name, value, def)
+	}
+	return def
+}